@@ -23,6 +23,7 @@ import (
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
 	"github.com/sacloud/iaas-service-go/ftps"
+	"github.com/sacloud/iaas-service-go/progress"
 	"github.com/sacloud/packages-go/size"
 )
 
@@ -79,8 +80,10 @@ func (b *BlankArchiveBuilder) Build(ctx context.Context, zone string) (*iaas.Arc
 	// upload sources via FTPS
 	ftpsClient := ftps.NewClient(ftpServer.User, ftpServer.Password, ftpServer.HostName)
 
-	if err := ftpsClient.UploadReader("data.raw", b.SourceReader); err != nil {
-		return archive, fmt.Errorf("uploading file via FTPS is failed: %s", err)
+	progress.Step(ctx, "archive", archive.ID, "created")
+	reader := progress.NewReader(ctx, b.SourceReader, "archive", archive.ID, "upload", 0)
+	if err := ftpsClient.UploadReader("data.raw", reader); err != nil {
+		return archive, fmt.Errorf("uploading file via FTPS is failed: %w", err)
 	}
 
 	// close FTP
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) CloseFTP(req *CloseFTPRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) CloseFTP(req *CloseFTPRequest) error {
 }
 
 func (s *Service) CloseFTPWithContext(ctx context.Context, req *CloseFTPRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "archive", "close_ftp", req, s.closeFTP)
+}
+
+func (s *Service) closeFTP(ctx context.Context, req *CloseFTPRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewArchiveOp(s.caller)
@@ -16,11 +16,11 @@ package archive
 
 import (
 	"context"
-	"fmt"
 	"io"
 	"os"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 	builder2 "github.com/sacloud/iaas-service-go/archive/builder"
 )
 
@@ -29,8 +29,12 @@ func (s *Service) Create(req *CreateRequest) (*iaas.Archive, error) {
 }
 
 func (s *Service) CreateWithContext(ctx context.Context, req *CreateRequest) (*iaas.Archive, error) {
+	return service.Invoke(ctx, s.interceptors, "archive", "create", req, s.create)
+}
+
+func (s *Service) create(ctx context.Context, req *CreateRequest) (*iaas.Archive, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	var reader io.Reader
@@ -40,7 +44,7 @@ func (s *Service) CreateWithContext(ctx context.Context, req *CreateRequest) (*i
 	default:
 		file, err := os.Open(req.SourcePath)
 		if err != nil {
-			return nil, fmt.Errorf("reading source file[%s] failed: %s", req.SourcePath, err)
+			return nil, service.StepError("read", err)
 		}
 		defer file.Close()
 		reader = file
@@ -23,7 +23,8 @@ type DeleteRequest struct {
 	Zone string   `service:"-" validate:"required"`
 	ID   types.ID `service:"-" validate:"required"`
 
-	FailIfNotFound bool `service:"-"`
+	FailIfNotFound   bool `service:"-"`
+	FailIfReferenced bool `service:"-"` // trueの場合、他リソースから参照されている間は削除せずに参照元の一覧を含むエラーを返す
 }
 
 func (req *DeleteRequest) Validate() error {
@@ -18,6 +18,8 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/reference"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 )
 
@@ -26,8 +28,19 @@ func (s *Service) Delete(req *DeleteRequest) error {
 }
 
 func (s *Service) DeleteWithContext(ctx context.Context, req *DeleteRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "archive", "delete", req, s.delete)
+}
+
+func (s *Service) delete(ctx context.Context, req *DeleteRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
+	}
+
+	if req.FailIfReferenced {
+		inspector := &reference.Inspector{Caller: s.caller}
+		if err := inspector.Check(ctx, req.Zone, reference.KindArchive, req.ID); err != nil {
+			return serviceutil.HandleNotFoundError(err, !req.FailIfNotFound)
+		}
 	}
 
 	client := iaas.NewArchiveOp(s.caller)
@@ -22,7 +22,9 @@ import (
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/ftps"
+	"github.com/sacloud/iaas-service-go/progress"
 )
 
 func (s *Service) Download(req *DownloadRequest) error {
@@ -30,14 +32,18 @@ func (s *Service) Download(req *DownloadRequest) error {
 }
 
 func (s *Service) DownloadWithContext(ctx context.Context, req *DownloadRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "archive", "download", req, s.download)
+}
+
+func (s *Service) download(ctx context.Context, req *DownloadRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewArchiveOp(s.caller)
 	resource, err := client.Read(ctx, req.Zone, req.ID)
 	if err != nil {
-		return fmt.Errorf("reading Archive[%s] failed: %s", req.ID, err)
+		return service.StepError("read", err)
 	}
 
 	if resource.Scope != types.Scopes.User {
@@ -46,28 +52,41 @@ func (s *Service) DownloadWithContext(ctx context.Context, req *DownloadRequest)
 
 	ftpServer, err := client.OpenFTP(ctx, req.Zone, req.ID, &iaas.OpenFTPRequest{ChangePassword: true})
 	if err != nil {
-		return fmt.Errorf("requesting FTP server information failed: %s", err)
+		return fmt.Errorf("requesting FTP server information failed: %w", err)
 	}
 
 	ftpsClient := ftps.NewClient(ftpServer.User, ftpServer.Password, ftpServer.HostName)
+	total := int64(resource.SizeMB) * 1024 * 1024
 	switch req.Path {
 	case "":
 		var out io.Writer = os.Stdout
 		if req.Writer != nil {
 			out = req.Writer
 		}
+		out = progress.NewWriter(ctx, out, "archive", req.ID, "download", total)
 		if err := ftpsClient.DownloadWriter(out); err != nil {
-			return fmt.Errorf("downloading via FTP failed: %s", err)
+			return fmt.Errorf("downloading via FTP failed: %w", err)
 		}
 	default:
-		if err := ftpsClient.Download(req.Path); err != nil {
-			return fmt.Errorf("downloading via FTP failed: %s", err)
+		f, err := os.Create(req.Path)
+		if err != nil {
+			return fmt.Errorf("downloading via FTP failed: %w", err)
+		}
+		err = ftpsClient.DownloadWriter(progress.NewWriter(ctx, f, "archive", req.ID, "download", total))
+		// 書き込みの失敗がCloseで判明する場合もあるためCloseのエラーも確認する
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			// 途中までのファイルを残さない
+			os.Remove(req.Path) //nolint:errcheck
+			return fmt.Errorf("downloading via FTP failed: %w", err)
 		}
 	}
 
 	// close
 	if err := client.CloseFTP(ctx, req.Zone, req.ID); err != nil {
-		return fmt.Errorf("closing FTP server failed: %s", err)
+		return fmt.Errorf("closing FTP server failed: %w", err)
 	}
 	return nil
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) OpenFTP(req *OpenFTPRequest) (*iaas.FTPServer, error) {
@@ -25,8 +26,12 @@ func (s *Service) OpenFTP(req *OpenFTPRequest) (*iaas.FTPServer, error) {
 }
 
 func (s *Service) OpenFTPWithContext(ctx context.Context, req *OpenFTPRequest) (*iaas.FTPServer, error) {
+	return service.Invoke(ctx, s.interceptors, "archive", "open_ftp", req, s.openFTP)
+}
+
+func (s *Service) openFTP(ctx context.Context, req *OpenFTPRequest) (*iaas.FTPServer, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 	client := iaas.NewArchiveOp(s.caller)
 	return client.OpenFTP(ctx, req.Zone, req.ID, &iaas.OpenFTPRequest{ChangePassword: req.ChangePassword})
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Read(req *ReadRequest) (*iaas.Archive, error) {
@@ -25,8 +26,12 @@ func (s *Service) Read(req *ReadRequest) (*iaas.Archive, error) {
 }
 
 func (s *Service) ReadWithContext(ctx context.Context, req *ReadRequest) (*iaas.Archive, error) {
+	return service.Invoke(ctx, s.interceptors, "archive", "read", req, s.read)
+}
+
+func (s *Service) read(ctx context.Context, req *ReadRequest) (*iaas.Archive, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 	client := iaas.NewArchiveOp(s.caller)
 	return client.Read(ctx, req.Zone, req.ID)
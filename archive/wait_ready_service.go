@@ -18,7 +18,8 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
-	"github.com/sacloud/iaas-api-go/helper/wait"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/progress"
 )
 
 func (s *Service) WaitReady(req *WaitReadyRequest) error {
@@ -26,11 +27,17 @@ func (s *Service) WaitReady(req *WaitReadyRequest) error {
 }
 
 func (s *Service) WaitReadyWithContext(ctx context.Context, req *WaitReadyRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "archive", "wait_ready", req, s.waitReady)
+}
+
+func (s *Service) waitReady(ctx context.Context, req *WaitReadyRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewArchiveOp(s.caller)
-	_, err := wait.UntilArchiveIsReady(ctx, client, req.Zone, req.ID)
+	_, err := progress.WaitForState(ctx, iaas.WaiterForReady(func() (interface{}, error) {
+		return client.Read(ctx, req.Zone, req.ID)
+	}), "archive", req.ID, "copy")
 	return err
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Read() (*iaas.AuthStatus, error) {
@@ -25,6 +26,12 @@ func (s *Service) Read() (*iaas.AuthStatus, error) {
 }
 
 func (s *Service) ReadWithContext(ctx context.Context) (*iaas.AuthStatus, error) {
+	return service.Invoke[interface{}, *iaas.AuthStatus](ctx, s.interceptors, "authstatus", "read", nil, func(ctx context.Context, _ interface{}) (*iaas.AuthStatus, error) {
+		return s.read(ctx)
+	})
+}
+
+func (s *Service) read(ctx context.Context) (*iaas.AuthStatus, error) {
 	client := iaas.NewAuthStatusOp(s.caller)
 	return client.Read(ctx)
 }
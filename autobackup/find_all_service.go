@@ -0,0 +1,34 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autobackup
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+func (s *Service) FindAll(req *FindRequest) ([]*iaas.AutoBackup, error) {
+	return s.FindAllWithContext(context.Background(), req)
+}
+
+func (s *Service) FindAllWithContext(ctx context.Context, req *FindRequest) ([]*iaas.AutoBackup, error) {
+	return service.Invoke(ctx, s.interceptors, "autobackup", "find_all", req, s.findAll)
+}
+
+func (s *Service) findAll(ctx context.Context, req *FindRequest) ([]*iaas.AutoBackup, error) {
+	return s.FindIterWithContext(ctx, req).All()
+}
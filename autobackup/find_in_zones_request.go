@@ -0,0 +1,55 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autobackup
+
+import (
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/search"
+	"github.com/sacloud/packages-go/validate"
+)
+
+type FindInZonesRequest struct {
+	// Zones 検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones
+	Zones []string `service:"-" validate:"omitempty,unique"`
+
+	Names []string `service:"-"`
+	Tags  []string `service:"-"`
+
+	Sort  search.SortKeys
+	Count int
+	From  int
+}
+
+func (req *FindInZonesRequest) Validate() error {
+	return validate.New().Struct(req)
+}
+
+func (req *FindInZonesRequest) ToFindRequest(zone string) *FindRequest {
+	return &FindRequest{
+		Zone:  zone,
+		Names: req.Names,
+		Tags:  req.Tags,
+		Sort:  req.Sort,
+		Count: req.Count,
+		From:  req.From,
+	}
+}
+
+func (req *FindInZonesRequest) zones() []string {
+	if len(req.Zones) == 0 {
+		return iaas.SakuraCloudZones
+	}
+	return req.Zones
+}
@@ -16,9 +16,9 @@ package autobackup
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Update(req *UpdateRequest) (*iaas.AutoBackup, error) {
@@ -26,19 +26,23 @@ func (s *Service) Update(req *UpdateRequest) (*iaas.AutoBackup, error) {
 }
 
 func (s *Service) UpdateWithContext(ctx context.Context, req *UpdateRequest) (*iaas.AutoBackup, error) {
+	return service.Invoke(ctx, s.interceptors, "autobackup", "update", req, s.update)
+}
+
+func (s *Service) update(ctx context.Context, req *UpdateRequest) (*iaas.AutoBackup, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewAutoBackupOp(s.caller)
 	current, err := client.Read(ctx, req.Zone, req.ID)
 	if err != nil {
-		return nil, fmt.Errorf("reading AutoBackup[%s] failed: %s", req.ID, err)
+		return nil, service.StepError("read", err)
 	}
 
 	params, err := req.ToRequestParameter(current)
 	if err != nil {
-		return nil, fmt.Errorf("processing request parameter failed: %s", err)
+		return nil, service.StepError("process request parameter", err)
 	}
 
 	return client.Update(ctx, req.Zone, req.ID, params)
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Create(req *CreateRequest) (*iaas.AutoScale, error) {
@@ -25,8 +26,12 @@ func (s *Service) Create(req *CreateRequest) (*iaas.AutoScale, error) {
 }
 
 func (s *Service) CreateWithContext(ctx context.Context, req *CreateRequest) (*iaas.AutoScale, error) {
+	return service.Invoke(ctx, s.interceptors, "autoscale", "create", req, s.create)
+}
+
+func (s *Service) create(ctx context.Context, req *CreateRequest) (*iaas.AutoScale, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	params, err := req.ToRequestParameter()
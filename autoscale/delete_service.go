@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 )
 
@@ -26,8 +27,12 @@ func (s *Service) Delete(req *DeleteRequest) error {
 }
 
 func (s *Service) DeleteWithContext(ctx context.Context, req *DeleteRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "autoscale", "delete", req, s.delete)
+}
+
+func (s *Service) delete(ctx context.Context, req *DeleteRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewAutoScaleOp(s.caller)
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Status(req *StatusRequest) (*iaas.AutoScaleStatus, error) {
@@ -25,8 +26,12 @@ func (s *Service) Status(req *StatusRequest) (*iaas.AutoScaleStatus, error) {
 }
 
 func (s *Service) StatusWithContext(ctx context.Context, req *StatusRequest) (*iaas.AutoScaleStatus, error) {
+	return service.Invoke(ctx, s.interceptors, "autoscale", "status", req, s.status)
+}
+
+func (s *Service) status(ctx context.Context, req *StatusRequest) (*iaas.AutoScaleStatus, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 	client := iaas.NewAutoScaleOp(s.caller)
 	return client.Status(ctx, req.ID)
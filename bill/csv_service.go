@@ -19,6 +19,7 @@ import (
 	"errors"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Csv(req *CsvRequest) (*iaas.BillDetailCSV, error) {
@@ -26,8 +27,12 @@ func (s *Service) Csv(req *CsvRequest) (*iaas.BillDetailCSV, error) {
 }
 
 func (s *Service) CsvWithContext(ctx context.Context, req *CsvRequest) (*iaas.BillDetailCSV, error) {
+	return service.Invoke(ctx, s.interceptors, "bill", "csv", req, s.csv)
+}
+
+func (s *Service) csv(ctx context.Context, req *CsvRequest) (*iaas.BillDetailCSV, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	billOp := iaas.NewBillOp(s.caller)
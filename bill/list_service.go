@@ -19,6 +19,7 @@ import (
 	"errors"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) List(req *ListRequest) ([]*iaas.Bill, error) {
@@ -26,8 +27,12 @@ func (s *Service) List(req *ListRequest) ([]*iaas.Bill, error) {
 }
 
 func (s *Service) ListWithContext(ctx context.Context, req *ListRequest) ([]*iaas.Bill, error) {
+	return service.Invoke(ctx, s.interceptors, "bill", "list", req, s.list)
+}
+
+func (s *Service) list(ctx context.Context, req *ListRequest) ([]*iaas.Bill, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	billOp := iaas.NewBillOp(s.caller)
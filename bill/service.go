@@ -14,14 +14,18 @@
 
 package bill
 
-import "github.com/sacloud/iaas-api-go"
+import (
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
 
 // Service provides a high-level API of for Bill
 type Service struct {
-	caller iaas.APICaller
+	caller       iaas.APICaller
+	interceptors []service.Interceptor
 }
 
 // New returns new service instance of Bill
-func New(caller iaas.APICaller) *Service {
-	return &Service{caller: caller}
+func New(caller iaas.APICaller, opts ...service.Option) *Service {
+	return &Service{caller: caller, interceptors: service.NewOptions(opts...).Interceptors}
 }
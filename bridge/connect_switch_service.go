@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) ConnectSwitch(req *ConnectSwitchRequest) error {
@@ -26,8 +27,12 @@ func (s *Service) ConnectSwitch(req *ConnectSwitchRequest) error {
 }
 
 func (s *Service) ConnectSwitchWithContext(ctx context.Context, req *ConnectSwitchRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "bridge", "connect_switch", req, s.connectSwitch)
+}
+
+func (s *Service) connectSwitch(ctx context.Context, req *ConnectSwitchRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	bridgeOp := iaas.NewBridgeOp(s.caller)
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) DisconnectSwitch(req *DisconnectSwitchRequest) error {
@@ -26,12 +27,16 @@ func (s *Service) DisconnectSwitch(req *DisconnectSwitchRequest) error {
 }
 
 func (s *Service) DisconnectSwitchWithContext(ctx context.Context, req *DisconnectSwitchRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "bridge", "disconnect_switch", req, s.disconnectSwitch)
+}
+
+func (s *Service) disconnectSwitch(ctx context.Context, req *DisconnectSwitchRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	bridgeOp := iaas.NewBridgeOp(s.caller)
@@ -0,0 +1,39 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bridge
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+func (s *Service) FindInZones(req *FindInZonesRequest) ([]*service.Zoned[*iaas.Bridge], error) {
+	return s.FindInZonesWithContext(context.Background(), req)
+}
+
+func (s *Service) FindInZonesWithContext(ctx context.Context, req *FindInZonesRequest) ([]*service.Zoned[*iaas.Bridge], error) {
+	return service.Invoke(ctx, s.interceptors, "bridge", "find_in_zones", req, s.findInZones)
+}
+
+func (s *Service) findInZones(ctx context.Context, req *FindInZonesRequest) ([]*service.Zoned[*iaas.Bridge], error) {
+	if err := req.Validate(); err != nil {
+		return nil, service.ValidationError(err)
+	}
+	return service.FindInZones(ctx, req.zones(), func(ctx context.Context, zone string) ([]*iaas.Bridge, error) {
+		return s.FindAllWithContext(ctx, req.ToFindRequest(zone))
+	})
+}
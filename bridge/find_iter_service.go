@@ -0,0 +1,35 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bridge
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+func (s *Service) FindIter(req *FindRequest) service.FindIterator[*iaas.Bridge] {
+	return s.FindIterWithContext(context.Background(), req)
+}
+
+func (s *Service) FindIterWithContext(ctx context.Context, req *FindRequest) service.FindIterator[*iaas.Bridge] {
+	return service.NewFindIterator(ctx, req.From, req.Count, func(ctx context.Context, from, count int) ([]*iaas.Bridge, error) {
+		page := *req
+		page.From = from
+		page.Count = count
+		return s.FindWithContext(ctx, &page)
+	})
+}
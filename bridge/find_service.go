@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Find(req *FindRequest) ([]*iaas.Bridge, error) {
@@ -25,8 +26,12 @@ func (s *Service) Find(req *FindRequest) ([]*iaas.Bridge, error) {
 }
 
 func (s *Service) FindWithContext(ctx context.Context, req *FindRequest) ([]*iaas.Bridge, error) {
+	return service.Invoke(ctx, s.interceptors, "bridge", "find", req, s.find)
+}
+
+func (s *Service) find(ctx context.Context, req *FindRequest) ([]*iaas.Bridge, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	params, err := req.ToRequestParameter()
@@ -0,0 +1,231 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bulk 複数のリクエストに対するサービスの操作を並行して実行する
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/progress"
+)
+
+const (
+	// DefaultParallelism 同時実行数のデフォルト値
+	DefaultParallelism = 10
+	// DefaultRetryInterval リトライ間隔のデフォルト値
+	DefaultRetryInterval = 5 * time.Second
+)
+
+// Mode エラー発生時の動作
+type Mode int
+
+const (
+	// BestEffort エラーが発生しても残りのリクエストを実行する
+	BestEffort Mode = iota
+	// StopOnFirstError 最初のエラーで未実行のリクエストの実行を取りやめる
+	StopOnFirstError
+)
+
+// Options 一括実行のオプション
+type Options struct {
+	// Parallelism 全体での同時実行数、省略時はDefaultParallelism
+	Parallelism int
+	// PerZoneParallelism ゾーンごとの同時実行数、0の場合はゾーンごとの制限を行わない
+	//
+	// リクエストのゾーンはZoneフィールドから判定する
+	PerZoneParallelism int
+	// Mode エラー発生時の動作
+	Mode Mode
+
+	// RetryCount 失敗時のリトライ回数、0の場合はリトライしない
+	RetryCount int
+	// RetryInterval リトライ間隔、省略時はDefaultRetryInterval
+	RetryInterval time.Duration
+	// Retryable リトライ対象のエラーであるかを判定する関数
+	//
+	// 省略時はバリデーションエラー/NotFound/シャットダウンが必要なエラー以外をリトライする
+	Retryable func(err error) bool
+}
+
+func (o *Options) init() *Options {
+	opts := &Options{}
+	if o != nil {
+		*opts = *o
+	}
+	if opts.Parallelism <= 0 {
+		opts.Parallelism = DefaultParallelism
+	}
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = DefaultRetryInterval
+	}
+	if opts.Retryable == nil {
+		opts.Retryable = isRetryable
+	}
+	return opts
+}
+
+func isRetryable(err error) bool {
+	return !errors.Is(err, service.ErrValidationFailed) &&
+		!errors.Is(err, service.ErrNotFound) &&
+		!errors.Is(err, service.ErrNeedsShutdown) &&
+		!errors.Is(err, context.Canceled)
+}
+
+// Result リクエストごとの実行結果
+type Result[Req, Res any] struct {
+	// Index リクエストの添字
+	Index   int
+	Request Req
+	Value   Res
+	Err     error
+	// Attempts 試行回数
+	Attempts int
+	// Skipped StopOnFirstErrorにより実行されなかった場合true
+	Skipped bool
+}
+
+// Results 実行結果のリスト、リクエストと同じ順に並ぶ
+type Results[Req, Res any] []*Result[Req, Res]
+
+// Values 成功したリクエストの戻り値を返す
+func (r Results[Req, Res]) Values() []Res {
+	var values []Res
+	for _, result := range r {
+		if result.Err == nil && !result.Skipped {
+			values = append(values, result.Value)
+		}
+	}
+	return values
+}
+
+// Failed 失敗したリクエストの結果を返す
+func (r Results[Req, Res]) Failed() Results[Req, Res] {
+	var failed Results[Req, Res]
+	for _, result := range r {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err 失敗したリクエストのエラーをまとめて返す、全て成功した場合はnil
+func (r Results[Req, Res]) Err() error {
+	var errs []error
+	for _, result := range r.Failed() {
+		errs = append(errs, fmt.Errorf("[%d]: %w", result.Index, result.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// Func 一括実行の対象となる関数、各サービスのXxxWithContextメソッドを指定する
+type Func[Req, Res any] func(ctx context.Context, req Req) (Res, error)
+
+// NoResult 戻り値がerrorのみのメソッドをFuncとして扱うための変換を行う
+//
+// 例: bulk.Run(ctx, reqs, bulk.NoResult(svc.BootWithContext), nil)
+func NoResult[Req any](fn func(ctx context.Context, req Req) error) Func[Req, struct{}] {
+	return func(ctx context.Context, req Req) (struct{}, error) {
+		return struct{}{}, fn(ctx, req)
+	}
+}
+
+// Run reqsの各リクエストに対してfnを並行して実行する
+//
+// 戻り値のResultsはreqsと同じ順に並ぶ。いずれかのリクエストが失敗した場合はResults.Err()の値を返す。
+// StopOnFirstErrorの場合、エラー発生後は未実行のリクエストを実行しないが、実行中のリクエストは中断しない。
+// contextにprogress.Observerが設定されている場合は完了したリクエスト数を通知する。
+func Run[Req, Res any](ctx context.Context, reqs []Req, fn Func[Req, Res], opts *Options) (Results[Req, Res], error) {
+	opts = opts.init()
+
+	stopCtx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	results := make(Results[Req, Res], len(reqs))
+	limiter := newLimiter(opts.Parallelism, opts.PerZoneParallelism)
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		done int64
+	)
+	for i, req := range reqs {
+		result := &Result[Req, Res]{Index: i, Request: req, Skipped: true}
+		results[i] = result
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			zone, _ := service.TargetOf(result.Request)
+			if !limiter.acquire(stopCtx, zone) {
+				return
+			}
+			defer limiter.release(zone)
+			if stopCtx.Err() != nil {
+				return
+			}
+
+			result.Skipped = false
+			result.Value, result.Attempts, result.Err = call(ctx, result.Request, fn, opts)
+			if result.Err != nil && opts.Mode == StopOnFirstError {
+				stop()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+			progress.Notify(ctx, &progress.Event{
+				Type:    progress.EventProgress,
+				Step:    "bulk",
+				Current: done,
+				Total:   int64(len(reqs)),
+				Unit:    "items",
+			})
+		}()
+	}
+	wg.Wait()
+
+	return results, results.Err()
+}
+
+func call[Req, Res any](ctx context.Context, req Req, fn Func[Req, Res], opts *Options) (Res, int, error) {
+	var (
+		value Res
+		err   error
+	)
+	for attempt := 1; ; attempt++ {
+		value, err = fn(ctx, req)
+		if err == nil || attempt > opts.RetryCount || !opts.Retryable(err) {
+			return value, attempt, err
+		}
+		progress.Notify(ctx, &progress.Event{
+			Type:        progress.EventRetry,
+			Step:        "bulk",
+			Attempt:     attempt,
+			MaxAttempts: opts.RetryCount,
+			Message:     err.Error(),
+		})
+		select {
+		case <-ctx.Done():
+			return value, attempt, err
+		case <-time.After(opts.RetryInterval):
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sacloud/iaas-api-go/testutil"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/swytch"
+	"github.com/stretchr/testify/require"
+)
+
+type dummyRequest struct {
+	Zone  string
+	Value int
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("best effort", func(t *testing.T) {
+		var reqs []*dummyRequest
+		for i := 0; i < 10; i++ {
+			reqs = append(reqs, &dummyRequest{Value: i})
+		}
+		results, err := Run(ctx, reqs, func(_ context.Context, req *dummyRequest) (int, error) {
+			if req.Value%3 == 0 {
+				return 0, fmt.Errorf("error%d", req.Value)
+			}
+			return req.Value * 2, nil
+		}, &Options{Parallelism: 3})
+
+		require.Error(t, err)
+		require.Len(t, results, 10)
+		require.Len(t, results.Failed(), 4)
+		require.Equal(t, []int{2, 4, 8, 10, 14, 16}, results.Values())
+		require.EqualError(t, results[3].Err, "error3")
+	})
+
+	t.Run("stop on first error", func(t *testing.T) {
+		var reqs []*dummyRequest
+		for i := 0; i < 10; i++ {
+			reqs = append(reqs, &dummyRequest{Value: i})
+		}
+		var called int32
+		results, err := Run(ctx, reqs, NoResult(func(_ context.Context, req *dummyRequest) error {
+			atomic.AddInt32(&called, 1)
+			return errors.New("error")
+		}), &Options{Parallelism: 1, Mode: StopOnFirstError})
+
+		require.Error(t, err)
+		require.Len(t, results.Failed(), 1)
+		require.EqualValues(t, 1, called)
+		skipped := 0
+		for _, r := range results {
+			if r.Skipped {
+				skipped++
+			}
+		}
+		require.Equal(t, 9, skipped)
+	})
+
+	t.Run("per zone parallelism", func(t *testing.T) {
+		var reqs []*dummyRequest
+		for i := 0; i < 20; i++ {
+			reqs = append(reqs, &dummyRequest{Zone: []string{"is1a", "tk1a"}[i%2]})
+		}
+		var (
+			mu      sync.Mutex
+			running = map[string]int{}
+			max     = map[string]int{}
+		)
+		_, err := Run(ctx, reqs, NoResult(func(_ context.Context, req *dummyRequest) error {
+			mu.Lock()
+			running[req.Zone]++
+			if running[req.Zone] > max[req.Zone] {
+				max[req.Zone] = running[req.Zone]
+			}
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			running[req.Zone]--
+			mu.Unlock()
+			return nil
+		}), &Options{Parallelism: 10, PerZoneParallelism: 2})
+
+		require.NoError(t, err)
+		require.LessOrEqual(t, max["is1a"], 2)
+		require.LessOrEqual(t, max["tk1a"], 2)
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		var attempts int32
+		results, err := Run(ctx, []*dummyRequest{{Value: 1}, {}}, func(_ context.Context, req *dummyRequest) (string, error) {
+			if req.Value == 1 && atomic.AddInt32(&attempts, 1) <= 2 {
+				return "", errors.New("temporary error")
+			}
+			return "ok", nil
+		}, &Options{RetryCount: 2, RetryInterval: time.Millisecond})
+
+		require.NoError(t, err)
+		require.Equal(t, 3, results[0].Attempts)
+		require.Equal(t, 1, results[1].Attempts)
+	})
+
+	t.Run("not retryable", func(t *testing.T) {
+		results, err := Run(ctx, []*dummyRequest{{}}, NoResult(func(context.Context, *dummyRequest) error {
+			return service.NewError(service.ErrValidationFailed, errors.New("invalid"))
+		}), &Options{RetryCount: 3, RetryInterval: time.Millisecond})
+
+		require.True(t, errors.Is(err, service.ErrValidationFailed))
+		require.Equal(t, 1, results[0].Attempts)
+	})
+}
+
+func TestRun_Service(t *testing.T) {
+	svc := swytch.New(testutil.SingletonAPICaller())
+	zone := testutil.TestZone()
+
+	var reqs []*swytch.CreateRequest
+	for i := 0; i < 5; i++ {
+		reqs = append(reqs, &swytch.CreateRequest{
+			Zone: zone,
+			Name: testutil.ResourceName(fmt.Sprintf("service-bulk-%d", i)),
+		})
+	}
+
+	results, err := Run(context.Background(), reqs, svc.CreateWithContext, &Options{Parallelism: 2})
+	require.NoError(t, err)
+	for i, sw := range results.Values() {
+		require.Equal(t, reqs[i].Name, sw.Name)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"context"
+	"sync"
+)
+
+// limiter 全体およびゾーンごとの同時実行数を制限する
+type limiter struct {
+	total   chan struct{}
+	perZone int
+	zones   map[string]chan struct{}
+	mu      sync.Mutex
+}
+
+func newLimiter(total, perZone int) *limiter {
+	return &limiter{
+		total:   make(chan struct{}, total),
+		perZone: perZone,
+		zones:   make(map[string]chan struct{}),
+	}
+}
+
+func (l *limiter) zone(zone string) chan struct{} {
+	if l.perZone <= 0 || zone == "" {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.zones[zone]; !ok {
+		l.zones[zone] = make(chan struct{}, l.perZone)
+	}
+	return l.zones[zone]
+}
+
+// acquire 実行枠を確保する、ctxが終了した場合はfalseを返す
+func (l *limiter) acquire(ctx context.Context, zone string) bool {
+	if z := l.zone(zone); z != nil {
+		select {
+		case z <- struct{}{}:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	select {
+	case l.total <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		if z := l.zone(zone); z != nil {
+			<-z
+		}
+		return false
+	}
+}
+
+func (l *limiter) release(zone string) {
+	<-l.total
+	if z := l.zone(zone); z != nil {
+		<-z
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cassette 実際のAPIとのやりとりを記録/再生するiaas.APICallerを提供する
+//
+// Recorderで記録したカセットファイルをPlayerで再生することで、実際のAPIのレスポンスを用いたテストをオフラインで行える。
+package cassette
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Interaction 1回分のAPI呼び出し
+type Interaction struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// Request リクエストボディ、GETの場合はクエリ文字列として送信されるJSON
+	Request json.RawMessage `json:"request,omitempty"`
+	// Response レスポンスボディ
+	Response json.RawMessage `json:"response,omitempty"`
+	// Error API呼び出しがエラーとなった場合のエラー
+	Error *Error `json:"error,omitempty"`
+}
+
+// Error 記録されたエラー
+type Error struct {
+	// StatusCode APIエラーの場合のレスポンスコード、APIエラー以外の場合は0
+	StatusCode int    `json:"status_code,omitempty"`
+	Code       string `json:"code,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Serial     string `json:"serial,omitempty"`
+}
+
+// Cassette 記録されたAPI呼び出しのリスト
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+
+	mu sync.Mutex
+}
+
+// New 空のCassetteを返す
+func New() *Cassette {
+	return &Cassette{}
+}
+
+// Load pathからカセットを読み込む
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := New()
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Add API呼び出しを追加する
+func (c *Cassette) Add(interaction *Interaction) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Interactions = append(c.Interactions, interaction)
+}
+
+// Save pathへカセットを保存する、親ディレクトリが存在しない場合は作成する
+func (c *Cassette) Save(path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644) //nolint:gosec
+}
@@ -0,0 +1,103 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassette
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/swytch"
+	"github.com/stretchr/testify/require"
+)
+
+// dummyCaller 実際のAPIの代わりに固定のレスポンスを返す
+type dummyCaller struct{}
+
+func (d *dummyCaller) Do(_ context.Context, method, uri string, _ interface{}) ([]byte, error) {
+	switch {
+	case method == http.MethodPost:
+		return []byte(`{"is_ok":true,"Success":true,"Switch":{"ID":"123456789012","Name":"recorded","Password":"p@ssw0rd"}}`), nil
+	case method == http.MethodGet && strings.HasSuffix(uri, "/123456789012"):
+		return []byte(`{"is_ok":true,"Switch":{"ID":"123456789012","Name":"recorded"}}`), nil
+	}
+	return nil, iaas.NewAPIError(method, nil, http.StatusNotFound, &iaas.APIErrorResponse{ErrorCode: "not_found", ErrorMessage: "not found"})
+}
+
+func TestCassette(t *testing.T) {
+	zone := "is1a"
+	path := filepath.Join(t.TempDir(), "switch.json")
+
+	// record
+	c := New()
+	svc := swytch.New(NewRecorder(&dummyCaller{}, c))
+	created, err := svc.Create(&swytch.CreateRequest{Zone: zone, Name: "recorded"})
+	require.NoError(t, err)
+	_, err = svc.Read(&swytch.ReadRequest{Zone: zone, ID: created.ID})
+	require.NoError(t, err)
+	_, err = svc.Read(&swytch.ReadRequest{Zone: zone, ID: types.ID(1)})
+	require.Error(t, err)
+	require.NoError(t, c.Save(path))
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, loaded.Interactions, 3)
+	require.NotContains(t, string(loaded.Interactions[0].Response), "p@ssw0rd")
+
+	t.Run("in order", func(t *testing.T) {
+		player := NewPlayer(loaded, InOrder)
+		svc := swytch.New(player)
+
+		replayed, err := svc.Create(&swytch.CreateRequest{Zone: zone, Name: "recorded"})
+		require.NoError(t, err)
+		require.Equal(t, created.ID, replayed.ID)
+
+		_, err = svc.Read(&swytch.ReadRequest{Zone: zone, ID: types.ID(2)})
+		require.EqualError(t, err, fmt.Sprintf(
+			"read switch[%s:2] failed: cassette: unexpected request GET %s/%s/api/cloud/1.1/switch/2, recorded: %s %s",
+			zone, iaas.SakuraCloudAPIRoot, zone, loaded.Interactions[1].Method, loaded.Interactions[1].URL,
+		))
+	})
+
+	t.Run("match request", func(t *testing.T) {
+		player := NewPlayer(loaded, MatchRequest)
+		svc := swytch.New(player)
+
+		_, err := svc.Read(&swytch.ReadRequest{Zone: zone, ID: types.ID(1)})
+		require.True(t, errors.Is(err, service.ErrNotFound))
+
+		for i := 0; i < 2; i++ {
+			read, err := svc.Read(&swytch.ReadRequest{Zone: zone, ID: created.ID})
+			require.NoError(t, err)
+			require.Equal(t, "recorded", read.Name)
+		}
+		require.Equal(t, 1, player.Remaining())
+	})
+}
+
+func TestScrub(t *testing.T) {
+	require.JSONEq(t,
+		`{"Server":{"Name":"foo","Password":"********"},"Keys":[{"PrivateKey":"********","PublicKey":"ssh-rsa"}]}`,
+		string(Scrub([]byte(`{"Server":{"Name":"foo","Password":"secret"},"Keys":[{"PrivateKey":"-----BEGIN","PublicKey":"ssh-rsa"}]}`))),
+	)
+	require.Equal(t, "not json", string(Scrub([]byte("not json"))))
+}
@@ -0,0 +1,154 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassette
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sync"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+// Mode Playerがリクエストに対応するInteractionを選ぶ方法
+type Mode int
+
+const (
+	// InOrder 記録された順に返す、メソッドとURLが一致しない場合はエラーとなる
+	InOrder Mode = iota
+	// MatchRequest メソッド/URL/リクエストボディが一致するInteractionのうち未使用のものを記録順に返す
+	//
+	// 未使用のものがない場合は最後に一致したものを返す(状態のポーリングなどで呼び出し回数が変わる場合向け)
+	MatchRequest
+)
+
+// Player Cassetteに記録されたレスポンスを返すiaas.APICaller
+type Player struct {
+	cassette *Cassette
+	mode     Mode
+
+	next int
+	used []bool
+	mu   sync.Mutex
+}
+
+// NewPlayer cassetteを再生するPlayerを返す
+func NewPlayer(cassette *Cassette, mode Mode) *Player {
+	return &Player{
+		cassette: cassette,
+		mode:     mode,
+		used:     make([]bool, len(cassette.Interactions)),
+	}
+}
+
+// Do iaas.APICallerの実装
+func (p *Player) Do(ctx context.Context, method, uri string, body interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var req []byte
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		req = Scrub(data)
+	}
+	uri = ScrubURL(uri)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var interaction *Interaction
+	switch p.mode {
+	case InOrder:
+		if p.next >= len(p.cassette.Interactions) {
+			return nil, fmt.Errorf("cassette: no more interactions for %s %s", method, uri)
+		}
+		interaction = p.cassette.Interactions[p.next]
+		if interaction.Method != method || interaction.URL != uri {
+			return nil, fmt.Errorf("cassette: unexpected request %s %s, recorded: %s %s", method, uri, interaction.Method, interaction.URL)
+		}
+		p.used[p.next] = true
+		p.next++
+	case MatchRequest:
+		index := -1
+		for i, in := range p.cassette.Interactions {
+			if in.Method != method || in.URL != uri || !equalJSON(in.Request, req) {
+				continue
+			}
+			index = i
+			if !p.used[i] {
+				break
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("cassette: no interaction matched for %s %s", method, uri)
+		}
+		p.used[index] = true
+		interaction = p.cassette.Interactions[index]
+	}
+
+	if interaction.Error != nil {
+		return nil, replayError(method, uri, interaction.Error)
+	}
+	return interaction.Response, nil
+}
+
+// Remaining 一度も返していないInteractionの数
+func (p *Player) Remaining() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	remaining := 0
+	for _, used := range p.used {
+		if !used {
+			remaining++
+		}
+	}
+	return remaining
+}
+
+func equalJSON(a, b []byte) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	if bytes.Equal(a, b) {
+		return true
+	}
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func replayError(method, uri string, e *Error) error {
+	if e.StatusCode == 0 {
+		return errors.New(e.Message)
+	}
+	u, _ := url.Parse(uri)
+	return iaas.NewAPIError(method, u, e.StatusCode, &iaas.APIErrorResponse{
+		Serial:       e.Serial,
+		ErrorCode:    e.Code,
+		ErrorMessage: e.Message,
+	})
+}
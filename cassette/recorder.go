@@ -0,0 +1,70 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassette
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+// Recorder 実際のAPI呼び出しをCassetteへ記録するiaas.APICaller
+//
+// 記録時にはScrubFieldsに該当する値を伏せる。呼び出し元には伏せる前のレスポンスを返す。
+type Recorder struct {
+	caller   iaas.APICaller
+	cassette *Cassette
+}
+
+// NewRecorder callerの呼び出しをcassetteへ記録するRecorderを返す
+func NewRecorder(caller iaas.APICaller, cassette *Cassette) *Recorder {
+	return &Recorder{caller: caller, cassette: cassette}
+}
+
+// Do iaas.APICallerの実装
+func (r *Recorder) Do(ctx context.Context, method, uri string, body interface{}) ([]byte, error) {
+	data, err := r.caller.Do(ctx, method, uri, body)
+
+	interaction := &Interaction{Method: method, URL: ScrubURL(uri)}
+	if body != nil {
+		if req, err := json.Marshal(body); err == nil {
+			interaction.Request = Scrub(req)
+		}
+	}
+	switch {
+	case err != nil:
+		interaction.Error = recordError(err)
+	case len(data) > 0 && json.Valid(data):
+		interaction.Response = Scrub(data)
+	}
+	r.cassette.Add(interaction)
+
+	return data, err
+}
+
+func recordError(err error) *Error {
+	var apiErr iaas.APIError
+	if errors.As(err, &apiErr) {
+		return &Error{
+			StatusCode: apiErr.ResponseCode(),
+			Code:       apiErr.Code(),
+			Message:    apiErr.Message(),
+			Serial:     apiErr.Serial(),
+		}
+	}
+	return &Error{Message: err.Error()}
+}
@@ -0,0 +1,83 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cassette
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ScrubbedValue 秘匿情報を置き換える値
+const ScrubbedValue = "********"
+
+// ScrubFields 記録時に値を伏せるJSONのキーに含まれる文字列、大文字小文字は区別しない
+var ScrubFields = []string{
+	"password",
+	"passphrase",
+	"secret",
+	"token",
+	"privatekey",
+	"apikey",
+}
+
+// Scrub JSONのうちScrubFieldsに該当するキーの値を伏せて返す、JSONでない場合はそのまま返す
+func Scrub(data []byte) []byte {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return data
+	}
+	scrub(v)
+	scrubbed, err := json.Marshal(v)
+	if err != nil {
+		return data
+	}
+	return scrubbed
+}
+
+// ScrubURL GETリクエストのクエリ文字列として送信されるJSONの秘匿情報を伏せて返す
+func ScrubURL(uri string) string {
+	base, query, found := strings.Cut(uri, "?")
+	if !found {
+		return uri
+	}
+	return base + "?" + string(Scrub([]byte(query)))
+}
+
+func scrub(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isScrubField(key) && value != nil && value != "" {
+				v[key] = ScrubbedValue
+				continue
+			}
+			scrub(value)
+		}
+	case []interface{}:
+		for _, value := range v {
+			scrub(value)
+		}
+	}
+}
+
+func isScrubField(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range ScrubFields {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -16,11 +16,11 @@ package cdrom
 
 import (
 	"context"
-	"fmt"
 	"io"
 	"os"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/ftps"
 	"github.com/sacloud/packages-go/size"
 )
@@ -30,8 +30,12 @@ func (s *Service) Create(req *CreateRequest) (*iaas.CDROM, error) {
 }
 
 func (s *Service) CreateWithContext(ctx context.Context, req *CreateRequest) (*iaas.CDROM, error) {
+	return service.Invoke(ctx, s.interceptors, "cdrom", "create", req, s.create)
+}
+
+func (s *Service) create(ctx context.Context, req *CreateRequest) (*iaas.CDROM, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	var reader io.Reader
@@ -41,7 +45,7 @@ func (s *Service) CreateWithContext(ctx context.Context, req *CreateRequest) (*i
 	default:
 		file, err := os.Open(req.SourcePath)
 		if err != nil {
-			return nil, fmt.Errorf("reading source file[%s] failed: %s", req.SourcePath, err)
+			return nil, service.StepError("read", err)
 		}
 		defer file.Close()
 		reader = file
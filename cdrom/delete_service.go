@@ -21,6 +21,8 @@ import (
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/helper/cleanup"
 	"github.com/sacloud/iaas-api-go/helper/query"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/reference"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 )
 
@@ -29,8 +31,19 @@ func (s *Service) Delete(req *DeleteRequest) error {
 }
 
 func (s *Service) DeleteWithContext(ctx context.Context, req *DeleteRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "cdrom", "delete", req, s.delete)
+}
+
+func (s *Service) delete(ctx context.Context, req *DeleteRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
+	}
+
+	if req.FailIfReferenced {
+		inspector := &reference.Inspector{Caller: s.caller}
+		if err := inspector.Check(ctx, req.Zone, reference.KindCDROM, req.ID); err != nil {
+			return serviceutil.HandleNotFoundError(err, !req.FailIfNotFound)
+		}
 	}
 
 	if req.WaitForRelease {
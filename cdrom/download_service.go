@@ -22,6 +22,7 @@ import (
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/ftps"
 )
 
@@ -30,14 +31,18 @@ func (s *Service) Download(req *DownloadRequest) error {
 }
 
 func (s *Service) DownloadWithContext(ctx context.Context, req *DownloadRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "cdrom", "download", req, s.download)
+}
+
+func (s *Service) download(ctx context.Context, req *DownloadRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewCDROMOp(s.caller)
 	resource, err := client.Read(ctx, req.Zone, req.ID)
 	if err != nil {
-		return fmt.Errorf("reading CDROM[%s] failed: %s", req.ID, err)
+		return service.StepError("read", err)
 	}
 
 	if resource.Scope != types.Scopes.User {
@@ -46,7 +51,7 @@ func (s *Service) DownloadWithContext(ctx context.Context, req *DownloadRequest)
 
 	ftpServer, err := client.OpenFTP(ctx, req.Zone, req.ID, &iaas.OpenFTPRequest{ChangePassword: req.ChangePassword})
 	if err != nil {
-		return fmt.Errorf("requesting FTP server information failed: %s", err)
+		return fmt.Errorf("requesting FTP server information failed: %w", err)
 	}
 
 	ftpsClient := ftps.NewClient(ftpServer.User, ftpServer.Password, ftpServer.HostName)
@@ -57,17 +62,17 @@ func (s *Service) DownloadWithContext(ctx context.Context, req *DownloadRequest)
 			out = req.Writer
 		}
 		if err := ftpsClient.DownloadWriter(out); err != nil {
-			return fmt.Errorf("downloading via FTP failed: %s", err)
+			return fmt.Errorf("downloading via FTP failed: %w", err)
 		}
 	default:
 		if err := ftpsClient.Download(req.Path); err != nil {
-			return fmt.Errorf("downloading via FTP failed: %s", err)
+			return fmt.Errorf("downloading via FTP failed: %w", err)
 		}
 	}
 
 	// close
 	if err := client.CloseFTP(ctx, req.Zone, req.ID); err != nil {
-		return fmt.Errorf("closing FTP server failed: %s", err)
+		return fmt.Errorf("closing FTP server failed: %w", err)
 	}
 	return nil
 }
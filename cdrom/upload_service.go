@@ -22,6 +22,7 @@ import (
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/ftps"
 )
 
@@ -30,14 +31,18 @@ func (s *Service) Upload(req *UploadRequest) error {
 }
 
 func (s *Service) UploadWithContext(ctx context.Context, req *UploadRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "cdrom", "upload", req, s.upload)
+}
+
+func (s *Service) upload(ctx context.Context, req *UploadRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewCDROMOp(s.caller)
 	resource, err := client.Read(ctx, req.Zone, req.ID)
 	if err != nil {
-		return fmt.Errorf("reading CDROM[%s] failed: %s", req.ID, err)
+		return service.StepError("read", err)
 	}
 
 	if resource.Scope != types.Scopes.User {
@@ -46,7 +51,7 @@ func (s *Service) UploadWithContext(ctx context.Context, req *UploadRequest) err
 
 	ftpServer, err := client.OpenFTP(ctx, req.Zone, req.ID, &iaas.OpenFTPRequest{ChangePassword: true})
 	if err != nil {
-		return fmt.Errorf("requesting FTP server information failed: %s", err)
+		return fmt.Errorf("requesting FTP server information failed: %w", err)
 	}
 
 	ftpsClient := ftps.NewClient(ftpServer.User, ftpServer.Password, ftpServer.HostName)
@@ -60,19 +65,19 @@ func (s *Service) UploadWithContext(ctx context.Context, req *UploadRequest) err
 	default:
 		f, err := os.Open(req.Path)
 		if err != nil {
-			return fmt.Errorf("opening upload file failed: %s", err)
+			return fmt.Errorf("opening upload file failed: %w", err)
 		}
 		defer f.Close()
 		reader = f
 	}
 
 	if err := ftpsClient.UploadReader("upload.raw", reader); err != nil {
-		return fmt.Errorf("uploading file failed: %s", err)
+		return fmt.Errorf("uploading file failed: %w", err)
 	}
 
 	// close FTP
 	if err := client.CloseFTP(ctx, req.Zone, resource.ID); err != nil {
-		return fmt.Errorf("closing FTP server failed: %s", err)
+		return fmt.Errorf("closing FTP server failed: %w", err)
 	}
 	return nil
 }
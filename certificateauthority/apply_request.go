@@ -15,10 +15,12 @@
 package certificateauthority
 
 import (
+	"context"
 	"time"
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/certificateauthority/builder"
 	"github.com/sacloud/packages-go/validate"
 )
@@ -67,3 +69,21 @@ func (req *ApplyRequest) Builder(caller iaas.APICaller) (*builder.Builder, error
 		PollingInterval:  req.PollingInterval,
 	}, nil
 }
+
+// Preflight 参照先のリソースを確認し、検出した全ての問題を返す
+//
+// リソースの作成や更新は行わない
+func (req *ApplyRequest) Preflight(ctx context.Context, caller iaas.APICaller) error {
+	p := service.NewPreflight()
+
+	p.Ref("ID", "certificate authority", "", req.ID, func() error {
+		_, err := iaas.NewCertificateAuthorityOp(caller).Read(ctx, req.ID)
+		return err
+	})
+	p.Ref("IconID", "icon", "", req.IconID, func() error {
+		_, err := iaas.NewIconOp(caller).Read(ctx, req.IconID)
+		return err
+	})
+
+	return p.Err()
+}
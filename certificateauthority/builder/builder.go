@@ -135,6 +135,14 @@ func (b *Builder) create(ctx context.Context) (*CertificateAuthority, error) {
 }
 
 func (b *Builder) update(ctx context.Context) (*CertificateAuthority, error) {
+	current, err := Read(ctx, b.Client, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !b.plan(current).HasChanges() {
+		return current, nil
+	}
+
 	updated, err := b.Client.Update(ctx, b.ID, &iaas.CertificateAuthorityUpdateRequest{
 		Name:        b.Name,
 		Description: b.Description,
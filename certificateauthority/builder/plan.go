@@ -0,0 +1,85 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"fmt"
+
+	service "github.com/sacloud/iaas-service-go"
+)
+
+// Plan Build時の変更内容を算出する
+//
+// IDが空の場合は新規作成を表すPlanを返す。APIによるリソースの変更は行わない。
+func (b *Builder) Plan(ctx context.Context) (*service.Plan, error) {
+	if b.ID.IsEmpty() {
+		return service.NewCreatePlan(map[string]interface{}{
+			"Name":             b.Name,
+			"Description":      b.Description,
+			"Tags":             b.Tags,
+			"IconID":           b.IconID,
+			"Country":          b.Country,
+			"Organization":     b.Organization,
+			"OrganizationUnit": b.OrganizationUnit,
+			"CommonName":       b.CommonName,
+			"NotAfter":         b.NotAfter,
+			"Clients":          len(b.Clients),
+			"Servers":          len(b.Servers),
+		}), nil
+	}
+
+	current, err := Read(ctx, b.Client, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	return b.plan(current), nil
+}
+
+func (b *Builder) plan(current *CertificateAuthority) *service.Plan {
+	plan := service.NewPlan()
+
+	plan.Diff("Name", current.Name, b.Name)
+	plan.Diff("Description", current.Description, b.Description)
+	plan.Diff("Tags", current.Tags, b.Tags)
+	plan.Diff("IconID", current.IconID, b.IconID)
+
+	// clients
+	for _, c := range b.deletedClients(current.Clients) {
+		if c.IssueState == "available" || c.IssueState == "approved" {
+			plan.Diff(fmt.Sprintf("Clients[%s]", c.ID), c.IssueState, "revoked")
+		}
+	}
+	for _, c := range b.updatedClients(current.Clients) {
+		plan.Diff(fmt.Sprintf("Clients[%s].Hold", c.ID), !c.Hold, c.Hold)
+	}
+	for i, c := range b.createdClients() {
+		plan.Diff(fmt.Sprintf("Clients[new:%d]", i), nil, c.CommonName)
+	}
+
+	// servers
+	for _, s := range b.deletedServers(current.Servers) {
+		if s.IssueState == "available" {
+			plan.Diff(fmt.Sprintf("Servers[%s]", s.ID), s.IssueState, "revoked")
+		}
+	}
+	for _, s := range b.updatedServers(current.Servers) {
+		plan.Diff(fmt.Sprintf("Servers[%s].Hold", s.ID), !s.Hold, s.Hold)
+	}
+	for i, s := range b.createdServers() {
+		plan.Diff(fmt.Sprintf("Servers[new:%d]", i), nil, s.CommonName)
+	}
+	return plan
+}
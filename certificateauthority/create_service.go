@@ -17,6 +17,7 @@ package certificateauthority
 import (
 	"context"
 
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/certificateauthority/builder"
 )
 
@@ -25,8 +26,12 @@ func (s *Service) Create(req *CreateRequest) (*builder.CertificateAuthority, err
 }
 
 func (s *Service) CreateWithContext(ctx context.Context, req *CreateRequest) (*builder.CertificateAuthority, error) {
+	return service.Invoke(ctx, s.interceptors, "certificateauthority", "create", req, s.create)
+}
+
+func (s *Service) create(ctx context.Context, req *CreateRequest) (*builder.CertificateAuthority, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	return s.ApplyWithContext(ctx, req.ApplyRequest())
@@ -0,0 +1,128 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certificateauthority
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/certificateauthority/builder"
+)
+
+func (s *Service) Export(req *ExportRequest) (*ApplyRequest, error) {
+	return s.ExportWithContext(context.Background(), req)
+}
+
+func (s *Service) ExportWithContext(ctx context.Context, req *ExportRequest) (*ApplyRequest, error) {
+	return service.Invoke(ctx, s.interceptors, "certificateauthority", "export", req, s.export)
+}
+
+func (s *Service) export(ctx context.Context, req *ExportRequest) (*ApplyRequest, error) {
+	if err := req.Validate(); err != nil {
+		return nil, service.ValidationError(err)
+	}
+
+	current, err := builder.Read(ctx, iaas.NewCertificateAuthorityOp(s.caller), req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := &ApplyRequest{
+		ID:          current.ID,
+		Name:        current.Name,
+		Description: current.Description,
+		Tags:        current.Tags,
+		IconID:      current.IconID,
+	}
+	if current.Detail != nil {
+		subject := parseSubject(current.Detail.Subject)
+		exported.Country = subject.country
+		exported.Organization = subject.organization
+		exported.OrganizationUnit = subject.organizationUnit
+		exported.CommonName = subject.commonName
+		exported.NotAfter = notAfter(current.Detail.CertificateData)
+	}
+
+	for _, c := range current.Clients {
+		subject := parseSubject(c.Subject)
+		exported.Clients = append(exported.Clients, &builder.ClientCert{
+			ID:               c.ID,
+			Country:          subject.country,
+			Organization:     subject.organization,
+			OrganizationUnit: subject.organizationUnit,
+			CommonName:       subject.commonName,
+			NotAfter:         notAfter(c.CertificateData),
+			IssuanceMethod:   c.IssuanceMethod,
+			EMail:            c.EMail,
+			Hold:             c.IssueState == "hold",
+		})
+	}
+	for _, sv := range current.Servers {
+		subject := parseSubject(sv.Subject)
+		exported.Servers = append(exported.Servers, &builder.ServerCert{
+			ID:               sv.ID,
+			Country:          subject.country,
+			Organization:     subject.organization,
+			OrganizationUnit: subject.organizationUnit,
+			CommonName:       subject.commonName,
+			NotAfter:         notAfter(sv.CertificateData),
+			SANs:             sv.SANs,
+			Hold:             sv.IssueState == "hold",
+		})
+	}
+	return exported, nil
+}
+
+type subject struct {
+	country          string
+	organization     string
+	organizationUnit []string
+	commonName       string
+}
+
+// parseSubject "C=JP, O=example, OU=dev, CN=example.com"のような形式のSubjectを解析する
+//
+// 解釈できない要素は無視する
+func parseSubject(s string) *subject {
+	result := &subject{}
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '/' }) {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(kv[1])
+		switch strings.ToUpper(strings.TrimSpace(kv[0])) {
+		case "C":
+			result.country = value
+		case "O":
+			result.organization = value
+		case "OU":
+			result.organizationUnit = append(result.organizationUnit, value)
+		case "CN":
+			result.commonName = value
+		}
+	}
+	return result
+}
+
+func notAfter(data *iaas.CertificateData) time.Time {
+	if data == nil {
+		return time.Time{}
+	}
+	return data.NotAfter
+}
@@ -0,0 +1,36 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certificateauthority
+
+import (
+	"context"
+
+	service "github.com/sacloud/iaas-service-go"
+)
+
+func (s *Service) Preflight(req *ApplyRequest) error {
+	return s.PreflightWithContext(context.Background(), req)
+}
+
+func (s *Service) PreflightWithContext(ctx context.Context, req *ApplyRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "certificateauthority", "preflight", req, s.preflight)
+}
+
+func (s *Service) preflight(ctx context.Context, req *ApplyRequest) error {
+	if err := req.Validate(); err != nil {
+		return service.ValidationError(err)
+	}
+	return req.Preflight(ctx, s.caller)
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 	builder2 "github.com/sacloud/iaas-service-go/certificateauthority/builder"
 )
 
@@ -26,8 +27,12 @@ func (s *Service) Read(req *ReadRequest) (*builder2.CertificateAuthority, error)
 }
 
 func (s *Service) ReadWithContext(ctx context.Context, req *ReadRequest) (*builder2.CertificateAuthority, error) {
+	return service.Invoke(ctx, s.interceptors, "certificateauthority", "read", req, s.read)
+}
+
+func (s *Service) read(ctx context.Context, req *ReadRequest) (*builder2.CertificateAuthority, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 	return builder2.Read(ctx, iaas.NewCertificateAuthorityOp(s.caller), req.ID)
 }
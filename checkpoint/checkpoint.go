@@ -0,0 +1,250 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package checkpoint builderの進捗を記録し、中断したビルドを途中から再開するためのチェックポイントを提供する
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/accessor"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/rollback"
+)
+
+// State チェックポイントに記録される進捗
+type State struct {
+	// Steps 完了したステップ名
+	Steps []string `json:"steps,omitempty"`
+	// IDs 作成したリソースのID、キーはbuilderごとに定められたリソース名
+	IDs map[string]types.ID `json:"ids,omitempty"`
+}
+
+func (s *State) clone() *State {
+	cloned := &State{}
+	if len(s.Steps) > 0 {
+		cloned.Steps = append([]string{}, s.Steps...)
+	}
+	if len(s.IDs) > 0 {
+		cloned.IDs = make(map[string]types.ID, len(s.IDs))
+		for k, v := range s.IDs {
+			cloned.IDs[k] = v
+		}
+	}
+	return cloned
+}
+
+// Store チェックポイントの保存先
+type Store interface {
+	// Load 保存されている進捗を返す、未保存の場合はnilを返す
+	Load(ctx context.Context, key string) (*State, error)
+	// Save 進捗を保存する
+	Save(ctx context.Context, key string, state *State) error
+	// Delete 保存されている進捗を削除する、未保存の場合は何もしない
+	Delete(ctx context.Context, key string) error
+}
+
+// Checkpoint 1回のビルドの進捗をStoreに記録する
+//
+// 同じStoreとKeyで再実行した場合、完了済みのステップを省略して再開できる。
+// nilの*Checkpointに対する操作は全て何もせず、Doneは常にfalseを、IDは常に空のIDを返す。
+type Checkpoint struct {
+	Store Store
+	Key   string
+
+	mu    sync.Mutex
+	state *State
+}
+
+// New Checkpointを返す
+func New(store Store, key string) *Checkpoint {
+	return &Checkpoint{Store: store, Key: key}
+}
+
+// Load Storeから進捗を読み込む
+//
+// builderは処理の開始時に呼び出す
+func (c *Checkpoint) Load(ctx context.Context) error {
+	if c == nil {
+		return nil
+	}
+	state, err := c.Store.Load(ctx, c.Key)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		state = &State{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state = state
+	return nil
+}
+
+// Done 指定のステップが完了済みか
+func (c *Checkpoint) Done(step string) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range c.current().Steps {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+// ID 記録されたリソースのIDを返す、未記録の場合は空のIDを返す
+func (c *Checkpoint) ID(name string) types.ID {
+	if c == nil {
+		return types.ID(0)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.current().IDs[name]
+}
+
+// Complete ステップの完了を記録する
+func (c *Checkpoint) Complete(ctx context.Context, step string) error {
+	if c == nil || c.Done(step) {
+		return nil
+	}
+	return c.update(ctx, func(state *State) {
+		state.Steps = append(state.Steps, step)
+	})
+}
+
+// Run 指定のステップが完了済みでなければfnを実行し、成功した場合はステップの完了を記録する
+func (c *Checkpoint) Run(ctx context.Context, step string, fn func() error) error {
+	if c.Done(step) {
+		return nil
+	}
+	if err := fn(); err != nil {
+		return err
+	}
+	return c.Complete(ctx, step)
+}
+
+// SetID 作成したリソースのIDを記録する
+func (c *Checkpoint) SetID(ctx context.Context, name string, id types.ID) error {
+	if c == nil {
+		return nil
+	}
+	return c.update(ctx, func(state *State) {
+		if state.IDs == nil {
+			state.IDs = make(map[string]types.ID)
+		}
+		state.IDs[name] = id
+	})
+}
+
+// Reset 記録した進捗を破棄する
+//
+// 記録されたリソースが削除されていた場合など、最初からやり直す際に利用する
+func (c *Checkpoint) Reset(ctx context.Context) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.Store.Delete(ctx, c.Key); err != nil {
+		return err
+	}
+	c.state = &State{}
+	return nil
+}
+
+// ResetOnRollback errがロールバックの成功を示す*rollback.Errorの場合に記録した進捗を破棄する
+//
+// 記録されたリソースはロールバックにより削除されているため、次回は最初からやり直す必要がある。
+// errをそのまま返し、破棄に失敗した場合はそのエラーを加えて返す
+func (c *Checkpoint) ResetOnRollback(ctx context.Context, err error) error {
+	var rollbackErr *rollback.Error
+	if c == nil || !errors.As(err, &rollbackErr) || !rollbackErr.Result.Succeeded() {
+		return err
+	}
+	if resetErr := c.Reset(ctx); resetErr != nil {
+		return errors.Join(err, resetErr)
+	}
+	return err
+}
+
+// State 記録されている進捗のコピーを返す
+func (c *Checkpoint) State() *State {
+	if c == nil {
+		return &State{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.current().clone()
+}
+
+func (c *Checkpoint) update(ctx context.Context, fn func(state *State)) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state := c.current().clone()
+	fn(state)
+	if err := c.Store.Save(ctx, c.Key, state); err != nil {
+		return err
+	}
+	c.state = state
+	return nil
+}
+
+// current 呼び出し元でc.muをロックしておく必要がある
+func (c *Checkpoint) current() *State {
+	if c.state == nil {
+		c.state = &State{}
+	}
+	return c.state
+}
+
+// CreateOrResume nameで記録されたIDのリソースが存在すればreadで取得して返し、そうでなければcreateで作成する
+//
+// 作成したリソースのIDはnameで記録される。記録されたリソースが削除されていた(404)場合は進捗を破棄した上で作成する。
+// 2番目の戻り値は記録されたリソースを返した場合にtrueとなる
+func CreateOrResume[T accessor.ID](
+	ctx context.Context,
+	c *Checkpoint,
+	name string,
+	read func(id types.ID) (T, error),
+	create func() (T, error),
+) (T, bool, error) {
+	var zero T
+	if id := c.ID(name); !id.IsEmpty() {
+		resumed, err := read(id)
+		if err == nil {
+			return resumed, true, nil
+		}
+		if !iaas.IsNotFoundError(err) {
+			return zero, false, err
+		}
+		if err := c.Reset(ctx); err != nil {
+			return zero, false, err
+		}
+	}
+
+	created, err := create()
+	if err != nil {
+		return created, false, err
+	}
+	return created, false, c.SetID(ctx, name, created.GetID())
+}
@@ -0,0 +1,219 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkpoint_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/checkpoint"
+	disk "github.com/sacloud/iaas-service-go/disk/builder"
+	server "github.com/sacloud/iaas-service-go/server/builder"
+	"github.com/sacloud/iaas-service-go/servicetest"
+	"github.com/sacloud/iaas-service-go/setup"
+	vpcrouter "github.com/sacloud/iaas-service-go/vpcrouter/builder"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpoint(t *testing.T) {
+	ctx := context.Background()
+
+	stores := map[string]checkpoint.Store{
+		"memory": checkpoint.NewMemoryStore(),
+		"file":   checkpoint.NewFileStore(t.TempDir()),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			cp := checkpoint.New(store, "example/build")
+			require.NoError(t, cp.Load(ctx))
+			require.False(t, cp.Done("step1"))
+
+			calls := 0
+			run := func() error {
+				calls++
+				return nil
+			}
+			require.NoError(t, cp.Run(ctx, "step1", run))
+			require.NoError(t, cp.Run(ctx, "step1", run))
+			require.Equal(t, 1, calls)
+			require.NoError(t, cp.SetID(ctx, "server", types.ID(1)))
+
+			// 別のCheckpointから進捗を参照できる
+			resumed := checkpoint.New(store, "example/build")
+			require.NoError(t, resumed.Load(ctx))
+			require.True(t, resumed.Done("step1"))
+			require.Equal(t, types.ID(1), resumed.ID("server"))
+			require.Equal(t, &checkpoint.State{
+				Steps: []string{"step1"},
+				IDs:   map[string]types.ID{"server": 1},
+			}, resumed.State())
+
+			require.NoError(t, resumed.Reset(ctx))
+			reset := checkpoint.New(store, "example/build")
+			require.NoError(t, reset.Load(ctx))
+			require.Equal(t, &checkpoint.State{}, reset.State())
+		})
+	}
+
+	t.Run("failed step is not recorded", func(t *testing.T) {
+		cp := checkpoint.New(checkpoint.NewMemoryStore(), "example")
+		require.Error(t, cp.Run(ctx, "step1", func() error { return errors.New("dummy") }))
+		require.False(t, cp.Done("step1"))
+	})
+
+	t.Run("nil checkpoint", func(t *testing.T) {
+		var cp *checkpoint.Checkpoint
+		require.NoError(t, cp.Load(ctx))
+		require.NoError(t, cp.SetID(ctx, "server", types.ID(1)))
+		require.True(t, cp.ID("server").IsEmpty())
+
+		calls := 0
+		for i := 0; i < 2; i++ {
+			require.NoError(t, cp.Run(ctx, "step1", func() error {
+				calls++
+				return nil
+			}))
+		}
+		require.Equal(t, 2, calls)
+	})
+}
+
+func TestServerBuilder_resume(t *testing.T) {
+	ctx := context.Background()
+	backend := servicetest.NewBackend()
+	backend.Inject("Server.InsertCDROM", &servicetest.Fault{Calls: []int{1}, Err: errors.New("dummy")})
+	store := checkpoint.NewMemoryStore()
+
+	build := func() (*server.BuildResult, error) {
+		builder := &server.Builder{
+			Name:       "example",
+			CPU:        1,
+			MemoryGB:   1,
+			NIC:        &server.SharedNICSetting{},
+			CDROMID:    types.ID(1),
+			Client:     backend.ServerBuilderClient(),
+			Checkpoint: checkpoint.New(store, "server"),
+		}
+		return builder.Build(ctx, "is1a")
+	}
+
+	_, err := build()
+	require.Error(t, err)
+
+	result, err := build()
+	require.NoError(t, err)
+	require.Equal(t, 1, backend.Calls("Server.Create"))
+	require.Equal(t, 2, backend.Calls("Server.InsertCDROM"))
+
+	servers := backend.Servers()
+	require.Len(t, servers, 1)
+	require.Equal(t, servers[0].ID, result.ServerID)
+	require.Equal(t, types.ID(1), servers[0].CDROMID)
+
+	// 完了済みのビルドを再実行しても何も作成されない
+	_, err = build()
+	require.NoError(t, err)
+	require.Equal(t, 1, backend.Calls("Server.Create"))
+	require.Equal(t, 2, backend.Calls("Server.InsertCDROM"))
+}
+
+func TestServerBuilder_resumeDiskCopy(t *testing.T) {
+	ctx := context.Background()
+	backend := servicetest.NewBackend()
+	// 1回目のビルドはコピー完了待ちの途中で中断し、再開時はコピー中の状態から完了を待つ
+	backend.Inject("Disk.Read", &servicetest.Fault{Calls: []int{1}, Err: errors.New("dummy")})
+	backend.Inject("Disk.Read", &servicetest.Fault{Calls: []int{2}, Availability: types.Availabilities.Migrating})
+	store := checkpoint.NewMemoryStore()
+
+	build := func() (*server.BuildResult, error) {
+		builder := &server.Builder{
+			Name:     "example",
+			CPU:      1,
+			MemoryGB: 1,
+			NIC:      &server.SharedNICSetting{},
+			DiskBuilders: []disk.Builder{
+				&disk.BlankBuilder{
+					Name:       "example",
+					SizeGB:     20,
+					PlanID:     types.DiskPlans.SSD,
+					Connection: types.DiskConnections.VirtIO,
+					Client:     backend.DiskBuilderClient(),
+				},
+			},
+			Client:     backend.ServerBuilderClient(),
+			Checkpoint: checkpoint.New(store, "server"),
+		}
+		return builder.Build(ctx, "is1a")
+	}
+
+	_, err := build()
+	require.Error(t, err)
+	disks := backend.Disks()
+	require.Len(t, disks, 1)
+
+	result, err := build()
+	require.NoError(t, err)
+	require.Equal(t, 1, backend.Calls("Server.Create"))
+	require.Equal(t, 1, backend.Calls("Disk.Create"))
+	require.Equal(t, 3, backend.Calls("Disk.Read"))
+	require.Equal(t, []types.ID{disks[0].ID}, result.DiskIDs)
+	require.Len(t, backend.Disks(), 1)
+}
+
+func TestVPCRouterBuilder_resume(t *testing.T) {
+	ctx := context.Background()
+	backend := servicetest.NewBackend()
+	backend.Inject("VPCRouter.UpdateSettings", &servicetest.Fault{Calls: []int{1}, Err: errors.New("dummy")})
+	store := checkpoint.NewMemoryStore()
+
+	build := func() (*iaas.VPCRouter, error) {
+		builder := &vpcrouter.Builder{
+			Zone:       "is1a",
+			Name:       "example",
+			PlanID:     types.VPCRouterPlans.Standard,
+			NICSetting: &vpcrouter.StandardNICSetting{},
+			AdditionalNICSettings: []vpcrouter.AdditionalNICSettingHolder{
+				&vpcrouter.AdditionalStandardNICSetting{
+					SwitchID:       types.ID(1),
+					IPAddress:      "192.168.0.1",
+					NetworkMaskLen: 24,
+					Index:          1,
+				},
+			},
+			SetupOptions: &setup.Options{
+				NICUpdateWaitDuration: time.Millisecond,
+				PollingInterval:       time.Millisecond,
+			},
+			Client:     backend.VPCRouterClient(),
+			Checkpoint: checkpoint.New(store, "vpcrouter"),
+		}
+		return builder.Build(ctx)
+	}
+
+	_, err := build()
+	require.Error(t, err)
+
+	created, err := build()
+	require.NoError(t, err)
+	require.Equal(t, 1, backend.Calls("VPCRouter.Create"))
+	require.Equal(t, 1, backend.Calls("VPCRouter.ConnectToSwitch"))
+	require.Equal(t, 2, backend.Calls("VPCRouter.UpdateSettings"))
+	require.Len(t, backend.VPCRouters(), 1)
+	require.Len(t, created.Interfaces, 2)
+}
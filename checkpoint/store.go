@@ -0,0 +1,139 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// MemoryStore プロセス内のメモリに進捗を保持するStore
+type MemoryStore struct {
+	mu     sync.Mutex
+	states map[string][]byte
+}
+
+// NewMemoryStore 空のMemoryStoreを返す
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{states: make(map[string][]byte)}
+}
+
+// Load Storeの実装
+func (s *MemoryStore) Load(_ context.Context, key string) (*State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.states[key]
+	if !ok {
+		return nil, nil
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Save Storeの実装
+func (s *MemoryStore) Save(_ context.Context, key string, state *State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[key] = data
+	return nil
+}
+
+// Delete Storeの実装
+func (s *MemoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, key)
+	return nil
+}
+
+// FileStore ディレクトリ配下にキーごとのJSONファイルとして進捗を保存するStore
+type FileStore struct {
+	// Dir 保存先ディレクトリ、存在しない場合は保存時に作成される
+	Dir string
+}
+
+// NewFileStore FileStoreを返す
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{Dir: dir}
+}
+
+// Load Storeの実装
+func (s *FileStore) Load(_ context.Context, key string) (*State, error) {
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Save Storeの実装
+//
+// 書き込み途中でプロセスが停止しても以前の内容が失われないよう、一時ファイルに書き込んだ上で置き換える
+func (s *FileStore) Save(_ context.Context, key string, state *State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.Dir, ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck,gosec
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+// Delete Storeの実装
+func (s *FileStore) Delete(_ context.Context, key string) error {
+	err := os.Remove(s.path(key))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *FileStore) path(key string) string {
+	return filepath.Join(s.Dir, url.PathEscape(key)+".json")
+}
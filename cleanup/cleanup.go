@@ -0,0 +1,452 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cleanup 利用されていないリソースや長期間停止しているリソースを削除候補として検出する
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/helper/query"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/archive"
+	"github.com/sacloud/iaas-service-go/disk"
+	"github.com/sacloud/iaas-service-go/ipv6addr"
+	"github.com/sacloud/iaas-service-go/note"
+	"github.com/sacloud/iaas-service-go/packetfilter"
+	"github.com/sacloud/iaas-service-go/server"
+	"github.com/sacloud/iaas-service-go/sshkey"
+	"github.com/sacloud/iaas-service-go/swytch"
+)
+
+// Kind 削除候補のリソース種別
+type Kind string
+
+const (
+	// KindServer 長期間停止しているサーバ
+	KindServer = Kind("server")
+	// KindDisk サーバに接続されていないディスク
+	KindDisk = Kind("disk")
+	// KindArchive ディスクやアーカイブのコピー元として参照されていないアーカイブ
+	KindArchive = Kind("archive")
+	// KindPacketFilter どのNICにも接続されていないパケットフィルタ
+	KindPacketFilter = Kind("packetfilter")
+	// KindSwitch サーバやアプライアンスが接続されていないスイッチ
+	KindSwitch = Kind("switch")
+	// KindIPv6Addr NICに割り当てられていないIPv6アドレス
+	KindIPv6Addr = Kind("ipv6addr")
+	// KindSSHKey Finder.SSHKeyNameにマッチするSSHキー
+	KindSSHKey = Kind("sshkey")
+	// KindNote ディスクの修正時に作成されたスタートアップスクリプト
+	KindNote = Kind("note")
+)
+
+// Kinds 全てのリソース種別
+//
+// Deleteはこの順番で削除を行う
+var Kinds = []Kind{KindServer, KindDisk, KindArchive, KindPacketFilter, KindSwitch, KindIPv6Addr, KindSSHKey, KindNote}
+
+// DefaultStoppedServerAge Finder.StoppedServerAgeのデフォルト値
+const DefaultStoppedServerAge = 90 * 24 * time.Hour
+
+// diskEditNoteName ディスクの修正時にNoteContentsから作成されるスタートアップスクリプトの名前
+var diskEditNoteName = regexp.MustCompile(`^note-\d{4}-\d{2}-\d{2}T`)
+
+// Candidate 削除候補のリソース
+type Candidate struct {
+	Kind Kind
+	// Zone グローバルリソースの場合は空
+	Zone string
+	// ID KindIPv6Addrの場合は空
+	ID types.ID
+	// Name リソース名、KindIPv6Addrの場合はIPv6アドレス
+	Name string
+	Tags types.Tags
+	// Reason 削除候補とした理由
+	Reason string
+	// Age 最終更新(停止中のサーバの場合は停止)からの経過時間、日時を持たないリソースの場合は0
+	Age time.Duration
+}
+
+// String fmt.Stringerの実装
+func (c *Candidate) String() string {
+	target := fmt.Sprintf("%s[%s]", c.Kind, c.ID)
+	if c.Kind == KindIPv6Addr {
+		target = fmt.Sprintf("%s[%s]", c.Kind, c.Name)
+	}
+	if c.Zone != "" {
+		target += " in " + c.Zone
+	}
+	return fmt.Sprintf("%s: %s", target, c.Reason)
+}
+
+// Finder 削除候補のリソースを検出する
+type Finder struct {
+	Caller iaas.APICaller
+
+	// Zones 対象ゾーンのリスト、デフォルトはiaas.SakuraCloudZones
+	Zones []string
+	// Kinds 対象とするリソース種別、デフォルトは全て
+	Kinds []Kind
+
+	// AllowTags いずれかのタグを持つリソースは削除候補に含めない
+	AllowTags types.Tags
+	// MinAge 最終更新からの経過時間がこれより短いリソースは削除候補に含めない
+	//
+	// 構築中のリソースを削除候補としないために利用する。日時を持たないリソースには適用されない
+	MinAge time.Duration
+	// StoppedServerAge 停止してからの経過時間がこれ以上のサーバを削除候補とする、デフォルトはDefaultStoppedServerAge
+	StoppedServerAge time.Duration
+	// SSHKeyName 名前がマッチするSSHキーを削除候補とする
+	//
+	// SSHキーは他のリソースから参照されないため利用状況を判定できない。
+	// ディスクの修正時に生成したSSHキーの名前などを指定する。nilの場合SSHキーは削除候補に含めない
+	SSHKeyName *regexp.Regexp
+}
+
+// Find 各ゾーンをスキャンして削除候補を返す
+//
+// いずれかのゾーンでエラーとなった場合でも他のゾーンの結果は返し、
+// エラーは*service.ZoneErrorをerrors.Joinでまとめたものを返す。
+func (f *Finder) Find(ctx context.Context) ([]*Candidate, error) {
+	zones := f.Zones
+	if len(zones) == 0 {
+		zones = iaas.SakuraCloudZones
+	}
+
+	var candidates []*Candidate
+	var errs []error
+	for _, zone := range zones {
+		found, err := f.findInZone(ctx, zone)
+		candidates = append(candidates, found...)
+		if err != nil {
+			errs = append(errs, &service.ZoneError{Zone: zone, Err: err})
+		}
+	}
+
+	found, err := f.findGlobal(ctx)
+	candidates = append(candidates, found...)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return candidates, errors.Join(errs...)
+}
+
+func (f *Finder) findInZone(ctx context.Context, zone string) ([]*Candidate, error) {
+	var candidates []*Candidate
+
+	servers, err := server.New(f.Caller).FindAllWithContext(ctx, &server.FindRequest{Zone: zone})
+	if err != nil {
+		return nil, err
+	}
+	if f.enabled(KindServer) {
+		for _, s := range servers {
+			if !s.InstanceStatus.IsDown() {
+				continue
+			}
+			changedAt := s.InstanceStatusChangedAt
+			if changedAt.IsZero() {
+				changedAt = s.ModifiedAt
+			}
+			age := time.Since(changedAt)
+			if age < f.stoppedServerAge() {
+				continue
+			}
+			f.add(&candidates, &Candidate{
+				Kind:   KindServer,
+				Zone:   zone,
+				ID:     s.ID,
+				Name:   s.Name,
+				Tags:   s.Tags,
+				Reason: fmt.Sprintf("stopped for %d days", int(age.Hours()/24)),
+				Age:    age,
+			})
+		}
+	}
+
+	disks, err := disk.New(f.Caller).FindAllWithContext(ctx, &disk.FindRequest{Zone: zone})
+	if err != nil {
+		return candidates, err
+	}
+	if f.enabled(KindDisk) {
+		for _, d := range disks {
+			if !d.ServerID.IsEmpty() || !d.Availability.IsAvailable() {
+				continue
+			}
+			f.add(&candidates, &Candidate{
+				Kind:   KindDisk,
+				Zone:   zone,
+				ID:     d.ID,
+				Name:   d.Name,
+				Tags:   d.Tags,
+				Reason: "not connected to any server",
+				Age:    age(d.CreatedAt, d.ModifiedAt),
+			})
+		}
+	}
+
+	if f.enabled(KindArchive) {
+		archives, err := archive.New(f.Caller).FindAllWithContext(ctx, &archive.FindRequest{Zone: zone, Scope: types.Scopes.User})
+		if err != nil {
+			return candidates, err
+		}
+		referenced := make(map[types.ID]bool)
+		for _, d := range disks {
+			referenced[d.SourceArchiveID] = true
+		}
+		for _, a := range archives {
+			referenced[a.SourceArchiveID] = true
+		}
+		for _, a := range archives {
+			if referenced[a.ID] || a.Scope != types.Scopes.User || !a.Availability.IsAvailable() {
+				continue
+			}
+			f.add(&candidates, &Candidate{
+				Kind:   KindArchive,
+				Zone:   zone,
+				ID:     a.ID,
+				Name:   a.Name,
+				Tags:   a.Tags,
+				Reason: "not used as a source of any disk or archive",
+				Age:    age(a.CreatedAt, a.ModifiedAt),
+			})
+		}
+	}
+
+	if f.enabled(KindPacketFilter) {
+		filters, err := packetfilter.New(f.Caller).FindAllWithContext(ctx, &packetfilter.FindRequest{Zone: zone})
+		if err != nil {
+			return candidates, err
+		}
+		referenced := make(map[types.ID]bool)
+		for _, s := range servers {
+			for _, iface := range s.Interfaces {
+				referenced[iface.PacketFilterID] = true
+			}
+		}
+		for _, pf := range filters {
+			if referenced[pf.ID] {
+				continue
+			}
+			f.add(&candidates, &Candidate{
+				Kind:   KindPacketFilter,
+				Zone:   zone,
+				ID:     pf.ID,
+				Name:   pf.Name,
+				Reason: "not attached to any interface",
+				Age:    age(pf.CreatedAt, time.Time{}),
+			})
+		}
+	}
+
+	if f.enabled(KindSwitch) {
+		switches, err := swytch.New(f.Caller).FindAllWithContext(ctx, &swytch.FindRequest{Zone: zone})
+		if err != nil {
+			return candidates, err
+		}
+		for _, sw := range switches {
+			// ルータ/ブリッジ/ハイブリッド接続と接続されているスイッチは対象外
+			if sw.ServerCount > 0 || len(sw.Subnets) > 0 || !sw.BridgeID.IsEmpty() || !sw.HybridConnectionID.IsEmpty() {
+				continue
+			}
+			if f.skip(sw.Tags, age(sw.CreatedAt, sw.ModifiedAt)) {
+				continue
+			}
+			referenced, err := query.IsSwitchReferenced(ctx, f.Caller, zone, sw.ID)
+			if err != nil {
+				return candidates, err
+			}
+			if referenced {
+				continue
+			}
+			f.add(&candidates, &Candidate{
+				Kind:   KindSwitch,
+				Zone:   zone,
+				ID:     sw.ID,
+				Name:   sw.Name,
+				Tags:   sw.Tags,
+				Reason: "no servers or appliances connected",
+				Age:    age(sw.CreatedAt, sw.ModifiedAt),
+			})
+		}
+	}
+
+	if f.enabled(KindIPv6Addr) {
+		addrs, err := ipv6addr.New(f.Caller).FindAllWithContext(ctx, &ipv6addr.FindRequest{Zone: zone})
+		if err != nil {
+			return candidates, err
+		}
+		for _, addr := range addrs {
+			if !addr.InterfaceID.IsEmpty() {
+				continue
+			}
+			f.add(&candidates, &Candidate{
+				Kind:   KindIPv6Addr,
+				Zone:   zone,
+				Name:   addr.IPv6Addr,
+				Reason: "not assigned to any interface",
+			})
+		}
+	}
+
+	return candidates, nil
+}
+
+func (f *Finder) findGlobal(ctx context.Context) ([]*Candidate, error) {
+	var candidates []*Candidate
+
+	if f.enabled(KindSSHKey) && f.SSHKeyName != nil {
+		keys, err := sshkey.New(f.Caller).FindAllWithContext(ctx, &sshkey.FindRequest{})
+		if err != nil {
+			return candidates, err
+		}
+		for _, key := range keys {
+			if !f.SSHKeyName.MatchString(key.Name) {
+				continue
+			}
+			f.add(&candidates, &Candidate{
+				Kind:   KindSSHKey,
+				ID:     key.ID,
+				Name:   key.Name,
+				Reason: "generated ssh key left behind",
+				Age:    age(key.CreatedAt, time.Time{}),
+			})
+		}
+	}
+
+	if f.enabled(KindNote) {
+		notes, err := note.New(f.Caller).FindAllWithContext(ctx, &note.FindRequest{Scope: types.Scopes.User})
+		if err != nil {
+			return candidates, err
+		}
+		for _, n := range notes {
+			if n.Scope != types.Scopes.User || n.Class != "shell" || !diskEditNoteName.MatchString(n.Name) {
+				continue
+			}
+			f.add(&candidates, &Candidate{
+				Kind:   KindNote,
+				ID:     n.ID,
+				Name:   n.Name,
+				Tags:   n.Tags,
+				Reason: "startup script created by disk edit left behind",
+				Age:    age(n.CreatedAt, n.ModifiedAt),
+			})
+		}
+	}
+
+	return candidates, nil
+}
+
+func (f *Finder) enabled(kind Kind) bool {
+	if len(f.Kinds) == 0 {
+		return true
+	}
+	for _, k := range f.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Finder) stoppedServerAge() time.Duration {
+	if f.StoppedServerAge > 0 {
+		return f.StoppedServerAge
+	}
+	return DefaultStoppedServerAge
+}
+
+// skip AllowTagsやMinAgeにより削除候補から除外するか
+func (f *Finder) skip(tags types.Tags, age time.Duration) bool {
+	for _, tag := range f.AllowTags {
+		for _, t := range tags {
+			if t == tag {
+				return true
+			}
+		}
+	}
+	return age > 0 && age < f.MinAge
+}
+
+func (f *Finder) add(candidates *[]*Candidate, c *Candidate) {
+	if f.skip(c.Tags, c.Age) {
+		return
+	}
+	*candidates = append(*candidates, c)
+}
+
+// age 最終更新からの経過時間を返す、更新日時を持たない場合は作成日時を用いる
+func age(createdAt, modifiedAt time.Time) time.Duration {
+	t := modifiedAt
+	if t.IsZero() {
+		t = createdAt
+	}
+	if t.IsZero() {
+		return 0
+	}
+	return time.Since(t)
+}
+
+// Delete 削除候補を各リソースのDeleteサービスを用いて削除する
+//
+// リソース種別ごとにKindsの順番で削除する。サーバはディスクを残して削除するため、
+// 接続されていたディスクは次回のFindでKindDiskの削除候補となる。
+// 削除に失敗した候補があっても残りの候補の削除を続け、エラーはerrors.Joinでまとめて返す。
+func Delete(ctx context.Context, caller iaas.APICaller, candidates []*Candidate) error {
+	order := make(map[Kind]int)
+	for i, k := range Kinds {
+		order[k] = i
+	}
+	sorted := append([]*Candidate{}, candidates...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return order[sorted[i].Kind] < order[sorted[j].Kind]
+	})
+
+	var errs []error
+	for _, c := range sorted {
+		if err := deleteCandidate(ctx, caller, c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func deleteCandidate(ctx context.Context, caller iaas.APICaller, c *Candidate) error {
+	switch c.Kind {
+	case KindServer:
+		return server.New(caller).DeleteWithContext(ctx, &server.DeleteRequest{Zone: c.Zone, ID: c.ID})
+	case KindDisk:
+		return disk.New(caller).DeleteWithContext(ctx, &disk.DeleteRequest{Zone: c.Zone, ID: c.ID})
+	case KindArchive:
+		return archive.New(caller).DeleteWithContext(ctx, &archive.DeleteRequest{Zone: c.Zone, ID: c.ID})
+	case KindPacketFilter:
+		return packetfilter.New(caller).DeleteWithContext(ctx, &packetfilter.DeleteRequest{Zone: c.Zone, ID: c.ID})
+	case KindSwitch:
+		return swytch.New(caller).DeleteWithContext(ctx, &swytch.DeleteRequest{Zone: c.Zone, ID: c.ID})
+	case KindIPv6Addr:
+		return ipv6addr.New(caller).DeleteWithContext(ctx, &ipv6addr.DeleteRequest{Zone: c.Zone, IPv6Addr: c.Name})
+	case KindSSHKey:
+		return sshkey.New(caller).DeleteWithContext(ctx, &sshkey.DeleteRequest{ID: c.ID})
+	case KindNote:
+		return note.New(caller).DeleteWithContext(ctx, &note.DeleteRequest{ID: c.ID})
+	}
+	return fmt.Errorf("unsupported kind: %s", c.Kind)
+}
@@ -0,0 +1,94 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/cleanup"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinder(t *testing.T) {
+	ctx := context.Background()
+	caller := testutil.SingletonAPICaller()
+	zone := testutil.TestZone()
+
+	orphan, err := iaas.NewDiskOp(caller).Create(ctx, zone, &iaas.DiskCreateRequest{
+		Name:       testutil.ResourceName("cleanup-orphan"),
+		DiskPlanID: types.DiskPlans.SSD,
+		SizeMB:     20 * 1024,
+	}, nil)
+	require.NoError(t, err)
+	allowed, err := iaas.NewDiskOp(caller).Create(ctx, zone, &iaas.DiskCreateRequest{
+		Name:       testutil.ResourceName("cleanup-allowed"),
+		DiskPlanID: types.DiskPlans.SSD,
+		SizeMB:     20 * 1024,
+		Tags:       types.Tags{"keep"},
+	}, nil)
+	require.NoError(t, err)
+	// コピー中のディスクは削除候補に含まれないため完了を待つ
+	for _, id := range []types.ID{orphan.ID, allowed.ID} {
+		require.Eventually(t, func() bool {
+			disk, err := iaas.NewDiskOp(caller).Read(ctx, zone, id)
+			return err == nil && disk.Availability.IsAvailable()
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+	sw, err := iaas.NewSwitchOp(caller).Create(ctx, zone, &iaas.SwitchCreateRequest{
+		Name: testutil.ResourceName("cleanup-switch"),
+	})
+	require.NoError(t, err)
+	key, err := iaas.NewSSHKeyOp(caller).Generate(ctx, &iaas.SSHKeyGenerateRequest{
+		Name: "generated-cleanup",
+	})
+	require.NoError(t, err)
+
+	finder := &cleanup.Finder{
+		Caller:     caller,
+		Zones:      []string{zone},
+		Kinds:      []cleanup.Kind{cleanup.KindDisk, cleanup.KindSwitch, cleanup.KindSSHKey},
+		AllowTags:  types.Tags{"keep"},
+		SSHKeyName: regexp.MustCompile("^generated-"),
+	}
+	candidates, err := finder.Find(ctx)
+	require.NoError(t, err)
+
+	found := make(map[types.ID]*cleanup.Candidate)
+	for _, c := range candidates {
+		found[c.ID] = c
+	}
+	require.Contains(t, found, orphan.ID)
+	require.Equal(t, cleanup.KindDisk, found[orphan.ID].Kind)
+	require.Equal(t, zone, found[orphan.ID].Zone)
+	require.NotContains(t, found, allowed.ID)
+	require.Contains(t, found, sw.ID)
+	require.Contains(t, found, key.ID)
+	require.Empty(t, found[key.ID].Zone)
+
+	// 削除
+	require.NoError(t, cleanup.Delete(ctx, caller, []*cleanup.Candidate{found[orphan.ID], found[sw.ID], found[key.ID]}))
+
+	candidates, err = finder.Find(ctx)
+	require.NoError(t, err)
+	for _, c := range candidates {
+		require.NotContains(t, []types.ID{orphan.ID, sw.ID, key.ID}, c.ID)
+	}
+}
@@ -15,9 +15,13 @@
 package containerregistry
 
 import (
+	"context"
+
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/containerregistry/builder"
+	"github.com/sacloud/iaas-service-go/secret"
 	"github.com/sacloud/packages-go/validate"
 )
 
@@ -36,8 +40,16 @@ type ApplyRequest struct {
 	SettingsHash string
 }
 
+// String 秘匿情報を伏せた文字列表現を返す
+func (req *ApplyRequest) String() string {
+	return secret.Redacted(req)
+}
+
 func (req *ApplyRequest) Validate() error {
-	return validate.New().Struct(req)
+	if err := validate.New().Struct(req); err != nil {
+		return err
+	}
+	return service.ValidateNoSecretPlaceholder(req)
 }
 
 func (req *ApplyRequest) Builder(caller iaas.APICaller) (*builder.Builder, error) {
@@ -55,3 +67,21 @@ func (req *ApplyRequest) Builder(caller iaas.APICaller) (*builder.Builder, error
 		Client:         iaas.NewContainerRegistryOp(caller),
 	}, nil
 }
+
+// Preflight 参照先のリソースを確認し、検出した全ての問題を返す
+//
+// リソースの作成や更新は行わない
+func (req *ApplyRequest) Preflight(ctx context.Context, caller iaas.APICaller) error {
+	p := service.NewPreflight()
+
+	p.Ref("ID", "container registry", "", req.ID, func() error {
+		_, err := iaas.NewContainerRegistryOp(caller).Read(ctx, req.ID)
+		return err
+	})
+	p.Ref("IconID", "icon", "", req.IconID, func() error {
+		_, err := iaas.NewIconOp(caller).Read(ctx, req.IconID)
+		return err
+	})
+
+	return p.Err()
+}
@@ -81,6 +81,14 @@ func (b *Builder) update(ctx context.Context) (*iaas.ContainerRegistry, error) {
 		return nil, errors.New("SubDomainLabel cannot be changed")
 	}
 
+	plan, err := b.plan(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.HasChanges() {
+		return current, nil
+	}
+
 	updated, err := b.Client.Update(ctx, b.ID, &iaas.ContainerRegistryUpdateRequest{
 		Name:          b.Name,
 		Description:   b.Description,
@@ -0,0 +1,100 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+// Plan Build時の変更内容を算出する
+//
+// IDが空の場合は新規作成を表すPlanを返す。APIによるリソースの変更は行わない。
+func (b *Builder) Plan(ctx context.Context) (*service.Plan, error) {
+	if b.ID.IsEmpty() {
+		return service.NewCreatePlan(map[string]interface{}{
+			"Name":           b.Name,
+			"Description":    b.Description,
+			"Tags":           b.Tags,
+			"IconID":         b.IconID,
+			"AccessLevel":    b.AccessLevel,
+			"VirtualDomain":  b.VirtualDomain,
+			"SubDomainLabel": b.SubDomainLabel,
+			"Users":          userNames(b.Users),
+		}), nil
+	}
+
+	current, err := b.Client.Read(ctx, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	return b.plan(ctx, current)
+}
+
+func (b *Builder) plan(ctx context.Context, current *iaas.ContainerRegistry) (*service.Plan, error) {
+	plan := service.NewPlan()
+
+	plan.Diff("Name", current.Name, b.Name)
+	plan.Diff("Description", current.Description, b.Description)
+	plan.Diff("Tags", current.Tags, b.Tags)
+	plan.Diff("IconID", current.IconID, b.IconID)
+	plan.Diff("AccessLevel", current.AccessLevel, b.AccessLevel)
+	plan.Diff("VirtualDomain", current.VirtualDomain, b.VirtualDomain)
+	plan.DiffReplace("SubDomainLabel", current.SubDomainLabel, b.SubDomainLabel)
+
+	currentUsers, err := b.Client.ListUsers(ctx, current.ID)
+	if err != nil {
+		return nil, err
+	}
+	var users []*iaas.ContainerRegistryUser
+	if currentUsers != nil {
+		users = currentUsers.Users
+	}
+
+	for _, name := range b.deletedUsers(users) {
+		plan.Diff(fmt.Sprintf("Users[%s]", name), name, nil)
+	}
+	for _, desired := range b.updatedUsers(users) {
+		for _, u := range users {
+			if u.UserName == desired.UserName {
+				plan.Diff(fmt.Sprintf("Users[%s].Permission", desired.UserName), u.Permission, desired.Permission)
+				break
+			}
+		}
+		// パスワードは現在値を参照できないため、指定されていれば常に変更ありとみなす
+		if desired.Password != "" {
+			plan.Add(&service.FieldChange{
+				Name:        fmt.Sprintf("Users[%s].Password", desired.UserName),
+				After:       service.SecretPlaceholder,
+				UpdateLevel: service.UpdateLevelSimple,
+			})
+		}
+	}
+	for _, created := range b.createdUsers(users) {
+		plan.Diff(fmt.Sprintf("Users[%s]", created.UserName), nil, created.UserName)
+	}
+	return plan, nil
+}
+
+func userNames(users []*User) []string {
+	var names []string
+	for _, u := range users {
+		names = append(names, u.UserName)
+	}
+	return names
+}
@@ -17,6 +17,7 @@ package containerregistry
 import (
 	"github.com/sacloud/iaas-api-go/types"
 	"github.com/sacloud/iaas-service-go/containerregistry/builder"
+	"github.com/sacloud/iaas-service-go/secret"
 	"github.com/sacloud/packages-go/validate"
 )
 
@@ -31,6 +32,11 @@ type CreateRequest struct {
 	Users          []*builder.User
 }
 
+// String 秘匿情報を伏せた文字列表現を返す
+func (req *CreateRequest) String() string {
+	return secret.Redacted(req)
+}
+
 func (req *CreateRequest) Validate() error {
 	return validate.New().Struct(req)
 }
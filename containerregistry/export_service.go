@@ -0,0 +1,69 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerregistry
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/containerregistry/builder"
+)
+
+func (s *Service) Export(req *ExportRequest) (*ApplyRequest, error) {
+	return s.ExportWithContext(context.Background(), req)
+}
+
+func (s *Service) ExportWithContext(ctx context.Context, req *ExportRequest) (*ApplyRequest, error) {
+	return service.Invoke(ctx, s.interceptors, "containerregistry", "export", req, s.export)
+}
+
+func (s *Service) export(ctx context.Context, req *ExportRequest) (*ApplyRequest, error) {
+	if err := req.Validate(); err != nil {
+		return nil, service.ValidationError(err)
+	}
+
+	client := iaas.NewContainerRegistryOp(s.caller)
+	current, err := client.Read(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	users, err := client.ListUsers(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := &ApplyRequest{
+		ID:             current.ID,
+		Name:           current.Name,
+		Description:    current.Description,
+		Tags:           current.Tags,
+		IconID:         current.IconID,
+		AccessLevel:    current.AccessLevel,
+		VirtualDomain:  current.VirtualDomain,
+		SubDomainLabel: current.SubDomainLabel,
+		SettingsHash:   current.SettingsHash,
+	}
+	if users != nil {
+		for _, u := range users.Users {
+			exported.Users = append(exported.Users, &builder.User{
+				UserName:   u.UserName,
+				Password:   service.SecretPlaceholder,
+				Permission: u.Permission,
+			})
+		}
+	}
+	return exported, nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerregistry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/containerregistry/builder"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerRegistryService_Plan(t *testing.T) {
+	ctx := context.Background()
+	caller := testutil.SingletonAPICaller()
+	name := testutil.ResourceName("container-registry-service-plan")
+	svc := New(caller)
+
+	req := &ApplyRequest{
+		Name:           name,
+		Description:    "desc",
+		Tags:           types.Tags{"tag1", "tag2"},
+		AccessLevel:    types.ContainerRegistryAccessLevels.ReadWrite,
+		SubDomainLabel: name,
+		Users: []*builder.User{
+			{
+				UserName:   "user1",
+				Password:   "password1",
+				Permission: types.ContainerRegistryPermissions.ReadWrite,
+			},
+		},
+	}
+
+	plan, err := svc.PlanWithContext(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, service.PlanActionCreate, plan.Action)
+
+	created, err := svc.ApplyWithContext(ctx, req)
+	require.NoError(t, err)
+	defer iaas.NewContainerRegistryOp(caller).Delete(ctx, created.ID) //nolint
+
+	// パスワード未指定 & 変更なし
+	req.ID = created.ID
+	req.Users[0].Password = ""
+	plan, err = svc.PlanWithContext(ctx, req)
+	require.NoError(t, err)
+	require.False(t, plan.HasChanges())
+
+	req.Description = "desc-upd"
+	req.SubDomainLabel = name + "-upd"
+	plan, err = svc.PlanWithContext(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, service.PlanActionReplace, plan.Action)
+	require.Len(t, plan.Changes, 2)
+}
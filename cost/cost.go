@@ -0,0 +1,353 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cost リクエストに対応するサービスクラスの料金から見積もりを算出する
+package cost
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/helper/query"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/database"
+	"github.com/sacloud/iaas-service-go/disk"
+	"github.com/sacloud/iaas-service-go/loadbalancer"
+	"github.com/sacloud/iaas-service-go/nfs"
+	"github.com/sacloud/iaas-service-go/proxylb"
+	"github.com/sacloud/iaas-service-go/server"
+	"github.com/sacloud/iaas-service-go/serviceclass"
+	"github.com/sacloud/iaas-service-go/vpcrouter"
+	"github.com/sacloud/packages-go/size"
+)
+
+// Price 料金(円)
+type Price struct {
+	Hourly  int
+	Daily   int
+	Monthly int
+}
+
+// Add pとotherの合計を返す
+func (p Price) Add(other Price) Price {
+	return Price{Hourly: p.Hourly + other.Hourly, Daily: p.Daily + other.Daily, Monthly: p.Monthly + other.Monthly}
+}
+
+// Sub pとotherの差を返す
+func (p Price) Sub(other Price) Price {
+	return Price{Hourly: p.Hourly - other.Hourly, Daily: p.Daily - other.Daily, Monthly: p.Monthly - other.Monthly}
+}
+
+// Item 見積もりの内訳
+type Item struct {
+	// Name 内訳の名前 例: server, Disks[0]
+	Name string
+	// ServiceClass サービスクラスのパス、対応するサービスクラスを特定できない場合は空
+	ServiceClass string
+	// Price サービスクラスが見つからなかった場合はゼロ値
+	Price Price
+}
+
+// Estimate 見積もり
+type Estimate struct {
+	Zone  string
+	Items []*Item
+	// Total 料金を特定できた内訳の合計
+	Total Price
+	// Unpriced サービスクラスが見つからず料金を特定できなかった内訳
+	Unpriced []*Item
+
+	// Current 更新対象の現在のリソースの見積もり、新規作成の場合はnil
+	Current *Estimate
+	// Delta 現在のリソースとの差額、新規作成の場合はTotalと同じ
+	Delta Price
+}
+
+// Estimator リクエストから見積もりを算出する
+//
+// 以下のリクエストに対応する。
+//   - server: CreateRequest/ApplyRequest(ディスクを含む)
+//   - disk: CreateRequest/ApplyRequest
+//   - database: CreateRequest/ApplyRequest
+//   - vpcrouter: CreateRequest/CreateStandardRequest/ApplyRequest
+//   - loadbalancer: CreateRequest/ApplyRequest
+//   - nfs: CreateRequest/ApplyRequest
+//   - proxylb: CreateRequest
+//
+// IDが指定されたApplyRequestの場合は現在のリソースの見積もりと差額も算出する。
+type Estimator struct {
+	Caller iaas.APICaller
+	// Aliases サービスクラスのパスの読み替え、キーはこのパッケージが組み立てたパス
+	Aliases map[string]string
+}
+
+// NewEstimator Estimatorを作成する
+func NewEstimator(caller iaas.APICaller) *Estimator {
+	return &Estimator{Caller: caller}
+}
+
+// Estimate reqの見積もりを返す
+func (e *Estimator) Estimate(ctx context.Context, req interface{}) (*Estimate, error) {
+	zone, requested, current, err := e.items(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	classes, err := serviceclass.New(e.Caller).FindAllWithContext(ctx, &serviceclass.FindRequest{Zone: zone})
+	if err != nil {
+		return nil, err
+	}
+	prices := make(map[string]*iaas.Price)
+	for _, class := range classes {
+		if class.Price != nil {
+			prices[class.ServiceClassPath] = class.Price
+		}
+	}
+
+	estimate := e.estimate(zone, requested, prices)
+	estimate.Delta = estimate.Total
+	if current != nil {
+		estimate.Current = e.estimate(zone, current, prices)
+		estimate.Delta = estimate.Total.Sub(estimate.Current.Total)
+	}
+	return estimate, nil
+}
+
+func (e *Estimator) estimate(zone string, items []*Item, prices map[string]*iaas.Price) *Estimate {
+	estimate := &Estimate{Zone: zone, Items: items}
+	for _, item := range items {
+		path := item.ServiceClass
+		if alias, ok := e.Aliases[path]; ok {
+			path = alias
+		}
+		price, ok := prices[path]
+		if !ok || path == "" {
+			estimate.Unpriced = append(estimate.Unpriced, item)
+			continue
+		}
+		item.Price = Price{Hourly: price.Hourly, Daily: price.Daily, Monthly: price.Monthly}
+		estimate.Total = estimate.Total.Add(item.Price)
+	}
+	return estimate
+}
+
+// items リクエストと現在のリソースの内訳を返す、現在のリソースが存在しない場合はcurrentはnil
+func (e *Estimator) items(ctx context.Context, req interface{}) (zone string, requested, current []*Item, err error) {
+	switch req := req.(type) {
+	case *server.CreateRequest:
+		requested, err = e.serverItems(ctx, req.Zone, serverPlan(req.CPU, req.MemoryGB, req.GPU, req.CPUModel, req.Commitment, req.Generation), req.Disks)
+		return req.Zone, requested, nil, err
+	case *server.ApplyRequest:
+		requested, err = e.serverItems(ctx, req.Zone, serverPlan(req.CPU, req.MemoryGB, req.GPU, req.CPUModel, req.Commitment, req.Generation), req.Disks)
+		if err != nil || req.ID.IsEmpty() {
+			return req.Zone, requested, nil, err
+		}
+		current, err = e.currentServerItems(ctx, req.Zone, req.ID)
+		return req.Zone, requested, current, err
+
+	case *disk.CreateRequest:
+		return req.Zone, []*Item{diskItem("disk", req.DiskPlanID, req.SizeGB)}, nil, nil
+	case *disk.ApplyRequest:
+		requested, err = e.diskItems(ctx, "disk", req)
+		if err != nil || req.ID.IsEmpty() {
+			return req.Zone, requested, nil, err
+		}
+		current, err = e.currentDiskItems(ctx, "disk", req.Zone, req.ID)
+		return req.Zone, requested, current, err
+
+	case *database.CreateRequest:
+		requested, err = e.databaseItems(ctx, req.PlanID)
+		return req.Zone, requested, nil, err
+	case *database.ApplyRequest:
+		requested, err = e.databaseItems(ctx, req.PlanID)
+		if err != nil || req.ID.IsEmpty() {
+			return req.Zone, requested, nil, err
+		}
+		db, err := iaas.NewDatabaseOp(e.Caller).Read(ctx, req.Zone, req.ID)
+		if err != nil {
+			return req.Zone, nil, nil, err
+		}
+		current, err = e.databaseItems(ctx, db.PlanID)
+		return req.Zone, requested, current, err
+
+	case *vpcrouter.CreateRequest:
+		return req.Zone, vpcRouterItems(req.PlanID), nil, nil
+	case *vpcrouter.CreateStandardRequest:
+		return req.Zone, vpcRouterItems(types.VPCRouterPlans.Standard), nil, nil
+	case *vpcrouter.ApplyRequest:
+		requested = vpcRouterItems(req.PlanID)
+		if req.ID.IsEmpty() {
+			return req.Zone, requested, nil, nil
+		}
+		router, err := iaas.NewVPCRouterOp(e.Caller).Read(ctx, req.Zone, req.ID)
+		if err != nil {
+			return req.Zone, nil, nil, err
+		}
+		return req.Zone, requested, vpcRouterItems(router.PlanID), nil
+
+	case *loadbalancer.CreateRequest:
+		return req.Zone, loadBalancerItems(req.PlanID), nil, nil
+	case *loadbalancer.ApplyRequest:
+		requested = loadBalancerItems(req.PlanID)
+		if req.ID.IsEmpty() {
+			return req.Zone, requested, nil, nil
+		}
+		lb, err := iaas.NewLoadBalancerOp(e.Caller).Read(ctx, req.Zone, req.ID)
+		if err != nil {
+			return req.Zone, nil, nil, err
+		}
+		return req.Zone, requested, loadBalancerItems(lb.PlanID), nil
+
+	case *nfs.CreateRequest:
+		return req.Zone, nfsItems(req.Plan, req.Size), nil, nil
+	case *nfs.ApplyRequest:
+		requested = nfsItems(req.Plan, req.Size)
+		if req.ID.IsEmpty() {
+			return req.Zone, requested, nil, nil
+		}
+		current, err = e.currentNFSItems(ctx, req.Zone, req.ID)
+		return req.Zone, requested, current, err
+
+	case *proxylb.CreateRequest:
+		// エンハンスドロードバランサはグローバルリソースのためデフォルトゾーンの料金を用いる
+		return iaas.APIDefaultZone, []*Item{{Name: "proxylb", ServiceClass: types.ProxyLBServiceClass(req.Plan, req.Region)}}, nil, nil
+	}
+	return "", nil, nil, fmt.Errorf("unsupported request type: %T", req)
+}
+
+// serverPlan リクエストで指定されたプランを返す
+func serverPlan(cpu, memoryGB, gpu int, cpuModel string, commitment types.ECommitment, generation types.EPlanGeneration) *iaas.ServerPlan {
+	// 省略時はserver/builderのデフォルト値(1コア/1GB)が用いられる
+	if cpu == 0 {
+		cpu = 1
+	}
+	if memoryGB == 0 {
+		memoryGB = 1
+	}
+	return &iaas.ServerPlan{
+		CPU:        cpu,
+		MemoryMB:   memoryGB * size.GiB,
+		GPU:        gpu,
+		CPUModel:   cpuModel,
+		Commitment: commitment,
+		Generation: generation,
+	}
+}
+
+func (e *Estimator) serverItems(ctx context.Context, zone string, plan *iaas.ServerPlan, disks []*disk.ApplyRequest) ([]*Item, error) {
+	items := []*Item{{Name: "server", ServiceClass: ServerServiceClass(plan)}}
+	for i, d := range disks {
+		if d.Zone == "" {
+			d = withZone(d, zone)
+		}
+		diskItems, err := e.diskItems(ctx, fmt.Sprintf("Disks[%d]", i), d)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, diskItems...)
+	}
+	return items, nil
+}
+
+func (e *Estimator) currentServerItems(ctx context.Context, zone string, id types.ID) ([]*Item, error) {
+	s, err := iaas.NewServerOp(e.Caller).Read(ctx, zone, id)
+	if err != nil {
+		return nil, err
+	}
+	items := []*Item{{Name: "server", ServiceClass: ServerServiceClass(&iaas.ServerPlan{
+		CPU:        s.CPU,
+		MemoryMB:   s.MemoryMB,
+		GPU:        s.GPU,
+		CPUModel:   s.ServerPlanCPUModel,
+		Commitment: s.ServerPlanCommitment,
+		Generation: s.ServerPlanGeneration,
+	})}}
+	for i, d := range s.Disks {
+		diskItems, err := e.currentDiskItems(ctx, fmt.Sprintf("Disks[%d]", i), zone, d.ID)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, diskItems...)
+	}
+	return items, nil
+}
+
+// diskItems ディスクの内訳を返す、既存ディスクでプランやサイズが省略されている場合は現在の値を用いる
+func (e *Estimator) diskItems(ctx context.Context, name string, req *disk.ApplyRequest) ([]*Item, error) {
+	planID, sizeGB := req.DiskPlanID, req.SizeGB
+	if !req.ID.IsEmpty() && (planID.IsEmpty() || sizeGB == 0) {
+		d, err := iaas.NewDiskOp(e.Caller).Read(ctx, req.Zone, req.ID)
+		if err != nil {
+			return nil, err
+		}
+		if planID.IsEmpty() {
+			planID = d.DiskPlanID
+		}
+		if sizeGB == 0 {
+			sizeGB = d.GetSizeGB()
+		}
+	}
+	return []*Item{diskItem(name, planID, sizeGB)}, nil
+}
+
+func (e *Estimator) currentDiskItems(ctx context.Context, name, zone string, id types.ID) ([]*Item, error) {
+	d, err := iaas.NewDiskOp(e.Caller).Read(ctx, zone, id)
+	if err != nil {
+		return nil, err
+	}
+	return []*Item{diskItem(name, d.DiskPlanID, d.GetSizeGB())}, nil
+}
+
+func diskItem(name string, planID types.ID, sizeGB int) *Item {
+	return &Item{Name: name, ServiceClass: DiskServiceClass(planID, sizeGB)}
+}
+
+func withZone(req *disk.ApplyRequest, zone string) *disk.ApplyRequest {
+	d := *req
+	d.Zone = zone
+	return &d
+}
+
+func (e *Estimator) databaseItems(ctx context.Context, planID types.ID) ([]*Item, error) {
+	class, err := databaseServiceClass(ctx, e.Caller, planID)
+	if err != nil {
+		return nil, err
+	}
+	return []*Item{{Name: "database", ServiceClass: class}}, nil
+}
+
+func vpcRouterItems(planID types.ID) []*Item {
+	return []*Item{{Name: "vpcrouter", ServiceClass: VPCRouterServiceClass(planID)}}
+}
+
+func loadBalancerItems(planID types.ID) []*Item {
+	return []*Item{{Name: "loadbalancer", ServiceClass: LoadBalancerServiceClass(planID)}}
+}
+
+func nfsItems(plan types.ID, size types.ENFSSize) []*Item {
+	return []*Item{{Name: "nfs", ServiceClass: NFSServiceClass(plan, size)}}
+}
+
+func (e *Estimator) currentNFSItems(ctx context.Context, zone string, id types.ID) ([]*Item, error) {
+	current, err := iaas.NewNFSOp(e.Caller).Read(ctx, zone, id)
+	if err != nil {
+		return nil, err
+	}
+	info, err := query.GetNFSPlanInfo(ctx, iaas.NewNoteOp(e.Caller), current.PlanID)
+	if err != nil {
+		return nil, err
+	}
+	return nfsItems(info.DiskPlanID, info.Size), nil
+}
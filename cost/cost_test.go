@@ -0,0 +1,113 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/cost"
+	"github.com/sacloud/iaas-service-go/disk"
+	"github.com/sacloud/iaas-service-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceClass(t *testing.T) {
+	plan := func(cpu, memoryGB, gpu int, cpuModel string, commitment types.ECommitment, generation types.EPlanGeneration) *iaas.ServerPlan {
+		return &iaas.ServerPlan{CPU: cpu, MemoryMB: memoryGB * 1024, GPU: gpu, CPUModel: cpuModel, Commitment: commitment, Generation: generation}
+	}
+	require.Equal(t, "cloud/plan/2core-4gb", cost.ServerServiceClass(plan(2, 4, 0, "", types.Commitments.Standard, types.PlanGenerations.Default)))
+	require.Equal(t, "cloud/plan/2core-4gb", cost.ServerServiceClass(plan(2, 4, 0, "uncategorized", types.Commitments.Standard, types.PlanGenerations.G100)))
+	require.Equal(t, "cloud/plan/dedicatedcpu/2core-4gb", cost.ServerServiceClass(plan(2, 4, 0, "", types.Commitments.DedicatedCPU, 0)))
+	require.Equal(t, "cloud/plan/gpu/4core-56gb-1gpu", cost.ServerServiceClass(plan(4, 56, 1, "", types.Commitments.Standard, 0)))
+
+	// CPUモデルや世代が異なるプランは区別する
+	require.Equal(t, "cloud/plan/amd_epyc_7713p/2core-4gb", cost.ServerServiceClass(plan(2, 4, 0, "amd_epyc_7713p", types.Commitments.Standard, 0)))
+	require.Equal(t, "cloud/plan/g200/2core-4gb", cost.ServerServiceClass(plan(2, 4, 0, "", types.Commitments.Standard, types.PlanGenerations.G200)))
+	require.Equal(t, "cloud/plan/dedicatedcpu/amd_epyc_7713p/g200/2core-4gb",
+		cost.ServerServiceClass(plan(2, 4, 0, "amd_epyc_7713p", types.Commitments.DedicatedCPU, types.PlanGenerations.G200)))
+
+	require.Equal(t, "cloud/disk/ssd/20g", cost.DiskServiceClass(types.DiskPlans.SSD, 20))
+	require.Equal(t, "cloud/disk/hdd/2048g", cost.DiskServiceClass(types.DiskPlans.HDD, 2048))
+	require.Equal(t, "", cost.DiskServiceClass(types.ID(1), 20))
+
+	require.Equal(t, "cloud/appliance/nfs/ssd/100g", cost.NFSServiceClass(types.NFSPlans.SSD, types.NFSSSDSizes.Size100GB))
+}
+
+func TestEstimator_Estimate(t *testing.T) {
+	ctx := context.Background()
+	caller := testutil.SingletonAPICaller()
+	zone := testutil.TestZone()
+
+	estimator := cost.NewEstimator(caller)
+	estimator.Aliases = map[string]string{
+		"cloud/plan/1core-1gb": "cloud/plan/1",
+		"cloud/plan/2core-2gb": "cloud/plan/2",
+	}
+
+	t.Run("create", func(t *testing.T) {
+		estimate, err := estimator.Estimate(ctx, &server.CreateRequest{Zone: zone, Name: "cost-test"})
+		require.NoError(t, err)
+		require.Nil(t, estimate.Current)
+		require.Empty(t, estimate.Unpriced)
+		require.Equal(t, cost.Price{Hourly: 10, Daily: 108, Monthly: 2139}, estimate.Total)
+		require.Equal(t, estimate.Total, estimate.Delta)
+
+		// 同じコア数/メモリサイズでもCPUモデルが異なるプランの料金は流用しない
+		estimate, err = estimator.Estimate(ctx, &server.CreateRequest{Zone: zone, Name: "cost-test", CPUModel: "amd_epyc_7713p"})
+		require.NoError(t, err)
+		require.Len(t, estimate.Unpriced, 1)
+		require.Equal(t, "cloud/plan/amd_epyc_7713p/1core-1gb", estimate.Unpriced[0].ServiceClass)
+		require.Equal(t, cost.Price{}, estimate.Total)
+	})
+
+	t.Run("apply existing resource", func(t *testing.T) {
+		current, err := iaas.NewServerOp(caller).Create(ctx, zone, &iaas.ServerCreateRequest{
+			CPU:                  1,
+			MemoryMB:             1024,
+			ServerPlanCommitment: types.Commitments.Standard,
+			Name:                 "cost-test",
+		})
+		require.NoError(t, err)
+
+		estimate, err := estimator.Estimate(ctx, &server.ApplyRequest{
+			Zone:     zone,
+			ID:       current.ID,
+			Name:     current.Name,
+			CPU:      2,
+			MemoryGB: 2,
+			Disks: []*disk.ApplyRequest{
+				{Name: "cost-test", DiskPlanID: types.DiskPlans.SSD, SizeGB: 20},
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, estimate.Current)
+		require.Equal(t, cost.Price{Hourly: 10, Daily: 108, Monthly: 2139}, estimate.Current.Total)
+		require.Equal(t, cost.Price{Hourly: 17, Daily: 172, Monthly: 3425}, estimate.Total)
+		require.Equal(t, cost.Price{Hourly: 7, Daily: 64, Monthly: 1286}, estimate.Delta)
+
+		require.Len(t, estimate.Unpriced, 1)
+		require.Equal(t, "Disks[0]", estimate.Unpriced[0].Name)
+		require.Equal(t, "cloud/disk/ssd/20g", estimate.Unpriced[0].ServiceClass)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := estimator.Estimate(ctx, struct{}{})
+		require.Error(t, err)
+	})
+}
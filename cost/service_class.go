@@ -0,0 +1,134 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/helper/query"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// サービスクラスのパスはさくらのクラウドの料金表の命名規則から組み立てる。
+// 実際のパスと一致しない場合はEstimator.Aliasesで読み替えを指定する。
+
+// defaultCPUModel CPUモデルを区別しないプランのCPUモデル
+const defaultCPUModel = "uncategorized"
+
+// ServerServiceClass サーバプランのサービスクラスのパスを返す 例: cloud/plan/2core-4gb
+//
+// CPUモデルや第2世代以降のプランは同じコア数/メモリサイズでも料金が異なるため、
+// 以下のようにパスに含めて区別する。
+//   - CPUモデル: cloud/plan/amd_epyc_7713p/2core-4gb
+//   - 世代: cloud/plan/g200/2core-4gb
+//
+// CPUモデルが空もしくはuncategorized、世代がDefaultもしくはG100の場合は含めない
+func ServerServiceClass(plan *iaas.ServerPlan) string {
+	path := "cloud/plan/"
+	switch {
+	case plan.GPU > 0:
+		path += "gpu/"
+	case plan.Commitment == types.Commitments.DedicatedCPU:
+		path += "dedicatedcpu/"
+	}
+	if plan.CPUModel != "" && plan.CPUModel != defaultCPUModel {
+		path += plan.CPUModel + "/"
+	}
+	if plan.Generation > types.PlanGenerations.G100 {
+		path += fmt.Sprintf("g%d/", plan.Generation)
+	}
+
+	path += fmt.Sprintf("%dcore-%dgb", plan.CPU, plan.GetMemoryGB())
+	if plan.GPU > 0 {
+		path += fmt.Sprintf("-%dgpu", plan.GPU)
+	}
+	return path
+}
+
+// DiskServiceClass ディスクのサービスクラスのパスを返す 例: cloud/disk/ssd/20g
+//
+// ディスクプランもしくはサイズが不明な場合は空文字を返す
+func DiskServiceClass(diskPlanID types.ID, sizeGB int) string {
+	name := diskPlanName(diskPlanID)
+	if name == "" || sizeGB <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("cloud/disk/%s/%dg", name, sizeGB)
+}
+
+// NFSServiceClass NFSのサービスクラスのパスを返す 例: cloud/appliance/nfs/ssd/100g
+//
+// planにはtypes.NFSPlans.HDDもしくはtypes.NFSPlans.SSDを指定する
+func NFSServiceClass(plan types.ID, size types.ENFSSize) string {
+	var name string
+	switch plan {
+	case types.NFSPlans.HDD:
+		name = "hdd"
+	case types.NFSPlans.SSD:
+		name = "ssd"
+	}
+	if name == "" || size <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("cloud/appliance/nfs/%s/%dg", name, size)
+}
+
+// VPCRouterServiceClass VPCルータのサービスクラスのパスを返す 例: cloud/appliance/vpc/premium
+func VPCRouterServiceClass(planID types.ID) string {
+	name, ok := types.VPCRouterPlanNameMap[planID]
+	if !ok {
+		return ""
+	}
+	return "cloud/appliance/vpc/" + name
+}
+
+// LoadBalancerServiceClass ロードバランサのサービスクラスのパスを返す 例: cloud/appliance/loadbalancer/standard
+func LoadBalancerServiceClass(planID types.ID) string {
+	name, ok := types.LoadBalancerPlanNameMap[planID]
+	if !ok {
+		return ""
+	}
+	return "cloud/appliance/loadbalancer/" + name
+}
+
+func diskPlanName(diskPlanID types.ID) string {
+	switch diskPlanID {
+	case types.DiskPlans.SSD:
+		return "ssd"
+	case types.DiskPlans.HDD:
+		return "hdd"
+	}
+	return ""
+}
+
+// databaseServiceClass データベースのプランIDに対応するサービスクラスのパスをシステム情報(sys-database)から取得する
+func databaseServiceClass(ctx context.Context, caller iaas.APICaller, planID types.ID) (string, error) {
+	for _, model := range []string{"Standard", "Proxy"} {
+		plans, err := query.ListDatabasePlan(ctx, iaas.NewNoteOp(caller), model)
+		if err != nil {
+			return "", err
+		}
+		for _, plan := range plans {
+			for _, disk := range plan.DiskSizes {
+				if disk.PlanID == planID {
+					return disk.ServiceClass, nil
+				}
+			}
+		}
+	}
+	return "", nil
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) List() ([]*iaas.Coupon, error) {
@@ -25,6 +26,12 @@ func (s *Service) List() ([]*iaas.Coupon, error) {
 }
 
 func (s *Service) ListWithContext(ctx context.Context) ([]*iaas.Coupon, error) {
+	return service.Invoke[interface{}, []*iaas.Coupon](ctx, s.interceptors, "coupon", "list", nil, func(ctx context.Context, _ interface{}) ([]*iaas.Coupon, error) {
+		return s.list(ctx)
+	})
+}
+
+func (s *Service) list(ctx context.Context) ([]*iaas.Coupon, error) {
 	authOp := iaas.NewAuthStatusOp(s.caller)
 	couponOp := iaas.NewCouponOp(s.caller)
 
@@ -15,11 +15,14 @@
 package database
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
 	builder2 "github.com/sacloud/iaas-service-go/database/builder"
+	"github.com/sacloud/iaas-service-go/secret"
 	"github.com/sacloud/packages-go/validate"
 )
 
@@ -54,8 +57,24 @@ type ApplyRequest struct {
 	NoWait bool
 }
 
+// String 秘匿情報を伏せた文字列表現を返す
+func (req *ApplyRequest) String() string {
+	return secret.Redacted(req)
+}
+
+// UnusedSecretFields secret.UnusedSecretsの実装
+func (req *ApplyRequest) UnusedSecretFields() []string {
+	if !req.EnableReplication {
+		return []string{"ReplicaUserPassword"}
+	}
+	return nil
+}
+
 func (req *ApplyRequest) Validate() error {
-	return validate.New().Struct(req)
+	if err := validate.New().Struct(req); err != nil {
+		return err
+	}
+	return service.ValidateNoSecretPlaceholder(req)
 }
 
 func (req *ApplyRequest) Builder(caller iaas.APICaller) (*builder2.Builder, error) {
@@ -108,3 +127,25 @@ func (req *ApplyRequest) Builder(caller iaas.APICaller) (*builder2.Builder, erro
 	}
 	return builder, nil
 }
+
+// Preflight 参照先のリソースを確認し、検出した全ての問題を返す
+//
+// リソースの作成や更新は行わない
+func (req *ApplyRequest) Preflight(ctx context.Context, caller iaas.APICaller) error {
+	p := service.NewPreflight()
+
+	p.Ref("ID", "database", req.Zone, req.ID, func() error {
+		_, err := iaas.NewDatabaseOp(caller).Read(ctx, req.Zone, req.ID)
+		return err
+	})
+	p.Ref("IconID", "icon", "", req.IconID, func() error {
+		_, err := iaas.NewIconOp(caller).Read(ctx, req.IconID)
+		return err
+	})
+	p.Ref("SwitchID", "switch", req.Zone, req.SwitchID, func() error {
+		_, err := iaas.NewSwitchOp(caller).Read(ctx, req.Zone, req.SwitchID)
+		return err
+	})
+
+	return p.Err()
+}
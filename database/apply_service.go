@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Apply(req *ApplyRequest) (*iaas.Database, error) {
@@ -25,8 +26,12 @@ func (s *Service) Apply(req *ApplyRequest) (*iaas.Database, error) {
 }
 
 func (s *Service) ApplyWithContext(ctx context.Context, req *ApplyRequest) (*iaas.Database, error) {
+	return service.Invoke(ctx, s.interceptors, "database", "apply", req, s.apply)
+}
+
+func (s *Service) apply(ctx context.Context, req *ApplyRequest) (*iaas.Database, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	builder, err := req.Builder(s.caller)
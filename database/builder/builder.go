@@ -24,6 +24,8 @@ import (
 	"github.com/sacloud/iaas-api-go/defaults"
 	"github.com/sacloud/iaas-api-go/helper/power"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/hook"
 	setup2 "github.com/sacloud/iaas-service-go/setup"
 )
 
@@ -59,6 +61,12 @@ type Builder struct {
 
 	SetupOptions *setup2.Options
 	Client       *APIClient
+
+	// Hooks 処理中の各箇所で呼び出すフック
+	//
+	// hook.BeforeUpdateSettings/hook.AfterConfig(Build/Update)、hook.BeforeBoot(Update)を呼び出す。
+	// フックがエラーを返した場合はそれ以降の処理を中断する
+	Hooks *hook.Hooks
 }
 
 func (b *Builder) init() {
@@ -123,10 +131,13 @@ func (b *Builder) create(ctx context.Context, zone string) (*iaas.Database, erro
 		Delete: func(ctx context.Context, zone string, id types.ID) error {
 			return b.Client.Database.Delete(ctx, zone, id)
 		},
+		Shutdown: func(ctx context.Context, zone string, id types.ID) error {
+			return power.ShutdownDatabase(ctx, b.Client.Database, zone, id, true)
+		},
 		Read: func(ctx context.Context, zone string, id types.ID) (interface{}, error) {
 			return b.Client.Database.Read(ctx, zone, id)
 		},
-		ProvisionBeforeUp: func(ctx context.Context, zone string, id types.ID, _ interface{}) error {
+		ProvisionBeforeUp: func(ctx context.Context, zone string, id types.ID, target interface{}) error {
 			if b.NoWait {
 				return nil
 			}
@@ -142,11 +153,18 @@ func (b *Builder) create(ctx context.Context, zone string) (*iaas.Database, erro
 				return err
 			}
 
+			db := target.(*iaas.Database)
+			if err := b.runHook(ctx, hook.BeforeUpdateSettings, zone, id, db); err != nil {
+				return err
+			}
 			if err := b.reconcileDatabaseParameters(ctx, zone, id); err != nil {
 				return err
 			}
 
-			return b.Client.Database.Config(ctx, zone, id)
+			if err := b.Client.Database.Config(ctx, zone, id); err != nil {
+				return err
+			}
+			return b.runHook(ctx, hook.AfterConfig, zone, id, db)
 		},
 		IsWaitForCopy: !b.NoWait,
 		IsWaitForUp:   !b.NoWait,
@@ -184,6 +202,14 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.D
 		return nil, err
 	}
 
+	plan, err := b.plan(ctx, zone, db)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.HasChanges() {
+		return db, nil
+	}
+
 	isNeedShutdown, err := b.collectUpdateInfo(db)
 	if err != nil {
 		return nil, err
@@ -192,7 +218,7 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.D
 	isNeedRestart := false
 	if db.InstanceStatus.IsUp() && isNeedShutdown {
 		if b.NoWait {
-			return nil, errors.New("NoWait option is not available due to the need to shut down")
+			return nil, service.NewError(service.ErrNeedsShutdown, errors.New("NoWait option is not available due to the need to shut down"))
 		}
 
 		isNeedRestart = true
@@ -201,6 +227,9 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.D
 		}
 	}
 
+	if err := b.runHook(ctx, hook.BeforeUpdateSettings, zone, id, db); err != nil {
+		return nil, err
+	}
 	_, err = b.Client.Database.Update(ctx, zone, id, &iaas.DatabaseUpdateRequest{
 		Name:               b.Name,
 		Description:        b.Description,
@@ -220,7 +249,13 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.D
 	if err := b.Client.Database.Config(ctx, zone, id); err != nil {
 		return nil, err
 	}
+	if err := b.runHook(ctx, hook.AfterConfig, zone, id, db); err != nil {
+		return nil, err
+	}
 	if isNeedRestart {
+		if err := b.runHook(ctx, hook.BeforeBoot, zone, id, db); err != nil {
+			return nil, err
+		}
 		if err := power.BootDatabase(ctx, b.Client.Database, zone, id); err != nil {
 			return nil, err
 		}
@@ -234,6 +269,16 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.D
 	return db, err
 }
 
+func (b *Builder) runHook(ctx context.Context, point hook.Point, zone string, id types.ID, db *iaas.Database) error {
+	return b.Hooks.Run(ctx, &hook.Event{
+		Point:    point,
+		Zone:     zone,
+		Resource: "database",
+		ID:       id,
+		Target:   db,
+	})
+}
+
 func (b *Builder) collectUpdateInfo(db *iaas.Database) (isNeedShutdown bool, err error) {
 	isNeedShutdown = b.CommonSetting.ReplicaPassword != db.CommonSetting.ReplicaPassword
 	return
@@ -0,0 +1,165 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+// Plan Build時の変更内容を算出する
+//
+// IDが空の場合は新規作成を表すPlanを返す。APIによるリソースの変更は行わない。
+func (b *Builder) Plan(ctx context.Context) (*service.Plan, error) {
+	if b.ID.IsEmpty() {
+		return service.NewCreatePlan(map[string]interface{}{
+			"Name":               b.Name,
+			"Description":        b.Description,
+			"Tags":               b.Tags,
+			"IconID":             b.IconID,
+			"PlanID":             b.PlanID,
+			"SwitchID":           b.SwitchID,
+			"IPAddresses":        b.IPAddresses,
+			"NetworkMaskLen":     b.NetworkMaskLen,
+			"DefaultRoute":       b.DefaultRoute,
+			"Conf":               b.Conf,
+			"BackupSetting":      b.BackupSetting,
+			"ReplicationSetting": b.ReplicationSetting,
+			"Parameters":         b.Parameters,
+		}), nil
+	}
+
+	db, err := b.Client.Database.Read(ctx, b.Zone, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	return b.plan(ctx, b.Zone, db)
+}
+
+func (b *Builder) plan(ctx context.Context, zone string, db *iaas.Database) (*service.Plan, error) {
+	plan := service.NewPlan()
+
+	plan.Diff("Name", db.Name, b.Name)
+	plan.Diff("Description", db.Description, b.Description)
+	plan.Diff("Tags", db.Tags, b.Tags)
+	plan.Diff("IconID", db.IconID, b.IconID)
+
+	plan.DiffReplace("PlanID", db.PlanID, b.PlanID)
+	plan.DiffReplace("SwitchID", db.SwitchID, b.SwitchID)
+	plan.DiffReplace("IPAddresses", db.IPAddresses, b.IPAddresses)
+	plan.DiffReplace("NetworkMaskLen", db.NetworkMaskLen, b.NetworkMaskLen)
+	plan.DiffReplace("DefaultRoute", db.DefaultRoute, b.DefaultRoute)
+	if b.Conf != nil && db.Conf != nil {
+		plan.DiffReplace("Conf.DatabaseName", db.Conf.DatabaseName, b.Conf.DatabaseName)
+		if b.Conf.DatabaseVersion != "" {
+			plan.DiffReplace("Conf.DatabaseVersion", db.Conf.DatabaseVersion, b.Conf.DatabaseVersion)
+		}
+	}
+
+	current := db.CommonSetting
+	if current == nil {
+		current = &iaas.DatabaseSettingCommon{}
+	}
+	desired := b.CommonSetting
+	if desired == nil {
+		desired = &iaas.DatabaseSettingCommon{}
+	}
+	plan.Diff("CommonSetting.WebUI", current.WebUI, desired.WebUI)
+	if desired.ServicePort != 0 {
+		plan.Diff("CommonSetting.ServicePort", current.ServicePort, desired.ServicePort)
+	}
+	plan.Diff("CommonSetting.SourceNetwork", current.SourceNetwork, desired.SourceNetwork)
+	plan.Diff("CommonSetting.DefaultUser", current.DefaultUser, desired.DefaultUser)
+	if current.UserPassword != desired.UserPassword {
+		plan.Add(&service.FieldChange{
+			Name:        "CommonSetting.UserPassword",
+			Before:      service.SecretPlaceholder,
+			After:       service.SecretPlaceholder,
+			UpdateLevel: service.UpdateLevelSimple,
+		})
+	}
+	plan.Diff("CommonSetting.ReplicaUser", current.ReplicaUser, desired.ReplicaUser)
+	// レプリケーション用パスワードの変更は再起動が必要
+	if current.ReplicaPassword != desired.ReplicaPassword {
+		plan.Add(&service.FieldChange{
+			Name:        "CommonSetting.ReplicaPassword",
+			Before:      service.SecretPlaceholder,
+			After:       service.SecretPlaceholder,
+			UpdateLevel: service.UpdateLevelNeedShutdown,
+		})
+	}
+
+	plan.Diff("BackupSetting", db.BackupSetting, b.BackupSetting)
+	plan.Diff("ReplicationSetting", db.ReplicationSetting, b.ReplicationSetting)
+
+	parameters, err := b.Client.Database.GetParameter(ctx, zone, db.ID)
+	if err != nil {
+		return nil, err
+	}
+	b.planParameters(plan, parameters)
+
+	return plan, nil
+}
+
+func (b *Builder) planParameters(plan *service.Plan, parameters *iaas.DatabaseParameter) {
+	desired := make(map[string]interface{})
+	for k, v := range b.Parameters {
+		name := k
+		for _, meta := range parameters.MetaInfo {
+			if k == meta.Label {
+				name = meta.Name
+				break
+			}
+		}
+		desired[name] = v
+	}
+
+	names := make(map[string]struct{})
+	for k := range parameters.Settings {
+		names[k] = struct{}{}
+	}
+	for k := range desired {
+		names[k] = struct{}{}
+	}
+	for _, name := range sortedKeys(names) {
+		before, after := parameters.Settings[name], desired[name]
+		if before == nil && after == nil {
+			continue
+		}
+		// APIから返される値はfloat64などになるため文字列表現で比較する
+		if before != nil && after != nil && fmt.Sprint(before) == fmt.Sprint(after) {
+			continue
+		}
+		plan.Add(&service.FieldChange{
+			Name:        fmt.Sprintf("Parameters[%s]", name),
+			Before:      before,
+			After:       after,
+			UpdateLevel: service.UpdateLevelSimple,
+		})
+	}
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -16,6 +16,7 @@ package database
 
 import (
 	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/secret"
 	"github.com/sacloud/packages-go/validate"
 )
 
@@ -49,6 +50,19 @@ type CreateRequest struct {
 	NoWait bool
 }
 
+// String 秘匿情報を伏せた文字列表現を返す
+func (req *CreateRequest) String() string {
+	return secret.Redacted(req)
+}
+
+// UnusedSecretFields secret.UnusedSecretsの実装
+func (req *CreateRequest) UnusedSecretFields() []string {
+	if !req.EnableReplication {
+		return []string{"ReplicaUserPassword"}
+	}
+	return nil
+}
+
 func (req *CreateRequest) Validate() error {
 	return validate.New().Struct(req)
 }
@@ -16,11 +16,11 @@ package database
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/helper/wait"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 )
 
@@ -29,8 +29,12 @@ func (s *Service) Delete(req *DeleteRequest) error {
 }
 
 func (s *Service) DeleteWithContext(ctx context.Context, req *DeleteRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "database", "delete", req, s.delete)
+}
+
+func (s *Service) delete(ctx context.Context, req *DeleteRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewDatabaseOp(s.caller)
@@ -40,7 +44,7 @@ func (s *Service) DeleteWithContext(ctx context.Context, req *DeleteRequest) err
 	}
 
 	if !req.Force && target.InstanceStatus.IsUp() {
-		return fmt.Errorf("target %s:%q has not yet shut down", req.Zone, req.ID)
+		return service.Errorf(service.ErrNeedsShutdown, "target %s:%q has not yet shut down", req.Zone, req.ID)
 	}
 
 	if target.InstanceStatus.IsUp() {
@@ -0,0 +1,85 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+func (s *Service) Export(req *ExportRequest) (*ApplyRequest, error) {
+	return s.ExportWithContext(context.Background(), req)
+}
+
+func (s *Service) ExportWithContext(ctx context.Context, req *ExportRequest) (*ApplyRequest, error) {
+	return service.Invoke(ctx, s.interceptors, "database", "export", req, s.export)
+}
+
+func (s *Service) export(ctx context.Context, req *ExportRequest) (*ApplyRequest, error) {
+	if err := req.Validate(); err != nil {
+		return nil, service.ValidationError(err)
+	}
+
+	client := iaas.NewDatabaseOp(s.caller)
+	current, err := client.Read(ctx, req.Zone, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	parameters, err := client.GetParameter(ctx, req.Zone, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := &ApplyRequest{
+		Zone:           req.Zone,
+		ID:             current.ID,
+		Name:           current.Name,
+		Description:    current.Description,
+		Tags:           current.Tags,
+		IconID:         current.IconID,
+		PlanID:         current.PlanID,
+		SwitchID:       current.SwitchID,
+		IPAddresses:    current.IPAddresses,
+		NetworkMaskLen: current.NetworkMaskLen,
+		DefaultRoute:   current.DefaultRoute,
+		Parameters:     parameters.Settings,
+	}
+	if current.Conf != nil {
+		exported.DatabaseType = strings.ToLower(current.Conf.DatabaseName)
+		exported.DatabaseVersion = current.Conf.DatabaseVersion
+	}
+	if cs := current.CommonSetting; cs != nil {
+		exported.Port = cs.ServicePort
+		exported.SourceNetwork = cs.SourceNetwork
+		exported.Username = cs.DefaultUser
+		exported.Password = service.SecretPlaceholder
+		exported.EnableWebUI = cs.WebUI.Bool()
+	}
+	if bs := current.BackupSetting; bs != nil && len(bs.DayOfWeek) > 0 {
+		exported.EnableBackup = true
+		exported.BackupWeekdays = bs.DayOfWeek
+		fmt.Sscanf(bs.Time, "%d:%d", &exported.BackupStartTimeHour, &exported.BackupStartTimeMinute) //nolint:errcheck
+	}
+	if rs := current.ReplicationSetting; rs != nil && rs.Model == types.DatabaseReplicationModels.MasterSlave {
+		exported.EnableReplication = true
+		exported.ReplicaUserPassword = service.SecretPlaceholder
+	}
+	return exported, nil
+}
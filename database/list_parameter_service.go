@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) ListParameter(req *ListParameterRequest) ([]*Parameter, error) {
@@ -25,8 +26,12 @@ func (s *Service) ListParameter(req *ListParameterRequest) ([]*Parameter, error)
 }
 
 func (s *Service) ListParameterWithContext(ctx context.Context, req *ListParameterRequest) ([]*Parameter, error) {
+	return service.Invoke(ctx, s.interceptors, "database", "list_parameter", req, s.listParameter)
+}
+
+func (s *Service) listParameter(ctx context.Context, req *ListParameterRequest) ([]*Parameter, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 	client := iaas.NewDatabaseOp(s.caller)
 	parameters, err := client.GetParameter(ctx, req.Zone, req.ID)
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 )
 
@@ -26,8 +27,12 @@ func (s *Service) MonitorCPU(req *MonitorCPURequest) ([]*iaas.MonitorCPUTimeValu
 }
 
 func (s *Service) MonitorCPUWithContext(ctx context.Context, req *MonitorCPURequest) ([]*iaas.MonitorCPUTimeValue, error) {
+	return service.Invoke(ctx, s.interceptors, "database", "monitor_cpu", req, s.monitorCPU)
+}
+
+func (s *Service) monitorCPU(ctx context.Context, req *MonitorCPURequest) ([]*iaas.MonitorCPUTimeValue, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewDatabaseOp(s.caller)
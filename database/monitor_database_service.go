@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 )
 
@@ -26,8 +27,12 @@ func (s *Service) MonitorDatabase(req *MonitorDatabaseRequest) ([]*iaas.MonitorD
 }
 
 func (s *Service) MonitorDatabaseWithContext(ctx context.Context, req *MonitorDatabaseRequest) ([]*iaas.MonitorDatabaseValue, error) {
+	return service.Invoke(ctx, s.interceptors, "database", "monitor_database", req, s.monitorDatabase)
+}
+
+func (s *Service) monitorDatabase(ctx context.Context, req *MonitorDatabaseRequest) ([]*iaas.MonitorDatabaseValue, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewDatabaseOp(s.caller)
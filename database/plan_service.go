@@ -0,0 +1,40 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	service "github.com/sacloud/iaas-service-go"
+)
+
+func (s *Service) Plan(req *ApplyRequest) (*service.Plan, error) {
+	return s.PlanWithContext(context.Background(), req)
+}
+
+func (s *Service) PlanWithContext(ctx context.Context, req *ApplyRequest) (*service.Plan, error) {
+	return service.Invoke(ctx, s.interceptors, "database", "plan", req, s.plan)
+}
+
+func (s *Service) plan(ctx context.Context, req *ApplyRequest) (*service.Plan, error) {
+	if err := req.Validate(); err != nil {
+		return nil, service.ValidationError(err)
+	}
+	builder, err := req.Builder(s.caller)
+	if err != nil {
+		return nil, err
+	}
+	return builder.Plan(ctx)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/secret"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 	"github.com/sacloud/packages-go/validate"
 )
@@ -49,6 +50,11 @@ type UpdateRequest struct {
 	NoWait       bool
 }
 
+// String 秘匿情報を伏せた文字列表現を返す
+func (req *UpdateRequest) String() string {
+	return secret.Redacted(req)
+}
+
 func (req *UpdateRequest) Validate() error {
 	return validate.New().Struct(req)
 }
@@ -16,9 +16,9 @@ package database
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Update(req *UpdateRequest) (*iaas.Database, error) {
@@ -26,13 +26,17 @@ func (s *Service) Update(req *UpdateRequest) (*iaas.Database, error) {
 }
 
 func (s *Service) UpdateWithContext(ctx context.Context, req *UpdateRequest) (*iaas.Database, error) {
+	return service.Invoke(ctx, s.interceptors, "database", "update", req, s.update)
+}
+
+func (s *Service) update(ctx context.Context, req *UpdateRequest) (*iaas.Database, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	applyRequest, err := req.ApplyRequest(ctx, s.caller)
 	if err != nil {
-		return nil, fmt.Errorf("processing request parameter failed: %s", err)
+		return nil, service.StepError("process request parameter", err)
 	}
 
 	return s.ApplyWithContext(ctx, applyRequest)
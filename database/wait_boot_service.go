@@ -19,6 +19,8 @@ import (
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/helper/wait"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/progress"
 )
 
 func (s *Service) WaitBoot(req *WaitBootRequest) error {
@@ -26,11 +28,17 @@ func (s *Service) WaitBoot(req *WaitBootRequest) error {
 }
 
 func (s *Service) WaitBootWithContext(ctx context.Context, req *WaitBootRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "database", "wait_boot", req, s.waitBoot)
+}
+
+func (s *Service) waitBoot(ctx context.Context, req *WaitBootRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewDatabaseOp(s.caller)
-	_, err := wait.UntilDatabaseIsUp(ctx, client, req.Zone, req.ID)
-	return err
+	return progress.Wait(ctx, "database", req.ID, "boot", func() error {
+		_, err := wait.UntilDatabaseIsUp(ctx, client, req.Zone, req.ID)
+		return err
+	})
 }
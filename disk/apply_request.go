@@ -15,10 +15,16 @@
 package disk
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/helper/query"
 	"github.com/sacloud/iaas-api-go/ostype"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
 	disk "github.com/sacloud/iaas-service-go/disk/builder"
+	"github.com/sacloud/iaas-service-go/secret"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 	"github.com/sacloud/packages-go/validate"
 )
@@ -46,6 +52,11 @@ type ApplyRequest struct {
 	NoWait bool
 }
 
+// String 秘匿情報を伏せた文字列表現を返す
+func (req *ApplyRequest) String() string {
+	return secret.Redacted(req)
+}
+
 // EditParameter ディスクの修正用パラメータ
 type EditParameter struct {
 	HostName string
@@ -70,10 +81,80 @@ type EditParameter struct {
 	Notes            []*iaas.DiskEditNote
 }
 
+// String 秘匿情報を伏せた文字列表現を返す
+func (p *EditParameter) String() string {
+	return secret.Redacted(p)
+}
+
 func (req *ApplyRequest) Validate() error {
 	return validate.New().Struct(req)
 }
 
+// Preflight 参照先のリソースやディスクプラン、OS種別に対応するアーカイブを確認し、検出した全ての問題を返す
+//
+// リソースの作成や更新は行わない
+func (req *ApplyRequest) Preflight(ctx context.Context, caller iaas.APICaller) error {
+	p := service.NewPreflight()
+	diskOp := iaas.NewDiskOp(caller)
+
+	p.Ref("ID", "disk", req.Zone, req.ID, func() error {
+		_, err := diskOp.Read(ctx, req.Zone, req.ID)
+		return err
+	})
+	p.Ref("IconID", "icon", "", req.IconID, func() error {
+		_, err := iaas.NewIconOp(caller).Read(ctx, req.IconID)
+		return err
+	})
+	p.Ref("DiskPlanID", "disk plan", req.Zone, req.DiskPlanID, func() error {
+		plan, err := iaas.NewDiskPlanOp(caller).Read(ctx, req.Zone, req.DiskPlanID)
+		if err == nil && !plan.Availability.IsAvailable() {
+			p.Add("DiskPlanID", "disk plan[%s] is not available in zone %s", req.DiskPlanID, req.Zone)
+		}
+		return err
+	})
+	p.Ref("SourceDiskID", "disk", req.Zone, req.SourceDiskID, func() error {
+		_, err := diskOp.Read(ctx, req.Zone, req.SourceDiskID)
+		return err
+	})
+	p.Ref("SourceArchiveID", "archive", req.Zone, req.SourceArchiveID, func() error {
+		_, err := iaas.NewArchiveOp(caller).Read(ctx, req.Zone, req.SourceArchiveID)
+		return err
+	})
+	if req.OSType != ostype.Custom {
+		p.Check("OSType", func() error {
+			_, err := query.FindArchiveByOSType(ctx, iaas.NewArchiveOp(caller), req.Zone, req.OSType)
+			return err
+		})
+	}
+	p.Ref("ServerID", "server", req.Zone, req.ServerID, func() error {
+		_, err := iaas.NewServerOp(caller).Read(ctx, req.Zone, req.ServerID)
+		return err
+	})
+	for i, id := range req.DistantFrom {
+		p.Ref(fmt.Sprintf("DistantFrom[%d]", i), "disk", req.Zone, id, func() error {
+			_, err := diskOp.Read(ctx, req.Zone, id)
+			return err
+		})
+	}
+
+	if req.EditParameter != nil {
+		for i, id := range req.EditParameter.SSHKeyIDs {
+			p.Ref(fmt.Sprintf("EditParameter.SSHKeyIDs[%d]", i), "ssh key", "", id, func() error {
+				_, err := iaas.NewSSHKeyOp(caller).Read(ctx, id)
+				return err
+			})
+		}
+		for i, note := range req.EditParameter.Notes {
+			p.Ref(fmt.Sprintf("EditParameter.Notes[%d].ID", i), "note", "", note.ID, func() error {
+				_, err := iaas.NewNoteOp(caller).Read(ctx, note.ID)
+				return err
+			})
+		}
+	}
+
+	return p.Err()
+}
+
 func (req *ApplyRequest) Builder(caller iaas.APICaller) (disk.Builder, error) {
 	var editParameter *disk.EditRequest
 
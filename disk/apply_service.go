@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Apply(req *ApplyRequest) (*iaas.Disk, error) {
@@ -25,8 +26,12 @@ func (s *Service) Apply(req *ApplyRequest) (*iaas.Disk, error) {
 }
 
 func (s *Service) ApplyWithContext(ctx context.Context, req *ApplyRequest) (*iaas.Disk, error) {
+	return service.Invoke(ctx, s.interceptors, "disk", "apply", req, s.apply)
+}
+
+func (s *Service) apply(ctx context.Context, req *ApplyRequest) (*iaas.Disk, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	builder, err := req.Builder(s.caller)
@@ -49,6 +54,13 @@ func (s *Service) ApplyWithContext(ctx context.Context, req *ApplyRequest) (*iaa
 	}
 
 	// update
+	current, err := iaas.NewDiskOp(s.caller).Read(ctx, req.Zone, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !req.plan(current).HasChanges() {
+		return current, nil
+	}
 	res, err := builder.Update(ctx, req.Zone)
 	if err != nil {
 		return nil, err
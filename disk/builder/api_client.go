@@ -53,6 +53,14 @@ type CreateDiskHandler interface {
 	ConnectToServer(ctx context.Context, zone string, id types.ID, serverID types.ID) error
 }
 
+// DeleteDiskHandler ロールバック時にディスクを削除するためのインターフェース
+//
+// APIClient.Diskが実装している場合のみ、ロールバック時に作成したディスクを削除できる
+type DeleteDiskHandler interface {
+	DisconnectFromServer(ctx context.Context, zone string, id types.ID) error
+	Delete(ctx context.Context, zone string, id types.ID) error
+}
+
 // PlanReader ディスクプラン取得のためのインターフェース
 type PlanReader interface {
 	Read(ctx context.Context, zone string, id types.ID) (*iaas.DiskPlan, error)
@@ -55,3 +55,17 @@ func (d *dummyNoteHandler) Create(ctx context.Context, param *iaas.NoteCreateReq
 func (d *dummyNoteHandler) Delete(ctx context.Context, id types.ID) error {
 	return d.err
 }
+
+// dummyDiskReader Readのみを実装したCreateDiskHandler
+type dummyDiskReader struct {
+	CreateDiskHandler
+	disk *iaas.Disk
+	err  error
+}
+
+func (d *dummyDiskReader) Read(ctx context.Context, zone string, id types.ID) (*iaas.Disk, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	return d.disk, nil
+}
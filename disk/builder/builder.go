@@ -18,13 +18,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"reflect"
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/helper/query"
 	"github.com/sacloud/iaas-api-go/ostype"
 	"github.com/sacloud/iaas-api-go/types"
 	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/progress"
+	"github.com/sacloud/iaas-service-go/rollback"
 	"github.com/sacloud/packages-go/size"
 )
 
@@ -130,7 +131,7 @@ func (d *FromUnixBuilder) DiskID() types.ID {
 
 // UpdateLevel Update時にどのレベルの変更が必要か
 func (d *FromUnixBuilder) UpdateLevel(ctx context.Context, zone string, disk *iaas.Disk) service.UpdateLevel {
-	return updateLevel(disk, d.EditParameter != nil, d)
+	return updateLevel(ctx, d.Client, zone, disk, d.EditParameter != nil, d)
 }
 
 func (d *FromUnixBuilder) updateDiskParameter() *iaas.DiskUpdateRequest {
@@ -243,7 +244,7 @@ func (d *FromFixedArchiveBuilder) DiskID() types.ID {
 
 // UpdateLevel Update時にどのレベルの変更が必要か
 func (d *FromFixedArchiveBuilder) UpdateLevel(ctx context.Context, zone string, disk *iaas.Disk) service.UpdateLevel {
-	return updateLevel(disk, false, d)
+	return updateLevel(ctx, d.Client, zone, disk, false, d)
 }
 
 func (d *FromFixedArchiveBuilder) updateDiskParameter() *iaas.DiskUpdateRequest {
@@ -372,7 +373,7 @@ func (d *FromDiskOrArchiveBuilder) DiskID() types.ID {
 
 // UpdateLevel Update時にどのレベルの変更が必要か
 func (d *FromDiskOrArchiveBuilder) UpdateLevel(ctx context.Context, zone string, disk *iaas.Disk) service.UpdateLevel {
-	return updateLevel(disk, d.EditParameter != nil, d)
+	return updateLevel(ctx, d.Client, zone, disk, d.EditParameter != nil, d)
 }
 
 func (d *FromDiskOrArchiveBuilder) updateDiskParameter() *iaas.DiskUpdateRequest {
@@ -469,7 +470,7 @@ func (d *BlankBuilder) DiskID() types.ID {
 
 // UpdateLevel Update時にどのレベルの変更が必要か
 func (d *BlankBuilder) UpdateLevel(ctx context.Context, zone string, disk *iaas.Disk) service.UpdateLevel {
-	return updateLevel(disk, false, d)
+	return updateLevel(ctx, d.Client, zone, disk, false, d)
 }
 
 func (d *BlankBuilder) updateDiskParameter() *iaas.DiskUpdateRequest {
@@ -593,7 +594,7 @@ func (d *ConnectedDiskBuilder) DiskID() types.ID {
 
 // UpdateLevel Update時にどのレベルの変更が必要か
 func (d *ConnectedDiskBuilder) UpdateLevel(ctx context.Context, zone string, disk *iaas.Disk) service.UpdateLevel {
-	return updateLevel(disk, d.EditParameter != nil, d)
+	return updateLevel(ctx, d.Client, zone, disk, d.EditParameter != nil, d)
 }
 
 func (d *ConnectedDiskBuilder) updateDiskParameter() *iaas.DiskUpdateRequest {
@@ -651,13 +652,23 @@ func build(ctx context.Context, client *APIClient, zone string, serverID types.I
 	} else {
 		disk, err = client.Disk.CreateWithConfig(ctx, zone, diskReq, editReq, false, distantFrom)
 	}
+	if disk != nil {
+		diskID := disk.ID
+		rollback.Track(ctx, "disk", diskID, func(ctx context.Context) error {
+			return deleteDisk(ctx, client, zone, diskID)
+		})
+	}
 	if err != nil {
 		if disk != nil {
 			return &BuildResult{DiskID: disk.ID}, err
 		}
 		return nil, err
 	}
+	if err := notifyCreated(ctx, disk.ID); err != nil {
+		return &BuildResult{DiskID: disk.ID}, err
+	}
 
+	progress.Step(ctx, "disk", disk.ID, "created")
 	if builder.NoWaitFlag() {
 		return &BuildResult{DiskID: disk.ID}, nil
 	}
@@ -665,7 +676,7 @@ func build(ctx context.Context, client *APIClient, zone string, serverID types.I
 	waiter := iaas.WaiterForReady(func() (interface{}, error) {
 		return client.Disk.Read(ctx, zone, disk.ID)
 	})
-	lastState, err := waiter.WaitForState(ctx)
+	lastState, err := progress.WaitForState(ctx, waiter, "disk", disk.ID, "copy")
 	if err != nil {
 		if lastState != nil {
 			return &BuildResult{DiskID: lastState.(*iaas.Disk).ID}, err
@@ -677,6 +688,45 @@ func build(ctx context.Context, client *APIClient, zone string, serverID types.I
 	return &BuildResult{DiskID: disk.ID}, nil
 }
 
+// CreatedFunc ディスクの作成直後、コピー完了を待つ前に呼び出される関数
+type CreatedFunc func(ctx context.Context, id types.ID) error
+
+type createdFuncKey struct{}
+
+// WithCreatedFunc ディスクの作成直後に呼び出す関数を設定したcontextを返す
+//
+// コピー完了待ちの途中で中断した場合も、作成したディスクのIDを記録できるようにするために利用する
+func WithCreatedFunc(ctx context.Context, fn CreatedFunc) context.Context {
+	return context.WithValue(ctx, createdFuncKey{}, fn)
+}
+
+func notifyCreated(ctx context.Context, id types.ID) error {
+	if fn, ok := ctx.Value(createdFuncKey{}).(CreatedFunc); ok && fn != nil {
+		return fn(ctx, id)
+	}
+	return nil
+}
+
+// deleteDisk ロールバック用、サーバに接続されている場合は切断してから削除する
+//
+// APIClient.DiskがDeleteDiskHandlerを実装していない場合は削除できないためエラーを返す
+func deleteDisk(ctx context.Context, client *APIClient, zone string, id types.ID) error {
+	deleter, ok := client.Disk.(DeleteDiskHandler)
+	if !ok {
+		return fmt.Errorf("disk client %T does not implement DeleteDiskHandler", client.Disk)
+	}
+	disk, err := client.Disk.Read(ctx, zone, id)
+	if err != nil {
+		return err
+	}
+	if !disk.ServerID.IsEmpty() {
+		if err := deleter.DisconnectFromServer(ctx, zone, id); err != nil {
+			return err
+		}
+	}
+	return deleter.Delete(ctx, zone, id)
+}
+
 func update(ctx context.Context, client *APIClient, zone string, builder diskBuilder) (*UpdateResult, error) {
 	var err error
 
@@ -717,7 +767,7 @@ func update(ctx context.Context, client *APIClient, zone string, builder diskBui
 	waiter := iaas.WaiterForReady(func() (interface{}, error) {
 		return client.Disk.Read(ctx, zone, disk.ID)
 	})
-	lastState, err := waiter.WaitForState(ctx)
+	lastState, err := progress.WaitForState(ctx, waiter, "disk", disk.ID, "edit")
 	if err != nil {
 		return nil, err
 	}
@@ -744,27 +794,37 @@ func validateDiskPlan(ctx context.Context, client *APIClient, zone string, diskP
 	return nil
 }
 
-func updateLevel(disk *iaas.Disk, hasEditReq bool, b diskBuilder) service.UpdateLevel {
+func updateLevel(ctx context.Context, client *APIClient, zone string, disk *iaas.Disk, hasEditReq bool, b diskBuilder) service.UpdateLevel {
 	if disk.ID != b.DiskID() || hasEditReq {
 		return service.UpdateLevelNeedShutdown
 	}
-
-	current := &iaas.DiskUpdateRequest{
-		Name:        disk.Name,
-		Description: disk.Description,
-		Tags:        disk.Tags,
-		IconID:      disk.IconID,
-		Connection:  disk.Connection,
-	}
 	desired := b.updateDiskParameter()
 	if desired == nil {
 		return service.UpdateLevelNone
 	}
-	if reflect.DeepEqual(current, desired) {
-		if current.Connection != desired.Connection {
-			return service.UpdateLevelNeedShutdown
+
+	// サーバに接続されたディスクの情報には説明やタグなどが含まれないため最新の状態を参照する
+	// 参照できなかった場合は差分を判定できないため更新が必要とみなす
+	readFailed := false
+	if client != nil && client.Disk != nil {
+		current, err := client.Disk.Read(ctx, zone, disk.ID)
+		if err != nil {
+			readFailed = true
+		} else {
+			disk = current
 		}
+	}
+
+	plan := service.NewPlan()
+	plan.Diff("Name", disk.Name, desired.Name)
+	plan.Diff("Description", disk.Description, desired.Description)
+	plan.Diff("Tags", disk.Tags, desired.Tags)
+	plan.Diff("IconID", disk.IconID, desired.IconID)
+	if desired.Connection != types.EDiskConnection("") {
+		plan.DiffNeedShutdown("Connection", disk.Connection, desired.Connection)
+	}
+	if readFailed && plan.UpdateLevel < service.UpdateLevelSimple {
 		return service.UpdateLevelSimple
 	}
-	return service.UpdateLevelNone
+	return plan.UpdateLevel
 }
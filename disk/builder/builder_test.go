@@ -23,6 +23,7 @@ import (
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/ostype"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/packages-go/size"
 	"github.com/stretchr/testify/require"
 )
@@ -101,3 +102,23 @@ func TestDiskFromUnixRequest_Validate(t *testing.T) {
 		require.Equal(t, tc.err, err)
 	}
 }
+
+func TestBlankBuilder_UpdateLevel(t *testing.T) {
+	ctx := context.Background()
+	current := &iaas.Disk{ID: 1, Name: "disk", Description: "desc", Tags: types.Tags{"tag"}}
+	// サーバに接続されたディスクの情報には説明やタグが含まれない
+	embedded := &iaas.Disk{ID: 1, Name: "disk"}
+
+	builder := &BlankBuilder{
+		ID:          1,
+		Name:        "disk",
+		Description: "desc",
+		Tags:        types.Tags{"tag"},
+		Client:      &APIClient{Disk: &dummyDiskReader{disk: current}},
+	}
+	require.Equal(t, service.UpdateLevelNone, builder.UpdateLevel(ctx, "tk1v", embedded))
+
+	// 最新の状態を参照できない場合は変更なしとはみなさない
+	builder.Client = &APIClient{Disk: &dummyDiskReader{err: errors.New("read failed")}}
+	require.Equal(t, service.UpdateLevelSimple, builder.UpdateLevel(ctx, "tk1v", embedded))
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/rollback"
 )
 
 // EditRequest 汎用ディスクの修正リクエストパラメータ DiskDirectorが利用する
@@ -122,6 +123,9 @@ func (u *UnixEditRequest) prepareDiskEditParameter(ctx context.Context, client *
 			return nil, nil, nil, err
 		}
 		generatedSSHKey = generated
+		rollback.Track(ctx, "sshkey", generated.ID, func(ctx context.Context) error {
+			return client.SSHKey.Delete(ctx, generated.ID)
+		})
 		sshKeys = append(sshKeys, &iaas.DiskEditSSHKey{
 			ID: generated.ID,
 		})
@@ -141,6 +145,9 @@ func (u *UnixEditRequest) prepareDiskEditParameter(ctx context.Context, client *
 		if err != nil {
 			return nil, nil, nil, err
 		}
+		rollback.Track(ctx, "note", created.ID, func(ctx context.Context) error {
+			return client.Note.Delete(ctx, created.ID)
+		})
 		notes = append(notes, &iaas.DiskEditNote{
 			ID: created.ID,
 		})
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) ConnectToServer(req *ConnectToServerRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) ConnectToServer(req *ConnectToServerRequest) error {
 }
 
 func (s *Service) ConnectToServerWithContext(ctx context.Context, req *ConnectToServerRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "disk", "connect_to_server", req, s.connectToServer)
+}
+
+func (s *Service) connectToServer(ctx context.Context, req *ConnectToServerRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewDiskOp(s.caller)
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) DisconnectFromServer(req *DisconnectFromServerRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) DisconnectFromServer(req *DisconnectFromServerRequest) error {
 }
 
 func (s *Service) DisconnectFromServerWithContext(ctx context.Context, req *DisconnectFromServerRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "disk", "disconnect_from_server", req, s.disconnectFromServer)
+}
+
+func (s *Service) disconnectFromServer(ctx context.Context, req *DisconnectFromServerRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewDiskOp(s.caller)
@@ -17,6 +17,7 @@ package disk
 import (
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/secret"
 	"github.com/sacloud/packages-go/validate"
 )
 
@@ -43,6 +44,11 @@ type EditRequest struct {
 	Notes []*iaas.DiskEditNote // スタートアップスクリプトをIDで指定(変数や埋め込むAPIキーを指定可能)
 }
 
+// String 秘匿情報を伏せた文字列表現を返す
+func (req *EditRequest) String() string {
+	return secret.Redacted(req)
+}
+
 func (req *EditRequest) Validate() error {
 	return validate.New().Struct(req)
 }
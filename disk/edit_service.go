@@ -19,6 +19,7 @@ import (
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/helper/wait"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Edit(req *EditRequest) error {
@@ -26,8 +27,12 @@ func (s *Service) Edit(req *EditRequest) error {
 }
 
 func (s *Service) EditWithContext(ctx context.Context, req *EditRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "disk", "edit", req, s.edit)
+}
+
+func (s *Service) edit(ctx context.Context, req *EditRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	params, err := req.ToRequestParameter()
@@ -0,0 +1,56 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disk
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+func (s *Service) Export(req *ExportRequest) (*ApplyRequest, error) {
+	return s.ExportWithContext(context.Background(), req)
+}
+
+func (s *Service) ExportWithContext(ctx context.Context, req *ExportRequest) (*ApplyRequest, error) {
+	return service.Invoke(ctx, s.interceptors, "disk", "export", req, s.export)
+}
+
+func (s *Service) export(ctx context.Context, req *ExportRequest) (*ApplyRequest, error) {
+	if err := req.Validate(); err != nil {
+		return nil, service.ValidationError(err)
+	}
+
+	current, err := iaas.NewDiskOp(s.caller).Read(ctx, req.Zone, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &ApplyRequest{
+		Zone:                req.Zone,
+		ID:                  current.ID,
+		Name:                current.Name,
+		Description:         current.Description,
+		Tags:                current.Tags,
+		IconID:              current.IconID,
+		DiskPlanID:          current.DiskPlanID,
+		Connection:          current.Connection,
+		EncryptionAlgorithm: current.EncryptionAlgorithm,
+		SourceDiskID:        current.SourceDiskID,
+		SourceArchiveID:     current.SourceArchiveID,
+		ServerID:            current.ServerID,
+		SizeGB:              current.GetSizeGB(),
+	}, nil
+}
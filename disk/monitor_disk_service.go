@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 )
 
@@ -26,8 +27,12 @@ func (s *Service) MonitorDisk(req *MonitorDiskRequest) ([]*iaas.MonitorDiskValue
 }
 
 func (s *Service) MonitorDiskWithContext(ctx context.Context, req *MonitorDiskRequest) ([]*iaas.MonitorDiskValue, error) {
+	return service.Invoke(ctx, s.interceptors, "disk", "monitor_disk", req, s.monitorDisk)
+}
+
+func (s *Service) monitorDisk(ctx context.Context, req *MonitorDiskRequest) ([]*iaas.MonitorDiskValue, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewDiskOp(s.caller)
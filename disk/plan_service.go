@@ -0,0 +1,86 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disk
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+func (s *Service) Plan(req *ApplyRequest) (*service.Plan, error) {
+	return s.PlanWithContext(context.Background(), req)
+}
+
+func (s *Service) PlanWithContext(ctx context.Context, req *ApplyRequest) (*service.Plan, error) {
+	return service.Invoke(ctx, s.interceptors, "disk", "plan", req, s.plan)
+}
+
+func (s *Service) plan(ctx context.Context, req *ApplyRequest) (*service.Plan, error) {
+	if err := req.Validate(); err != nil {
+		return nil, service.ValidationError(err)
+	}
+
+	if req.ID.IsEmpty() {
+		return service.NewCreatePlan(map[string]interface{}{
+			"Name":                req.Name,
+			"Description":         req.Description,
+			"Tags":                req.Tags,
+			"IconID":              req.IconID,
+			"DiskPlanID":          req.DiskPlanID,
+			"Connection":          req.Connection,
+			"EncryptionAlgorithm": req.EncryptionAlgorithm,
+			"SourceDiskID":        req.SourceDiskID,
+			"SourceArchiveID":     req.SourceArchiveID,
+			"ServerID":            req.ServerID,
+			"SizeGB":              req.SizeGB,
+			"OSType":              req.OSType,
+		}), nil
+	}
+
+	current, err := iaas.NewDiskOp(s.caller).Read(ctx, req.Zone, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return req.plan(current), nil
+}
+
+func (req *ApplyRequest) plan(current *iaas.Disk) *service.Plan {
+	plan := service.NewPlan()
+
+	plan.Diff("Name", current.Name, req.Name)
+	plan.Diff("Description", current.Description, req.Description)
+	plan.Diff("Tags", current.Tags, req.Tags)
+	plan.Diff("IconID", current.IconID, req.IconID)
+	if req.Connection != types.EDiskConnection("") {
+		plan.DiffNeedShutdown("Connection", current.Connection, req.Connection)
+	}
+	if !req.DiskPlanID.IsEmpty() {
+		plan.DiffReplace("DiskPlanID", current.DiskPlanID, req.DiskPlanID)
+	}
+	if req.SizeGB > 0 {
+		plan.DiffReplace("SizeGB", current.GetSizeGB(), req.SizeGB)
+	}
+	if req.EditParameter != nil {
+		plan.Add(&service.FieldChange{
+			Name:        "EditParameter",
+			After:       "(edit disk)",
+			UpdateLevel: service.UpdateLevelNeedShutdown,
+		})
+	}
+	return plan
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/helper/wait"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) ResizePartition(req *ResizePartitionRequest) error {
@@ -26,8 +27,12 @@ func (s *Service) ResizePartition(req *ResizePartitionRequest) error {
 }
 
 func (s *Service) ResizePartitionWithContext(ctx context.Context, req *ResizePartitionRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "disk", "resize_partition", req, s.resizePartition)
+}
+
+func (s *Service) resizePartition(ctx context.Context, req *ResizePartitionRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewDiskOp(s.caller)
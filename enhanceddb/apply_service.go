@@ -17,6 +17,7 @@ package enhanceddb
 import (
 	"context"
 
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/enhanceddb/builder"
 )
 
@@ -25,8 +26,12 @@ func (s *Service) Apply(req *ApplyRequest) (*builder.EnhancedDB, error) {
 }
 
 func (s *Service) ApplyWithContext(ctx context.Context, req *ApplyRequest) (*builder.EnhancedDB, error) {
+	return service.Invoke(ctx, s.interceptors, "enhanceddb", "apply", req, s.apply)
+}
+
+func (s *Service) apply(ctx context.Context, req *ApplyRequest) (*builder.EnhancedDB, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	builder, err := req.Builder(s.caller)
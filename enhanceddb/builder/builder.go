@@ -83,13 +83,16 @@ func (b *Builder) create(ctx context.Context) (*EnhancedDB, error) {
 }
 
 func (b *Builder) update(ctx context.Context) (*EnhancedDB, error) {
-	current, err := b.Client.Read(ctx, b.ID)
+	current, err := Read(ctx, b.Client, b.ID)
 	if err != nil {
 		return nil, err
 	}
 	if current.DatabaseName != b.DatabaseName {
 		return nil, errors.New("DatabaseName cannot be changed")
 	}
+	if !b.plan(current).HasChanges() {
+		return current, nil
+	}
 
 	updated, err := b.Client.Update(ctx, b.ID, &iaas.EnhancedDBUpdateRequest{
 		Name:         b.Name,
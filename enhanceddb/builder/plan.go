@@ -0,0 +1,86 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package builder
+
+import (
+	"context"
+
+	service "github.com/sacloud/iaas-service-go"
+)
+
+// Plan Build時の変更内容を算出する
+//
+// IDが空の場合は新規作成を表すPlanを返す。APIによるリソースの変更は行わない。
+func (b *Builder) Plan(ctx context.Context) (*service.Plan, error) {
+	if b.ID.IsEmpty() {
+		return service.NewCreatePlan(map[string]interface{}{
+			"Name":            b.Name,
+			"Description":     b.Description,
+			"Tags":            b.Tags,
+			"IconID":          b.IconID,
+			"DatabaseName":    b.DatabaseName,
+			"DatabaseType":    b.DatabaseType,
+			"Region":          b.Region,
+			"Password":        sensitive(b.Password),
+			"AllowedNetworks": b.AllowedNetworks,
+		}), nil
+	}
+
+	current, err := Read(ctx, b.Client, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	return b.plan(current), nil
+}
+
+func (b *Builder) plan(current *EnhancedDB) *service.Plan {
+	plan := service.NewPlan()
+
+	plan.Diff("Name", current.Name, b.Name)
+	plan.Diff("Description", current.Description, b.Description)
+	plan.Diff("Tags", current.Tags, b.Tags)
+	plan.Diff("IconID", current.IconID, b.IconID)
+	plan.DiffReplace("DatabaseName", current.DatabaseName, b.DatabaseName)
+	if b.DatabaseType != "" {
+		plan.DiffReplace("DatabaseType", current.DatabaseType, b.DatabaseType)
+	}
+	if b.Region != "" {
+		plan.DiffReplace("Region", current.Region, b.Region)
+	}
+
+	// パスワードは現在値を参照できないため、指定されていれば常に変更ありとみなす
+	if b.Password != "" {
+		plan.Add(&service.FieldChange{
+			Name:        "Password",
+			After:       sensitive(b.Password),
+			UpdateLevel: service.UpdateLevelSimple,
+		})
+	}
+
+	if b.AllowedNetworks != nil {
+		var allowedNetworks []string
+		if current.Config != nil {
+			allowedNetworks = current.Config.AllowedNetworks
+		}
+		plan.Diff("AllowedNetworks", allowedNetworks, b.AllowedNetworks)
+	}
+	return plan
+}
+
+func sensitive(v string) string {
+	if v == "" {
+		return ""
+	}
+	return service.SecretPlaceholder
+}
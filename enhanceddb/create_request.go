@@ -16,6 +16,7 @@ package enhanceddb
 
 import (
 	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/secret"
 	"github.com/sacloud/packages-go/validate"
 )
 
@@ -32,6 +33,11 @@ type CreateRequest struct {
 	AllowedNetworks []string
 }
 
+// String 秘匿情報を伏せた文字列表現を返す
+func (req *CreateRequest) String() string {
+	return secret.Redacted(req)
+}
+
 func (req *CreateRequest) Validate() error {
 	return validate.New().Struct(req)
 }
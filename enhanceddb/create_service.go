@@ -17,6 +17,7 @@ package enhanceddb
 import (
 	"context"
 
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/enhanceddb/builder"
 )
 
@@ -25,8 +26,12 @@ func (s *Service) Create(req *CreateRequest) (*builder.EnhancedDB, error) {
 }
 
 func (s *Service) CreateWithContext(ctx context.Context, req *CreateRequest) (*builder.EnhancedDB, error) {
+	return service.Invoke(ctx, s.interceptors, "enhanceddb", "create", req, s.create)
+}
+
+func (s *Service) create(ctx context.Context, req *CreateRequest) (*builder.EnhancedDB, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	return s.ApplyWithContext(ctx, req.ApplyRequest())
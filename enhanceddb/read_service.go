@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/enhanceddb/builder"
 )
 
@@ -26,8 +27,12 @@ func (s *Service) Read(req *ReadRequest) (*builder.EnhancedDB, error) {
 }
 
 func (s *Service) ReadWithContext(ctx context.Context, req *ReadRequest) (*builder.EnhancedDB, error) {
+	return service.Invoke(ctx, s.interceptors, "enhanceddb", "read", req, s.read)
+}
+
+func (s *Service) read(ctx context.Context, req *ReadRequest) (*builder.EnhancedDB, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 	client := iaas.NewEnhancedDBOp(s.caller)
 	return builder.Read(ctx, client, req.ID)
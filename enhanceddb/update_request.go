@@ -19,6 +19,7 @@ import (
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/secret"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 	"github.com/sacloud/packages-go/validate"
 )
@@ -37,6 +38,11 @@ type UpdateRequest struct {
 	SettingsHash string
 }
 
+// String 秘匿情報を伏せた文字列表現を返す
+func (req *UpdateRequest) String() string {
+	return secret.Redacted(req)
+}
+
 func (req *UpdateRequest) Validate() error {
 	return validate.New().Struct(req)
 }
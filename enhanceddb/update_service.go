@@ -16,8 +16,8 @@ package enhanceddb
 
 import (
 	"context"
-	"fmt"
 
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/enhanceddb/builder"
 )
 
@@ -26,13 +26,17 @@ func (s *Service) Update(req *UpdateRequest) (*builder.EnhancedDB, error) {
 }
 
 func (s *Service) UpdateWithContext(ctx context.Context, req *UpdateRequest) (*builder.EnhancedDB, error) {
+	return service.Invoke(ctx, s.interceptors, "enhanceddb", "update", req, s.update)
+}
+
+func (s *Service) update(ctx context.Context, req *UpdateRequest) (*builder.EnhancedDB, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	applyRequest, err := req.ApplyRequest(ctx, s.caller)
 	if err != nil {
-		return nil, fmt.Errorf("processing request parameter failed: %s", err)
+		return nil, service.StepError("process request parameter", err)
 	}
 
 	return s.ApplyWithContext(ctx, applyRequest)
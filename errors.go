@@ -0,0 +1,201 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iaas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	sacloud "github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// エラーの分類、errors.Isで判定する
+var (
+	// ErrNotFound 対象リソースが存在しない
+	ErrNotFound = errors.New("not found")
+	// ErrValidationFailed リクエストのバリデーションエラー
+	ErrValidationFailed = errors.New("validation failed")
+	// ErrNeedsShutdown 対象リソースのシャットダウンが必要
+	ErrNeedsShutdown = errors.New("needs shutdown")
+	// ErrConflict 競合エラー、SettingsHashの不一致などを含む
+	ErrConflict = errors.New("conflict")
+	// ErrTimeout 待ち処理などのタイムアウト
+	ErrTimeout = errors.New("timeout")
+	// ErrMaxRetryCountExceeded リトライ最大数超過
+	ErrMaxRetryCountExceeded = errors.New("max retry count exceeded")
+)
+
+// Error 各サービスが返すエラー
+//
+// 対象リソースや操作の情報を保持し、元のエラー(iaas-api-goのAPIErrorなど)をラップする。
+// 分類はerrors.Is(err, ErrNotFound)のように判定する。
+type Error struct {
+	// Kind リソース種別 例: server
+	Kind string
+	Zone string
+	ID   types.ID
+	// Operation 操作名 例: update
+	Operation string
+	// Step 操作内で失敗した処理 例: read
+	Step string
+	// Category エラーの分類、ErrNotFoundなど
+	//
+	// 未指定の場合でも元のエラーがAPIの404/409エラーやタイムアウトであればerrors.Isで判定できる
+	Category error
+	Err      error
+}
+
+// NewError 分類を指定してエラーを生成する
+func NewError(category, err error) *Error {
+	return &Error{Category: category, Err: err}
+}
+
+// Errorf 分類を指定してfmt.Errorfでエラーを生成する
+func Errorf(category error, format string, a ...interface{}) *Error {
+	return NewError(category, fmt.Errorf(format, a...))
+}
+
+// ValidationError バリデーションエラーを生成する、errがnilの場合はnilを返す
+func ValidationError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return NewError(ErrValidationFailed, err)
+}
+
+// StepError 操作内で失敗した処理を付与したエラーを生成する、errがnilの場合はnilを返す
+func StepError(step string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Step: step, Err: err}
+}
+
+// WrapError *errにリソース種別や操作などの情報を付与する
+//
+// 各サービスのメソッドでdeferと共に利用する。reqがZone/IDフィールドを持つ場合はその値も付与する。
+// *errが既に*Errorである場合は未設定の項目のみを補完する。
+func WrapError(err *error, kind, operation string, req interface{}) {
+	if err == nil || *err == nil {
+		return
+	}
+	e, ok := (*err).(*Error)
+	if !ok {
+		e = &Error{Err: *err}
+	}
+	if e.Kind == "" {
+		e.Kind = kind
+	}
+	if e.Operation == "" {
+		e.Operation = operation
+	}
+	zone, id := TargetOf(req)
+	if e.Zone == "" {
+		e.Zone = zone
+	}
+	if e.ID.IsEmpty() {
+		e.ID = id
+	}
+	*err = e
+}
+
+// TargetOf リクエストのZoneフィールドとIDフィールドの値を返す、フィールドが存在しない場合はゼロ値を返す
+func TargetOf(req interface{}) (zone string, id types.ID) {
+	v := reflect.ValueOf(req)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", 0
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return "", 0
+	}
+	if f := v.FieldByName("Zone"); f.IsValid() && f.Kind() == reflect.String {
+		zone = f.String()
+	}
+	if f := v.FieldByName("ID"); f.IsValid() && f.Type() == reflect.TypeOf(types.ID(0)) {
+		id = f.Interface().(types.ID)
+	}
+	return zone, id
+}
+
+// Error errorの実装
+func (e *Error) Error() string {
+	msg := ""
+	switch {
+	case e.Err != nil:
+		msg = e.Err.Error()
+	case e.Category != nil:
+		msg = e.Category.Error()
+	}
+	if e.Step != "" {
+		msg = fmt.Sprintf("%s: %s", e.Step, msg)
+	}
+	if e.Kind == "" || e.Operation == "" {
+		return msg
+	}
+
+	target := e.Kind
+	switch {
+	case e.Zone != "" && !e.ID.IsEmpty():
+		target += fmt.Sprintf("[%s:%s]", e.Zone, e.ID)
+	case !e.ID.IsEmpty():
+		target += fmt.Sprintf("[%s]", e.ID)
+	}
+	return fmt.Sprintf("%s %s failed: %s", e.Operation, target, msg)
+}
+
+// Unwrap 元のエラーを返す
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is errors.Isの実装、エラーの分類を判定する
+func (e *Error) Is(target error) bool {
+	if e.Category != nil && target == e.Category {
+		return true
+	}
+	switch target {
+	case ErrNotFound:
+		return responseCode(e.Err) == http.StatusNotFound
+	case ErrConflict:
+		return responseCode(e.Err) == http.StatusConflict
+	case ErrTimeout:
+		return errors.Is(e.Err, context.DeadlineExceeded)
+	}
+	return false
+}
+
+// APIError 元のエラーに含まれるiaas-api-goのAPIErrorを返す、含まれない場合はnil
+func (e *Error) APIError() sacloud.APIError {
+	var apiErr sacloud.APIError
+	if errors.As(e.Err, &apiErr) {
+		return apiErr
+	}
+	return nil
+}
+
+func responseCode(err error) int {
+	var apiErr sacloud.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ResponseCode()
+	}
+	return 0
+}
@@ -0,0 +1,74 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iaas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	sacloud "github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+type dummyRequest struct {
+	Zone string
+	ID   types.ID
+}
+
+func TestWrapError(t *testing.T) {
+	apiErr := sacloud.NewAPIError("GET", nil, http.StatusNotFound, &sacloud.APIErrorResponse{})
+
+	wrap := func(err error) (ret error) {
+		defer WrapError(&ret, "server", "update", &dummyRequest{Zone: "is1a", ID: 1})
+		return err
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		require.NoError(t, wrap(nil))
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		err := wrap(StepError("read", apiErr))
+
+		var e *Error
+		require.True(t, errors.As(err, &e))
+		require.Equal(t, "server", e.Kind)
+		require.Equal(t, "is1a", e.Zone)
+		require.Equal(t, types.ID(1), e.ID)
+		require.Equal(t, "update", e.Operation)
+		require.Equal(t, "read", e.Step)
+		require.Equal(t, apiErr, e.APIError())
+
+		require.True(t, errors.Is(err, ErrNotFound))
+		require.False(t, errors.Is(err, ErrConflict))
+		require.Equal(t, fmt.Sprintf("update server[is1a:1] failed: read: %s", apiErr), err.Error())
+	})
+
+	t.Run("category", func(t *testing.T) {
+		err := wrap(Errorf(ErrNeedsShutdown, "target has not yet shut down"))
+		require.True(t, errors.Is(err, ErrNeedsShutdown))
+		require.False(t, errors.Is(err, ErrValidationFailed))
+		require.EqualError(t, err, "update server[is1a:1] failed: target has not yet shut down")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		err := wrap(fmt.Errorf("waiting failed: %w", context.DeadlineExceeded))
+		require.True(t, errors.Is(err, ErrTimeout))
+	})
+}
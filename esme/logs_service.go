@@ -16,9 +16,9 @@ package esme
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Logs(req *LogsRequest) ([]*iaas.ESMELogs, error) {
@@ -26,13 +26,17 @@ func (s *Service) Logs(req *LogsRequest) ([]*iaas.ESMELogs, error) {
 }
 
 func (s *Service) LogsWithContext(ctx context.Context, req *LogsRequest) ([]*iaas.ESMELogs, error) {
+	return service.Invoke(ctx, s.interceptors, "esme", "logs", req, s.logs)
+}
+
+func (s *Service) logs(ctx context.Context, req *LogsRequest) ([]*iaas.ESMELogs, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 	client := iaas.NewESMEOp(s.caller)
 	_, err := client.Read(ctx, req.ID)
 	if err != nil {
-		return nil, fmt.Errorf("reading ESME[%s] failed: %s", req.ID, err)
+		return nil, service.StepError("read", err)
 	}
 
 	return client.Logs(ctx, req.ID)
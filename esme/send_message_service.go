@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) SendMessage(req *SendMessageRequest) (*iaas.ESMESendMessageResult, error) {
@@ -26,14 +27,18 @@ func (s *Service) SendMessage(req *SendMessageRequest) (*iaas.ESMESendMessageRes
 }
 
 func (s *Service) SendMessageWithContext(ctx context.Context, req *SendMessageRequest) (*iaas.ESMESendMessageResult, error) {
+	return service.Invoke(ctx, s.interceptors, "esme", "send_message", req, s.sendMessage)
+}
+
+func (s *Service) sendMessage(ctx context.Context, req *SendMessageRequest) (*iaas.ESMESendMessageResult, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewESMEOp(s.caller)
 	_, err := client.Read(ctx, req.ID)
 	if err != nil {
-		return nil, fmt.Errorf("reading ESME[%s] failed: %s", req.ID, err)
+		return nil, service.StepError("read", err)
 	}
 
 	params := req.ToRequestParameter()
@@ -0,0 +1,145 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iaas
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SecretPlaceholder パスワードなどのシークレットの実際の値の代わりに設定される値
+//
+// ExportやPlanの出力で利用される。Exportで得たリクエストをApplyする場合は実際の値へ置き換える必要がある。
+const SecretPlaceholder = "(sensitive)"
+
+// ValidateNoSecretPlaceholder reqの文字列フィールドにSecretPlaceholderが残っている場合はエラーを返す
+//
+// Exportで得たリクエストのシークレットを置き換えないままApplyし、SecretPlaceholderがそのまま設定されることを防ぐ。
+// シークレットを持つリクエストのValidateから呼び出す
+func ValidateNoSecretPlaceholder(req interface{}) error {
+	if path := findSecretPlaceholder(reflect.ValueOf(req), ""); path != "" {
+		return fmt.Errorf("%s: %q must be replaced with the actual value", path, SecretPlaceholder)
+	}
+	return nil
+}
+
+func findSecretPlaceholder(v reflect.Value, path string) string {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return ""
+		}
+		return findSecretPlaceholder(v.Elem(), path)
+	case reflect.String:
+		if v.String() == SecretPlaceholder {
+			return path
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name := f.Name
+			if path != "" {
+				name = path + "." + f.Name
+			}
+			if found := findSecretPlaceholder(v.Field(i), name); found != "" {
+				return found
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if found := findSecretPlaceholder(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); found != "" {
+				return found
+			}
+		}
+	}
+	return ""
+}
+
+// MarshalRequestJSON リクエストをJSONへ変換する
+func MarshalRequestJSON(req interface{}) ([]byte, error) {
+	return json.MarshalIndent(req, "", "  ")
+}
+
+// UnmarshalRequestJSON JSONからリクエストを組み立てる
+//
+// リクエストに存在しないフィールドが含まれる場合はエラーとなる
+func UnmarshalRequestJSON(data []byte, req interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(req)
+}
+
+// MarshalRequestYAML リクエストをYAMLへ変換する
+//
+// フィールド名はJSONと同じくGoのフィールド名が利用される
+func MarshalRequestYAML(req interface{}) ([]byte, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(yamlValue(v))
+}
+
+// UnmarshalRequestYAML YAMLからリクエストを組み立てる
+func UnmarshalRequestYAML(data []byte, req interface{}) error {
+	var v interface{}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	j, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return UnmarshalRequestJSON(j, req)
+}
+
+// yamlValue json.Numberを数値としてYAMLへ出力できる値に変換する
+func yamlValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]interface{}:
+		for k, value := range v {
+			v[k] = yamlValue(value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = yamlValue(value)
+		}
+		return v
+	}
+	return v
+}
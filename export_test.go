@@ -0,0 +1,79 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iaas
+
+import (
+	"testing"
+
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalRequest(t *testing.T) {
+	type request struct {
+		ID       types.ID
+		Name     string
+		Tags     types.Tags
+		SizeGB   int
+		Settings map[string]interface{}
+	}
+	req := &request{
+		ID:       123456789012,
+		Name:     "name",
+		Tags:     types.Tags{"tag1", "tag2"},
+		SizeGB:   20,
+		Settings: map[string]interface{}{"key": "value"},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		data, err := MarshalRequestJSON(req)
+		require.NoError(t, err)
+
+		restored := &request{}
+		require.NoError(t, UnmarshalRequestJSON(data, restored))
+		require.Equal(t, req, restored)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		data, err := MarshalRequestYAML(req)
+		require.NoError(t, err)
+		require.Contains(t, string(data), "ID: 123456789012")
+
+		restored := &request{}
+		require.NoError(t, UnmarshalRequestYAML(data, restored))
+		require.Equal(t, req, restored)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		err := UnmarshalRequestYAML([]byte("Unknown: 1"), &request{})
+		require.Error(t, err)
+	})
+}
+
+func TestValidateNoSecretPlaceholder(t *testing.T) {
+	type user struct {
+		Password string
+	}
+	type request struct {
+		Name  string
+		Users []*user
+	}
+
+	require.NoError(t, ValidateNoSecretPlaceholder(&request{Name: "name", Users: []*user{{Password: "password"}}}))
+
+	err := ValidateNoSecretPlaceholder(&request{Name: "name", Users: []*user{{Password: SecretPlaceholder}}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Users[0].Password")
+}
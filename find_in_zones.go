@@ -0,0 +1,79 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iaas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Zoned ゾーンを付与した検索結果
+type Zoned[T any] struct {
+	Zone  string
+	Value T
+}
+
+// ZoneError ゾーンごとの処理で発生したエラー
+type ZoneError struct {
+	Zone string
+	Err  error
+}
+
+// Error errorの実装
+func (e *ZoneError) Error() string {
+	return fmt.Sprintf("zone[%s]: %s", e.Zone, e.Err)
+}
+
+// Unwrap 元のエラーを返す
+func (e *ZoneError) Unwrap() error {
+	return e.Err
+}
+
+// FindInZonesFunc 単一ゾーンでの検索を行う関数
+type FindInZonesFunc[T any] func(ctx context.Context, zone string) ([]T, error)
+
+// FindInZones 複数のゾーンで並行して検索を行い、ゾーンを付与した結果を返す
+//
+// 結果はzonesで指定した順に並ぶ。いずれかのゾーンでエラーとなった場合でも他のゾーンの結果は返し、
+// エラーは*ZoneErrorをerrors.Joinでまとめたものを返す。
+func FindInZones[T any](ctx context.Context, zones []string, find FindInZonesFunc[T]) ([]*Zoned[T], error) {
+	values := make([][]T, len(zones))
+	errs := make([]error, len(zones))
+
+	var wg sync.WaitGroup
+	for i, zone := range zones {
+		wg.Add(1)
+		go func(i int, zone string) {
+			defer wg.Done()
+			found, err := find(ctx, zone)
+			if err != nil {
+				errs[i] = &ZoneError{Zone: zone, Err: err}
+				return
+			}
+			values[i] = found
+		}(i, zone)
+	}
+	wg.Wait()
+
+	var results []*Zoned[T]
+	for i, zone := range zones {
+		for _, v := range values[i] {
+			results = append(results, &Zoned[T]{Zone: zone, Value: v})
+		}
+	}
+	return results, errors.Join(errs...)
+}
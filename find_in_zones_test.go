@@ -0,0 +1,46 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iaas
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindInZones(t *testing.T) {
+	find := func(ctx context.Context, zone string) ([]string, error) {
+		if zone == "is1b" {
+			return nil, errors.New("unavailable")
+		}
+		return []string{zone + "-1", zone + "-2"}, nil
+	}
+
+	results, err := FindInZones(context.Background(), []string{"tk1a", "is1b", "is1a"}, find)
+
+	var zoneErr *ZoneError
+	require.True(t, errors.As(err, &zoneErr))
+	require.Equal(t, "is1b", zoneErr.Zone)
+	require.EqualError(t, err, "zone[is1b]: unavailable")
+
+	require.Equal(t, []*Zoned[string]{
+		{Zone: "tk1a", Value: "tk1a-1"},
+		{Zone: "tk1a", Value: "tk1a-2"},
+		{Zone: "is1a", Value: "is1a-1"},
+		{Zone: "is1a", Value: "is1a-2"},
+	}, results)
+}
@@ -0,0 +1,100 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hook builderの処理中の決められた箇所で任意の処理を呼び出すためのフックを提供する
+package hook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+// Point フックを呼び出す箇所
+type Point string
+
+const (
+	// AfterCreate リソースの作成直後
+	AfterCreate = Point("after_create")
+	// AfterDisk サーバへのディスクの作成/接続ごと
+	AfterDisk = Point("after_disk")
+	// BeforeUpdateSettings アプライアンスの設定投入の前
+	BeforeUpdateSettings = Point("before_update_settings")
+	// AfterConfig アプライアンスの設定反映の後
+	AfterConfig = Point("after_config")
+	// BeforeBoot 起動の前
+	BeforeBoot = Point("before_boot")
+	// BeforeShutdown Update時のシャットダウンの前
+	BeforeShutdown = Point("before_shutdown")
+)
+
+// Event フックに渡される情報
+type Event struct {
+	Point    Point
+	Zone     string
+	Resource string
+	ID       types.ID
+	// Index AfterDiskの場合は対象ディスクのインデックス、それ以外は0
+	Index int
+	// Target 対象リソースの値
+	//
+	// 例えばサーバのAfterCreateでは*iaas.Server、AfterDiskでは*disk.BuildResultとなる。
+	// 値が得られない箇所ではnilとなる
+	Target interface{}
+}
+
+// Func フックとして呼び出される関数
+//
+// エラーを返した場合、builderはそれ以降の処理を中断してエラーを返す
+type Func func(ctx context.Context, event *Event) error
+
+// Hooks 呼び出し箇所ごとのフックの集合
+//
+// nilの*Hooksに対するRunは何もしない
+type Hooks struct {
+	funcs map[Point][]Func
+}
+
+// New 空のHooksを返す
+func New() *Hooks {
+	return &Hooks{funcs: make(map[Point][]Func)}
+}
+
+// On 指定の箇所で呼び出すフックを登録する
+//
+// 同じ箇所に複数のフックを登録した場合は登録順に呼び出される
+func (h *Hooks) On(point Point, funcs ...Func) *Hooks {
+	if h.funcs == nil {
+		h.funcs = make(map[Point][]Func)
+	}
+	h.funcs[point] = append(h.funcs[point], funcs...)
+	return h
+}
+
+// Run event.Pointに登録されたフックを順に呼び出す
+//
+// フックがエラーを返した場合は残りのフックを呼び出さずにエラーを返す
+func (h *Hooks) Run(ctx context.Context, event *Event) error {
+	if h == nil {
+		return nil
+	}
+	for _, fn := range h.funcs[event.Point] {
+		if err := fn(ctx, event); err != nil {
+			return service.StepError(fmt.Sprintf("%s hook", event.Point), err)
+		}
+	}
+	return nil
+}
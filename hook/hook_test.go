@@ -0,0 +1,146 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hook_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	disk "github.com/sacloud/iaas-service-go/disk/builder"
+	"github.com/sacloud/iaas-service-go/hook"
+	server "github.com/sacloud/iaas-service-go/server/builder"
+	"github.com/sacloud/iaas-service-go/servicetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHooks_Run(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("nil hooks", func(t *testing.T) {
+		var hooks *hook.Hooks
+		require.NoError(t, hooks.Run(ctx, &hook.Event{Point: hook.AfterCreate}))
+	})
+
+	t.Run("calls hooks in order", func(t *testing.T) {
+		var called []string
+		hooks := hook.New().
+			On(hook.AfterCreate, func(ctx context.Context, event *hook.Event) error {
+				called = append(called, "first")
+				return nil
+			}).
+			On(hook.AfterCreate, func(ctx context.Context, event *hook.Event) error {
+				called = append(called, "second")
+				return nil
+			}).
+			On(hook.BeforeBoot, func(ctx context.Context, event *hook.Event) error {
+				called = append(called, "boot")
+				return nil
+			})
+
+		require.NoError(t, hooks.Run(ctx, &hook.Event{Point: hook.AfterCreate}))
+		require.Equal(t, []string{"first", "second"}, called)
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		called := 0
+		hooks := (&hook.Hooks{}).On(hook.BeforeBoot,
+			func(ctx context.Context, event *hook.Event) error {
+				called++
+				return errors.New("dummy")
+			},
+			func(ctx context.Context, event *hook.Event) error {
+				called++
+				return nil
+			},
+		)
+
+		err := hooks.Run(ctx, &hook.Event{Point: hook.BeforeBoot})
+		require.Error(t, err)
+		require.Equal(t, 1, called)
+
+		var serviceErr *service.Error
+		require.ErrorAs(t, err, &serviceErr)
+		require.Equal(t, "before_boot hook", serviceErr.Step)
+	})
+}
+
+func TestServerBuilder_hooks(t *testing.T) {
+	ctx := context.Background()
+	zone := "is1a"
+
+	t.Run("calls hooks at each point and aborts before boot", func(t *testing.T) {
+		backend := servicetest.NewBackend()
+
+		var points []hook.Point
+		var diskIDs []types.ID
+		record := func(ctx context.Context, event *hook.Event) error {
+			points = append(points, event.Point)
+			if event.Point == hook.AfterDisk {
+				diskIDs = append(diskIDs, event.Target.(*disk.BuildResult).DiskID)
+			}
+			return nil
+		}
+
+		builder := &server.Builder{
+			Name:     "example",
+			CPU:      1,
+			MemoryGB: 1,
+			NIC:      &server.SharedNICSetting{},
+			DiskBuilders: []disk.Builder{
+				&disk.BlankBuilder{Name: "disk1", SizeGB: 20, PlanID: types.DiskPlans.SSD, NoWait: true, Client: backend.DiskBuilderClient()},
+				&disk.BlankBuilder{Name: "disk2", SizeGB: 20, PlanID: types.DiskPlans.SSD, NoWait: true, Client: backend.DiskBuilderClient()},
+			},
+			BootAfterCreate: true,
+			Client:          backend.ServerBuilderClient(),
+			Hooks: hook.New().
+				On(hook.AfterCreate, record).
+				On(hook.AfterDisk, record).
+				On(hook.BeforeBoot, record, func(ctx context.Context, event *hook.Event) error {
+					return errors.New("dummy")
+				}),
+		}
+		result, err := builder.Build(ctx, zone)
+		require.Error(t, err)
+		require.Equal(t, []hook.Point{hook.AfterCreate, hook.AfterDisk, hook.AfterDisk, hook.BeforeBoot}, points)
+		require.Equal(t, result.DiskIDs, diskIDs)
+		require.Len(t, diskIDs, 2)
+		require.Equal(t, 0, backend.Calls("Server.Boot"))
+	})
+
+	t.Run("hook error aborts the build", func(t *testing.T) {
+		backend := servicetest.NewBackend()
+
+		builder := &server.Builder{
+			Name:            "example",
+			CPU:             1,
+			MemoryGB:        1,
+			NIC:             &server.SharedNICSetting{},
+			CDROMID:         types.ID(1),
+			BootAfterCreate: true,
+			Client:          backend.ServerBuilderClient(),
+			Hooks: hook.New().On(hook.AfterCreate, func(ctx context.Context, event *hook.Event) error {
+				return errors.New("dummy")
+			}),
+		}
+		_, err := builder.Build(ctx, zone)
+		require.Error(t, err)
+		require.Equal(t, 1, backend.Calls("Server.Create"))
+		require.Equal(t, 0, backend.Calls("Server.InsertCDROM"))
+		require.Equal(t, 0, backend.Calls("Server.Boot"))
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iaas
+
+import (
+	"context"
+	"fmt"
+)
+
+// Invocation サービスの操作の呼び出し内容
+type Invocation struct {
+	// Kind リソース種別 例: server
+	Kind string
+	// Operation 操作名 例: update
+	Operation string
+	// Request 操作に渡されるリクエスト 例: *server.UpdateRequest
+	//
+	// Interceptorで同じ型の値に置き換えることでリクエストを書き換えられる。
+	// リクエストを受け取らない操作の場合はnil
+	Request interface{}
+}
+
+// Handler 操作を実行する関数
+//
+// 戻り値は操作の戻り値、戻り値を持たない操作の場合はnil
+type Handler func(ctx context.Context, inv *Invocation) (interface{}, error)
+
+// Interceptor 操作の呼び出しに割り込む関数
+//
+// nextを呼び出すことで後続のInterceptorもしくは操作本体を実行する。
+type Interceptor func(ctx context.Context, inv *Invocation, next Handler) (interface{}, error)
+
+// Chain 複数のInterceptorを連結する、先頭に指定したものが最も外側で実行される
+func Chain(interceptors ...Interceptor) Interceptor {
+	return func(ctx context.Context, inv *Invocation, next Handler) (interface{}, error) {
+		handler := next
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, h := interceptors[i], handler
+			handler = func(ctx context.Context, inv *Invocation) (interface{}, error) {
+				return interceptor(ctx, inv, h)
+			}
+		}
+		return handler(ctx, inv)
+	}
+}
+
+// Options 各サービスの生成時に指定するオプション
+type Options struct {
+	Interceptors []Interceptor
+}
+
+// Option Optionsを設定する関数
+type Option func(o *Options)
+
+// WithInterceptors 全ての操作の呼び出しに割り込むInterceptorを追加する
+func WithInterceptors(interceptors ...Interceptor) Option {
+	return func(o *Options) {
+		o.Interceptors = append(o.Interceptors, interceptors...)
+	}
+}
+
+// NewOptions Optionを適用したOptionsを返す
+func NewOptions(opts ...Option) *Options {
+	o := &Options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Invoke Interceptorを経由して操作を実行する
+//
+// 各サービスのXxxWithContextメソッドから呼び出される。fnが返したエラーにはWrapErrorで操作の情報が付与される。
+func Invoke[Req, Res any](ctx context.Context, interceptors []Interceptor, kind, operation string, req Req, fn func(ctx context.Context, req Req) (Res, error)) (Res, error) {
+	handler := func(ctx context.Context, inv *Invocation) (result interface{}, err error) {
+		r, ok := inv.Request.(Req)
+		if !ok && inv.Request != nil {
+			return nil, fmt.Errorf("invalid request type for %s.%s: %T", kind, operation, inv.Request)
+		}
+		defer WrapError(&err, kind, operation, r)
+		return fn(ctx, r)
+	}
+
+	var (
+		result interface{}
+		err    error
+	)
+	inv := &Invocation{Kind: kind, Operation: operation, Request: req}
+	if len(interceptors) == 0 {
+		result, err = handler(ctx, inv)
+	} else {
+		result, err = Chain(interceptors...)(ctx, inv, handler)
+	}
+
+	res, _ := result.(Res)
+	return res, err
+}
+
+// InvokeNoResult 戻り値を持たない操作をInterceptorを経由して実行する
+func InvokeNoResult[Req any](ctx context.Context, interceptors []Interceptor, kind, operation string, req Req, fn func(ctx context.Context, req Req) error) error {
+	_, err := Invoke(ctx, interceptors, kind, operation, req, func(ctx context.Context, req Req) (interface{}, error) {
+		return nil, fn(ctx, req)
+	})
+	return err
+}
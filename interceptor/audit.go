@@ -0,0 +1,159 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sacloud/iaas-api-go/accessor"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/secret"
+)
+
+// RedactedValue 秘匿情報を置き換える値
+const RedactedValue = secret.RedactedValue
+
+// SensitiveFields 監査ログ出力時に値を伏せるフィールド名に含まれる文字列、大文字小文字は区別しない
+//
+// デフォルトはsecret.SensitiveFields
+var SensitiveFields = secret.SensitiveFields
+
+// readOnlyOperations 参照のみを行う操作名のプレフィックス
+var readOnlyOperations = []string{
+	"csv",
+	"download",
+	"export",
+	"find",
+	"get_",
+	"health",
+	"list",
+	"logs",
+	"monitor_",
+	"network_operators",
+	"plan",
+	"read",
+	"status",
+	"traffic_status",
+	"wait_",
+}
+
+// IsMutating 操作がリソースを変更するものであるか
+func IsMutating(operation string) bool {
+	for _, prefix := range readOnlyOperations {
+		if strings.HasPrefix(operation, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// AuditEntry 監査ログの1エントリ
+type AuditEntry struct {
+	Time      time.Time `json:"time"`
+	Kind      string    `json:"kind"`
+	Operation string    `json:"operation"`
+	Zone      string    `json:"zone,omitempty"`
+	// ID 対象リソースのID、作成系の操作の場合は作成されたリソースのID
+	ID       types.ID               `json:"id,omitempty"`
+	Request  map[string]interface{} `json:"request,omitempty"`
+	Duration time.Duration          `json:"duration"`
+	Error    string                 `json:"error,omitempty"`
+}
+
+// Journal 監査ログの出力先
+type Journal interface {
+	Record(entry *AuditEntry) error
+}
+
+// JSONJournal 監査ログを1行1エントリのJSONで出力するJournal
+type JSONJournal struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+// NewJSONJournal wへ出力するJSONJournalを返す
+func NewJSONJournal(w io.Writer) *JSONJournal {
+	return &JSONJournal{w: w}
+}
+
+// Record Journalの実装
+func (j *JSONJournal) Record(entry *AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	_, err = j.w.Write(append(data, '\n'))
+	return err
+}
+
+// Audit リソースを変更する操作を監査ログとしてjournalへ記録するInterceptorを返す
+//
+// リクエストのうちSensitiveFieldsに該当するフィールドの値はRedactedValueに置き換えて記録する。
+// 記録に失敗しても操作の結果には影響しない。
+func Audit(journal Journal) service.Interceptor {
+	return func(ctx context.Context, inv *service.Invocation, next service.Handler) (interface{}, error) {
+		if !IsMutating(inv.Operation) {
+			return next(ctx, inv)
+		}
+
+		started := time.Now()
+		result, err := next(ctx, inv)
+
+		zone, id := service.TargetOf(inv.Request)
+		if id.IsEmpty() && err == nil {
+			id = resultID(result)
+		}
+		entry := &AuditEntry{
+			Time:      started,
+			Kind:      inv.Kind,
+			Operation: inv.Operation,
+			Zone:      zone,
+			ID:        id,
+			Request:   Redact(inv.Request),
+			Duration:  time.Since(started),
+		}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		_ = journal.Record(entry)
+		return result, err
+	}
+}
+
+// resultID 操作の結果からリソースのIDを返す、結果がnil(型付きのnilを含む)の場合は空のIDを返す
+func resultID(result interface{}) types.ID {
+	v, ok := result.(accessor.ID)
+	if !ok {
+		return types.ID(0)
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return types.ID(0)
+	}
+	return v.GetID()
+}
+
+// Redact リクエストをmapに変換し、SensitiveFieldsに該当するフィールドの値を伏せて返す
+func Redact(req interface{}) map[string]interface{} {
+	return secret.Redact(req, SensitiveFields)
+}
@@ -0,0 +1,139 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/testutil"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/services"
+	"github.com/sacloud/iaas-service-go/swytch"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterceptors(t *testing.T) {
+	var logs, journal bytes.Buffer
+	stats := NewStats()
+
+	svc := swytch.New(testutil.SingletonAPICaller(), service.WithInterceptors(
+		Logging(slog.New(slog.NewTextHandler(&logs, nil))),
+		Metrics(stats),
+		Audit(NewJSONJournal(&journal)),
+		RewriteRequest(func(_ context.Context, req *swytch.CreateRequest) error {
+			req.Tags = append(req.Tags, "managed")
+			return nil
+		}),
+	))
+	zone := testutil.TestZone()
+
+	created, err := svc.Create(&swytch.CreateRequest{Zone: zone, Name: testutil.ResourceName("service-interceptor")})
+	require.NoError(t, err)
+	require.Contains(t, created.Tags, "managed")
+
+	_, err = svc.Read(&swytch.ReadRequest{Zone: zone})
+	require.Error(t, err)
+
+	require.Contains(t, logs.String(), "kind=switch operation=create")
+	require.Contains(t, logs.String(), "level=ERROR msg=\"service call failed\" kind=switch operation=read")
+
+	snapshot := stats.Snapshot()
+	require.Len(t, snapshot, 2)
+	require.Equal(t, "create", snapshot[0].Operation)
+	require.Equal(t, 1, snapshot[0].Count)
+	require.Equal(t, "read", snapshot[1].Operation)
+	require.Equal(t, 1, snapshot[1].Errors)
+
+	lines := strings.Split(strings.TrimSpace(journal.String()), "\n")
+	require.Len(t, lines, 1)
+	var entry AuditEntry
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	require.Equal(t, "create", entry.Operation)
+	require.Equal(t, created.ID, entry.ID)
+}
+
+func TestAudit_failedMutation(t *testing.T) {
+	var journal bytes.Buffer
+	interceptors := []service.Interceptor{Audit(NewJSONJournal(&journal))}
+
+	result, err := service.Invoke(context.Background(), interceptors, "server", "create", &struct{ Zone string }{Zone: "is1a"},
+		func(_ context.Context, _ *struct{ Zone string }) (*iaas.Server, error) {
+			return nil, errors.New("create failed")
+		},
+	)
+	require.Error(t, err)
+	require.Nil(t, result)
+
+	var entry AuditEntry
+	require.NoError(t, json.Unmarshal(journal.Bytes(), &entry))
+	require.Equal(t, "create", entry.Operation)
+	require.True(t, entry.ID.IsEmpty())
+	require.Contains(t, entry.Error, "create failed")
+}
+
+func TestRegistry_Use(t *testing.T) {
+	var journal bytes.Buffer
+	registry := services.New(testutil.SingletonAPICaller()).Registry()
+	registry.Use(Audit(NewJSONJournal(&journal)))
+
+	_, err := registry.Create(context.Background(), "note", map[string]interface{}{
+		"Name":    testutil.ResourceName("service-interceptor"),
+		"Class":   "shell",
+		"Content": "#!/bin/sh",
+	})
+	require.NoError(t, err)
+
+	var entry AuditEntry
+	require.NoError(t, json.Unmarshal(journal.Bytes(), &entry))
+	require.Equal(t, "note", entry.Kind)
+	require.False(t, entry.ID.IsEmpty())
+}
+
+func TestRedact(t *testing.T) {
+	type nested struct {
+		Password string
+	}
+	values := Redact(&struct {
+		Name       string
+		Password   string
+		PrivateKey string
+		Settings   []*nested
+	}{
+		Name:       "foo",
+		Password:   "p@ssw0rd",
+		PrivateKey: "-----BEGIN",
+		Settings:   []*nested{{Password: "secret"}},
+	})
+
+	require.Equal(t, "foo", values["Name"])
+	require.Equal(t, RedactedValue, values["Password"])
+	require.Equal(t, RedactedValue, values["PrivateKey"])
+	require.Equal(t, RedactedValue, values["Settings"].([]interface{})[0].(map[string]interface{})["Password"])
+}
+
+func TestIsMutating(t *testing.T) {
+	require.True(t, IsMutating("create"))
+	require.True(t, IsMutating("generate"))
+	require.False(t, IsMutating("find_all"))
+	require.False(t, IsMutating("monitor_cpu"))
+	require.False(t, IsMutating("wait_boot"))
+}
@@ -0,0 +1,57 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package interceptor 各サービスの操作の呼び出しに割り込むservice.Interceptorの実装を提供する
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	service "github.com/sacloud/iaas-service-go"
+)
+
+// Logging 操作の呼び出し結果をloggerへ出力するInterceptorを返す
+//
+// 成功時はInfo、失敗時はErrorレベルで出力する。loggerがnilの場合はslog.Default()を利用する。
+func Logging(logger *slog.Logger) service.Interceptor {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(ctx context.Context, inv *service.Invocation, next service.Handler) (interface{}, error) {
+		started := time.Now()
+		result, err := next(ctx, inv)
+
+		attrs := []slog.Attr{
+			slog.String("kind", inv.Kind),
+			slog.String("operation", inv.Operation),
+			slog.Duration("duration", time.Since(started)),
+		}
+		zone, id := service.TargetOf(inv.Request)
+		if zone != "" {
+			attrs = append(attrs, slog.String("zone", zone))
+		}
+		if !id.IsEmpty() {
+			attrs = append(attrs, slog.String("id", id.String()))
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+			logger.LogAttrs(ctx, slog.LevelError, "service call failed", attrs...)
+		} else {
+			logger.LogAttrs(ctx, slog.LevelInfo, "service call", attrs...)
+		}
+		return result, err
+	}
+}
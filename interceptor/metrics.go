@@ -0,0 +1,147 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/progress"
+)
+
+// Measurement 操作1回分の計測値
+type Measurement struct {
+	Kind      string
+	Operation string
+	Duration  time.Duration
+	// Retries 操作中に発生したリトライの回数、progress.EventRetryの数
+	Retries int
+	Err     error
+}
+
+// Recorder 計測値を受け取る
+type Recorder interface {
+	Record(m *Measurement)
+}
+
+// Metrics 操作ごとのレイテンシ/リトライ回数/エラーを計測してrecorderへ渡すInterceptorを返す
+//
+// リトライ回数はcontextにprogress.Observerを設定して数える。既に設定されているObserverにもイベントは通知される。
+func Metrics(recorder Recorder) service.Interceptor {
+	return func(ctx context.Context, inv *service.Invocation, next service.Handler) (interface{}, error) {
+		var (
+			mu      sync.Mutex
+			retries int
+		)
+		parent := progress.ObserverFromContext(ctx)
+		ctx = progress.WithObserver(ctx, progress.ObserverFunc(func(event *progress.Event) {
+			if event.Type == progress.EventRetry {
+				mu.Lock()
+				retries++
+				mu.Unlock()
+			}
+			if parent != nil {
+				parent.Observe(event)
+			}
+		}))
+
+		started := time.Now()
+		result, err := next(ctx, inv)
+
+		mu.Lock()
+		defer mu.Unlock()
+		recorder.Record(&Measurement{
+			Kind:      inv.Kind,
+			Operation: inv.Operation,
+			Duration:  time.Since(started),
+			Retries:   retries,
+			Err:       err,
+		})
+		return result, err
+	}
+}
+
+// OperationStats 操作ごとの集計値
+type OperationStats struct {
+	Kind          string
+	Operation     string
+	Count         int
+	Errors        int
+	Retries       int
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+}
+
+// AverageDuration 平均レイテンシ
+func (s *OperationStats) AverageDuration() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.TotalDuration / time.Duration(s.Count)
+}
+
+// Stats 計測値を操作ごとにメモリ上で集計するRecorder
+type Stats struct {
+	stats map[string]*OperationStats
+	mu    sync.Mutex
+}
+
+// NewStats 空のStatsを返す
+func NewStats() *Stats {
+	return &Stats{stats: make(map[string]*OperationStats)}
+}
+
+// Record Recorderの実装
+func (s *Stats) Record(m *Measurement) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := m.Kind + "." + m.Operation
+	stat, ok := s.stats[key]
+	if !ok {
+		stat = &OperationStats{Kind: m.Kind, Operation: m.Operation}
+		s.stats[key] = stat
+	}
+	stat.Count++
+	stat.Retries += m.Retries
+	stat.TotalDuration += m.Duration
+	if m.Duration > stat.MaxDuration {
+		stat.MaxDuration = m.Duration
+	}
+	if m.Err != nil {
+		stat.Errors++
+	}
+}
+
+// Snapshot 現時点の集計値をリソース種別/操作名の順で返す
+func (s *Stats) Snapshot() []OperationStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var stats []OperationStats
+	for _, stat := range s.stats {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Kind != stats[j].Kind {
+			return stats[i].Kind < stats[j].Kind
+		}
+		return stats[i].Operation < stats[j].Operation
+	})
+	return stats
+}
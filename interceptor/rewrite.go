@@ -0,0 +1,51 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+
+	service "github.com/sacloud/iaas-service-go"
+)
+
+// Rewrite 操作の実行前にfnを呼び出すInterceptorを返す
+//
+// fnではinv.Requestのフィールドを変更するか、同じ型の値に置き換えることでリクエストを書き換えられる。
+// fnがエラーを返した場合は操作を実行せずにそのエラーを返す。
+func Rewrite(fn func(ctx context.Context, inv *service.Invocation) error) service.Interceptor {
+	return func(ctx context.Context, inv *service.Invocation, next service.Handler) (interface{}, error) {
+		if err := fn(ctx, inv); err != nil {
+			return nil, err
+		}
+		return next(ctx, inv)
+	}
+}
+
+// RewriteRequest Reqの型のリクエストを受け取る操作に対してのみfnを呼び出すInterceptorを返す
+//
+// 例: 全てのサーバ作成時にタグを付与する
+//
+//	interceptor.RewriteRequest(func(ctx context.Context, req *server.CreateRequest) error {
+//		req.Tags = append(req.Tags, "managed")
+//		return nil
+//	})
+func RewriteRequest[Req any](fn func(ctx context.Context, req Req) error) service.Interceptor {
+	return Rewrite(func(ctx context.Context, inv *service.Invocation) error {
+		if req, ok := inv.Request.(Req); ok {
+			return fn(ctx, req)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+
+	service "github.com/sacloud/iaas-service-go"
+)
+
+// Tracer 操作ごとのスパンを開始する
+//
+// OpenTelemetryを利用する場合はtrace.Tracerをラップして実装する。
+// スパン名は"kind.operation"形式 例: server.update
+type Tracer interface {
+	Start(ctx context.Context, name string, attributes map[string]string) (context.Context, Span)
+}
+
+// Span Tracerが開始したスパン
+type Span interface {
+	// RecordError 操作が失敗した場合に呼ばれる
+	RecordError(err error)
+	End()
+}
+
+// Tracing 操作ごとにスパンを記録するInterceptorを返す
+//
+// 属性としてservice.kind/service.operationと、リクエストが持つ場合はservice.zone/service.idを付与する。
+func Tracing(tracer Tracer) service.Interceptor {
+	return func(ctx context.Context, inv *service.Invocation, next service.Handler) (interface{}, error) {
+		attributes := map[string]string{
+			"service.kind":      inv.Kind,
+			"service.operation": inv.Operation,
+		}
+		zone, id := service.TargetOf(inv.Request)
+		if zone != "" {
+			attributes["service.zone"] = zone
+		}
+		if !id.IsEmpty() {
+			attributes["service.id"] = id.String()
+		}
+
+		ctx, span := tracer.Start(ctx, inv.Kind+"."+inv.Operation, attributes)
+		defer span.End()
+
+		result, err := next(ctx, inv)
+		if err != nil {
+			span.RecordError(err)
+		}
+		return result, err
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iaas
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvoke(t *testing.T) {
+	ctx := context.Background()
+	var calls []string
+	record := func(name string) Interceptor {
+		return func(ctx context.Context, inv *Invocation, next Handler) (interface{}, error) {
+			calls = append(calls, name+":before")
+			result, err := next(ctx, inv)
+			calls = append(calls, name+":after")
+			return result, err
+		}
+	}
+	rewrite := func(ctx context.Context, inv *Invocation, next Handler) (interface{}, error) {
+		inv.Request = &dummyRequest{Zone: "tk1a", ID: 2}
+		return next(ctx, inv)
+	}
+
+	result, err := Invoke(ctx, []Interceptor{record("outer"), record("inner"), rewrite}, "server", "read", &dummyRequest{Zone: "is1a", ID: 1},
+		func(ctx context.Context, req *dummyRequest) (string, error) {
+			calls = append(calls, "call")
+			return req.Zone, nil
+		})
+	require.NoError(t, err)
+	require.Equal(t, "tk1a", result)
+	require.Equal(t, []string{"outer:before", "inner:before", "call", "inner:after", "outer:after"}, calls)
+
+	err = InvokeNoResult(ctx, nil, "server", "delete", &dummyRequest{Zone: "is1a", ID: 1}, func(ctx context.Context, req *dummyRequest) error {
+		return Errorf(ErrNeedsShutdown, "still running")
+	})
+	require.True(t, errors.Is(err, ErrNeedsShutdown))
+	require.EqualError(t, err, "delete server[is1a:1] failed: still running")
+}
@@ -16,9 +16,9 @@ package internet
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) AddSubnet(req *AddSubnetRequest) (*iaas.Subnet, error) {
@@ -26,19 +26,23 @@ func (s *Service) AddSubnet(req *AddSubnetRequest) (*iaas.Subnet, error) {
 }
 
 func (s *Service) AddSubnetWithContext(ctx context.Context, req *AddSubnetRequest) (*iaas.Subnet, error) {
+	return service.Invoke(ctx, s.interceptors, "internet", "add_subnet", req, s.addSubnet)
+}
+
+func (s *Service) addSubnet(ctx context.Context, req *AddSubnetRequest) (*iaas.Subnet, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewInternetOp(s.caller)
 	current, err := client.Read(ctx, req.Zone, req.ID)
 	if err != nil {
-		return nil, fmt.Errorf("reading the internet resource[%s] failed: %s", req.ID, err)
+		return nil, service.StepError("read", err)
 	}
 
 	params, err := req.ToRequestParameter(current)
 	if err != nil {
-		return nil, fmt.Errorf("processing request parameter failed: %s", err)
+		return nil, service.StepError("process request parameter", err)
 	}
 	result, err := client.AddSubnet(ctx, req.Zone, req.ID, params)
 	if err != nil {
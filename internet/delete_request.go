@@ -23,8 +23,9 @@ type DeleteRequest struct {
 	Zone string   `service:"-" validate:"required"`
 	ID   types.ID `service:"-" validate:"required"`
 
-	FailIfNotFound bool `service:"-"`
-	Force          bool `service:"-"` // trueの場合IPv6やサブネットも一緒に削除する(falseの場合これらがあるとDeleteでエラーとなる)
+	FailIfNotFound   bool `service:"-"`
+	FailIfReferenced bool `service:"-"` // trueの場合、他リソースから参照されている間は削除せずに参照元の一覧を含むエラーを返す
+	Force            bool `service:"-"` // trueの場合IPv6やサブネットも一緒に削除する(falseの場合これらがあるとDeleteでエラーとなる)
 }
 
 func (req *DeleteRequest) Validate() error {
@@ -19,6 +19,8 @@ import (
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/helper/cleanup"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/reference"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 )
 
@@ -27,8 +29,24 @@ func (s *Service) Delete(req *DeleteRequest) error {
 }
 
 func (s *Service) DeleteWithContext(ctx context.Context, req *DeleteRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "internet", "delete", req, s.delete)
+}
+
+func (s *Service) delete(ctx context.Context, req *DeleteRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
+	}
+
+	if req.FailIfReferenced {
+		inspector := &reference.Inspector{Caller: s.caller}
+		var ignore []reference.Kind
+		if req.Force {
+			// サブネットやIPv6はルータと一緒に削除される
+			ignore = []reference.Kind{reference.KindSubnet, reference.KindIPv6Net}
+		}
+		if err := inspector.Check(ctx, req.Zone, reference.KindInternet, req.ID, ignore...); err != nil {
+			return serviceutil.HandleNotFoundError(err, !req.FailIfNotFound)
+		}
 	}
 
 	client := iaas.NewInternetOp(s.caller)
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) DeleteSubnet(req *DeleteSubnetRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) DeleteSubnet(req *DeleteSubnetRequest) error {
 }
 
 func (s *Service) DeleteSubnetWithContext(ctx context.Context, req *DeleteSubnetRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "internet", "delete_subnet", req, s.deleteSubnet)
+}
+
+func (s *Service) deleteSubnet(ctx context.Context, req *DeleteSubnetRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewInternetOp(s.caller)
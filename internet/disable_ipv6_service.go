@@ -16,9 +16,9 @@ package internet
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) DisableIPv6(req *DisableIPv6Request) error {
@@ -26,14 +26,18 @@ func (s *Service) DisableIPv6(req *DisableIPv6Request) error {
 }
 
 func (s *Service) DisableIPv6WithContext(ctx context.Context, req *DisableIPv6Request) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "internet", "disable_i_pv6", req, s.disableIPv6)
+}
+
+func (s *Service) disableIPv6(ctx context.Context, req *DisableIPv6Request) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	internetOp := iaas.NewInternetOp(s.caller)
 	current, err := internetOp.Read(ctx, req.Zone, req.ID)
 	if err != nil {
-		return fmt.Errorf("reading the internet resource[%s] failed: %s", req.ID, err)
+		return service.StepError("read", err)
 	}
 
 	if len(current.Switch.IPv6Nets) == 0 {
@@ -16,9 +16,9 @@ package internet
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) EnableIPv6(req *EnableIPv6Request) (*iaas.IPv6Net, error) {
@@ -26,14 +26,18 @@ func (s *Service) EnableIPv6(req *EnableIPv6Request) (*iaas.IPv6Net, error) {
 }
 
 func (s *Service) EnableIPv6WithContext(ctx context.Context, req *EnableIPv6Request) (*iaas.IPv6Net, error) {
+	return service.Invoke(ctx, s.interceptors, "internet", "enable_i_pv6", req, s.enableIPv6)
+}
+
+func (s *Service) enableIPv6(ctx context.Context, req *EnableIPv6Request) (*iaas.IPv6Net, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	internetOp := iaas.NewInternetOp(s.caller)
 	current, err := internetOp.Read(ctx, req.Zone, req.ID)
 	if err != nil {
-		return nil, fmt.Errorf("reading the internet resource[%s] failed: %s", req.ID, err)
+		return nil, service.StepError("read", err)
 	}
 
 	ipv6Op := iaas.NewIPv6NetOp(s.caller)
@@ -16,9 +16,9 @@ package internet
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) ListSubnet(req *ListSubnetRequest) ([]*iaas.Subnet, error) {
@@ -26,14 +26,18 @@ func (s *Service) ListSubnet(req *ListSubnetRequest) ([]*iaas.Subnet, error) {
 }
 
 func (s *Service) ListSubnetWithContext(ctx context.Context, req *ListSubnetRequest) ([]*iaas.Subnet, error) {
+	return service.Invoke(ctx, s.interceptors, "internet", "list_subnet", req, s.listSubnet)
+}
+
+func (s *Service) listSubnet(ctx context.Context, req *ListSubnetRequest) ([]*iaas.Subnet, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	internetOp := iaas.NewInternetOp(s.caller)
 	current, err := internetOp.Read(ctx, req.Zone, req.ID)
 	if err != nil {
-		return nil, fmt.Errorf("reading the internet resource[%s] failed: %s", req.ID, err)
+		return nil, service.StepError("read", err)
 	}
 
 	// Note: *iaas.InternetのSwitch.Subnetsでは情報が不足しているため1件ずつReadする
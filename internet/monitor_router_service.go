@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 )
 
@@ -26,8 +27,12 @@ func (s *Service) MonitorRouter(req *MonitorRouterRequest) ([]*iaas.MonitorRoute
 }
 
 func (s *Service) MonitorRouterWithContext(ctx context.Context, req *MonitorRouterRequest) ([]*iaas.MonitorRouterValue, error) {
+	return service.Invoke(ctx, s.interceptors, "internet", "monitor_router", req, s.monitorRouter)
+}
+
+func (s *Service) monitorRouter(ctx context.Context, req *MonitorRouterRequest) ([]*iaas.MonitorRouterValue, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewInternetOp(s.caller)
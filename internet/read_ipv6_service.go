@@ -16,9 +16,9 @@ package internet
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) ReadIPv6(req *ReadIPv6Request) (*iaas.IPv6Net, error) {
@@ -26,14 +26,18 @@ func (s *Service) ReadIPv6(req *ReadIPv6Request) (*iaas.IPv6Net, error) {
 }
 
 func (s *Service) ReadIPv6WithContext(ctx context.Context, req *ReadIPv6Request) (*iaas.IPv6Net, error) {
+	return service.Invoke(ctx, s.interceptors, "internet", "read_i_pv6", req, s.readIPv6)
+}
+
+func (s *Service) readIPv6(ctx context.Context, req *ReadIPv6Request) (*iaas.IPv6Net, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	internetOp := iaas.NewInternetOp(s.caller)
 	current, err := internetOp.Read(ctx, req.Zone, req.ID)
 	if err != nil {
-		return nil, fmt.Errorf("reading the internet resource[%s] failed: %s", req.ID, err)
+		return nil, service.StepError("read", err)
 	}
 	if len(current.Switch.IPv6Nets) == 0 {
 		return nil, iaas.NewNoResultsError()
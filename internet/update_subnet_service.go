@@ -16,9 +16,9 @@ package internet
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) UpdateSubnet(req *UpdateSubnetRequest) (*iaas.Subnet, error) {
@@ -26,19 +26,23 @@ func (s *Service) UpdateSubnet(req *UpdateSubnetRequest) (*iaas.Subnet, error) {
 }
 
 func (s *Service) UpdateSubnetWithContext(ctx context.Context, req *UpdateSubnetRequest) (*iaas.Subnet, error) {
+	return service.Invoke(ctx, s.interceptors, "internet", "update_subnet", req, s.updateSubnet)
+}
+
+func (s *Service) updateSubnet(ctx context.Context, req *UpdateSubnetRequest) (*iaas.Subnet, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewInternetOp(s.caller)
 	current, err := client.Read(ctx, req.Zone, req.ID)
 	if err != nil {
-		return nil, fmt.Errorf("reading the internet resource[%s] failed: %s", req.ID, err)
+		return nil, service.StepError("read", err)
 	}
 
 	params, err := req.ToRequestParameter(current)
 	if err != nil {
-		return nil, fmt.Errorf("processing request parameter failed: %s", err)
+		return nil, service.StepError("process request parameter", err)
 	}
 	result, err := client.UpdateSubnet(ctx, req.Zone, req.ID, req.SubnetID, params)
 	if err != nil {
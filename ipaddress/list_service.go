@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) List(req *ListRequest) ([]*iaas.IPAddress, error) {
@@ -25,8 +26,12 @@ func (s *Service) List(req *ListRequest) ([]*iaas.IPAddress, error) {
 }
 
 func (s *Service) ListWithContext(ctx context.Context, req *ListRequest) ([]*iaas.IPAddress, error) {
+	return service.Invoke(ctx, s.interceptors, "ipaddress", "list", req, s.list)
+}
+
+func (s *Service) list(ctx context.Context, req *ListRequest) ([]*iaas.IPAddress, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewIPAddressOp(s.caller)
@@ -20,6 +20,8 @@ import (
 	"time"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/setup"
 )
 
 const (
@@ -32,8 +34,12 @@ func (s *Service) UpdateHostName(req *UpdateHostNameRequest) error {
 }
 
 func (s *Service) UpdateHostNameWithContext(ctx context.Context, req *UpdateHostNameRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "ipaddress", "update_host_name", req, s.updateHostName)
+}
+
+func (s *Service) updateHostName(ctx context.Context, req *UpdateHostNameRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewIPAddressOp(s.caller)
@@ -53,13 +59,15 @@ func (s *Service) UpdateHostNameWithContext(ctx context.Context, req *UpdateHost
 	success := false
 	for i < req.RetryMax {
 		if err := ctx.Err(); err != nil {
-			return fmt.Errorf("updating the HostName for %s failed: %s", req.IPAddress, err)
+			return fmt.Errorf("updating the HostName for %s failed: %w", req.IPAddress, err)
 		}
-		if _, err = client.UpdateHostName(ctx, req.Zone, req.IPAddress, req.HostName); err == nil {
+		if _, err := client.UpdateHostName(ctx, req.Zone, req.IPAddress, req.HostName); err == nil {
 			success = true
 			break
 		}
-		time.Sleep(time.Duration(req.RetryInterval) * time.Second)
+		if err := setup.Sleep(ctx, time.Duration(req.RetryInterval)*time.Second); err != nil {
+			return fmt.Errorf("updating the HostName for %s failed: %w", req.IPAddress, err)
+		}
 		i++
 	}
 
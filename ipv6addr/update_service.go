@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Update(req *UpdateRequest) (*iaas.IPv6Addr, error) {
@@ -26,14 +27,18 @@ func (s *Service) Update(req *UpdateRequest) (*iaas.IPv6Addr, error) {
 }
 
 func (s *Service) UpdateWithContext(ctx context.Context, req *UpdateRequest) (*iaas.IPv6Addr, error) {
+	return service.Invoke(ctx, s.interceptors, "ipv6addr", "update", req, s.update)
+}
+
+func (s *Service) update(ctx context.Context, req *UpdateRequest) (*iaas.IPv6Addr, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewIPv6AddrOp(s.caller)
 	_, err := client.Read(ctx, req.Zone, req.IPv6Addr)
 	if err != nil {
-		return nil, fmt.Errorf("reading IPv6Addr[%s] failed: %s", req.IPv6Addr, err)
+		return nil, fmt.Errorf("reading IPv6Addr[%s] failed: %w", req.IPv6Addr, err)
 	}
 
 	return client.Update(ctx, req.Zone, req.IPv6Addr, &iaas.IPv6AddrUpdateRequest{HostName: req.HostName})
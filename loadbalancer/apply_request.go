@@ -15,8 +15,11 @@
 package loadbalancer
 
 import (
+	"context"
+
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/loadbalancer/builder"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 	"github.com/sacloud/packages-go/validate"
@@ -53,3 +56,25 @@ func (req *ApplyRequest) Builder(caller iaas.APICaller) (*builder.Builder, error
 	}
 	return b, nil
 }
+
+// Preflight 参照先のリソースを確認し、検出した全ての問題を返す
+//
+// リソースの作成や更新は行わない
+func (req *ApplyRequest) Preflight(ctx context.Context, caller iaas.APICaller) error {
+	p := service.NewPreflight()
+
+	p.Ref("ID", "load balancer", req.Zone, req.ID, func() error {
+		_, err := iaas.NewLoadBalancerOp(caller).Read(ctx, req.Zone, req.ID)
+		return err
+	})
+	p.Ref("IconID", "icon", "", req.IconID, func() error {
+		_, err := iaas.NewIconOp(caller).Read(ctx, req.IconID)
+		return err
+	})
+	p.Ref("SwitchID", "switch", req.Zone, req.SwitchID, func() error {
+		_, err := iaas.NewSwitchOp(caller).Read(ctx, req.Zone, req.SwitchID)
+		return err
+	})
+
+	return p.Err()
+}
@@ -20,8 +20,10 @@ import (
 	"reflect"
 
 	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/helper/power"
 	"github.com/sacloud/iaas-api-go/helper/wait"
 	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/rollback"
 )
 
 type Builder struct {
@@ -40,14 +42,25 @@ type Builder struct {
 	DefaultRoute       string
 	VirtualIPAddresses iaas.LoadBalancerVirtualIPAddresses
 
-	NoWait       bool
-	SettingsHash string // for update
-	Client       iaas.LoadBalancerAPI
+	NoWait bool
+	// RollbackOnFailure trueの場合、作成後の起動待ちに失敗した際に作成したリソースを削除する
+	RollbackOnFailure bool
+	SettingsHash      string // for update
+	Client            iaas.LoadBalancerAPI
 }
 
 func (b *Builder) Build(ctx context.Context) (*iaas.LoadBalancer, error) {
 	if b.ID.IsEmpty() {
-		return b.create(ctx)
+		if !b.RollbackOnFailure {
+			return b.create(ctx)
+		}
+		var created *iaas.LoadBalancer
+		err := rollback.Run(ctx, func(ctx context.Context) error {
+			var err error
+			created, err = b.create(ctx)
+			return err
+		})
+		return created, err
 	}
 	return b.update(ctx)
 }
@@ -69,6 +82,12 @@ func (b *Builder) create(ctx context.Context) (*iaas.LoadBalancer, error) {
 	if err != nil {
 		return nil, err
 	}
+	rollback.Track(ctx, "loadbalancer", created.ID, func(ctx context.Context) error {
+		if err := power.ShutdownLoadBalancer(ctx, b.Client, b.Zone, created.ID, true); err != nil {
+			return err
+		}
+		return b.Client.Delete(ctx, b.Zone, created.ID)
+	})
 	if b.NoWait {
 		return created, nil
 	}
@@ -105,6 +124,9 @@ func (b *Builder) update(ctx context.Context) (*iaas.LoadBalancer, error) {
 	if err := b.validateForUpdate(current); err != nil {
 		return nil, err
 	}
+	if !b.plan(current).HasChanges() {
+		return current, nil
+	}
 
 	updated, err := b.Client.Update(ctx, b.Zone, b.ID, &iaas.LoadBalancerUpdateRequest{
 		Name:               b.Name,
@@ -0,0 +1,67 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+// Plan Build時の変更内容を算出する
+//
+// IDが空の場合は新規作成を表すPlanを返す。APIによるリソースの変更は行わない。
+func (b *Builder) Plan(ctx context.Context) (*service.Plan, error) {
+	if b.ID.IsEmpty() {
+		return service.NewCreatePlan(map[string]interface{}{
+			"Name":               b.Name,
+			"Description":        b.Description,
+			"Tags":               b.Tags,
+			"IconID":             b.IconID,
+			"SwitchID":           b.SwitchID,
+			"PlanID":             b.PlanID,
+			"VRID":               b.VRID,
+			"IPAddresses":        b.IPAddresses,
+			"NetworkMaskLen":     b.NetworkMaskLen,
+			"DefaultRoute":       b.DefaultRoute,
+			"VirtualIPAddresses": b.VirtualIPAddresses,
+		}), nil
+	}
+
+	current, err := b.Client.Read(ctx, b.Zone, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	return b.plan(current), nil
+}
+
+func (b *Builder) plan(current *iaas.LoadBalancer) *service.Plan {
+	plan := service.NewPlan()
+
+	plan.Diff("Name", current.Name, b.Name)
+	plan.Diff("Description", current.Description, b.Description)
+	plan.Diff("Tags", current.Tags, b.Tags)
+	plan.Diff("IconID", current.IconID, b.IconID)
+	plan.Diff("VirtualIPAddresses", current.VirtualIPAddresses, b.VirtualIPAddresses)
+
+	plan.DiffReplace("SwitchID", current.SwitchID, b.SwitchID)
+	plan.DiffReplace("PlanID", current.PlanID, b.PlanID)
+	plan.DiffReplace("VRID", current.VRID, b.VRID)
+	plan.DiffReplace("IPAddresses", current.IPAddresses, b.IPAddresses)
+	plan.DiffReplace("NetworkMaskLen", current.NetworkMaskLen, b.NetworkMaskLen)
+	plan.DiffReplace("DefaultRoute", current.DefaultRoute, b.DefaultRoute)
+	return plan
+}
@@ -19,6 +19,8 @@ import (
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/helper/wait"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/progress"
 )
 
 func (s *Service) WaitShutdown(req *WaitShutdownRequest) error {
@@ -26,11 +28,17 @@ func (s *Service) WaitShutdown(req *WaitShutdownRequest) error {
 }
 
 func (s *Service) WaitShutdownWithContext(ctx context.Context, req *WaitShutdownRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "loadbalancer", "wait_shutdown", req, s.waitShutdown)
+}
+
+func (s *Service) waitShutdown(ctx context.Context, req *WaitShutdownRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewLoadBalancerOp(s.caller)
-	_, err := wait.UntilLoadBalancerIsDown(ctx, client, req.Zone, req.ID)
-	return err
+	return progress.Wait(ctx, "loadbalancer", req.ID, "shutdown", func() error {
+		_, err := wait.UntilLoadBalancerIsDown(ctx, client, req.Zone, req.ID)
+		return err
+	})
 }
@@ -15,8 +15,12 @@
 package localrouter
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/packages-go/validate"
 )
 
@@ -35,7 +39,10 @@ type ApplyRequest struct {
 }
 
 func (req *ApplyRequest) Validate() error {
-	return validate.New().Struct(req)
+	if err := validate.New().Struct(req); err != nil {
+		return err
+	}
+	return service.ValidateNoSecretPlaceholder(req)
 }
 
 func (req *ApplyRequest) Builder(caller iaas.APICaller) *Builder {
@@ -53,3 +60,27 @@ func (req *ApplyRequest) Builder(caller iaas.APICaller) *Builder {
 		Caller:       caller,
 	}
 }
+
+// Preflight 参照先のリソースを確認し、検出した全ての問題を返す
+//
+// リソースの作成や更新は行わない
+func (req *ApplyRequest) Preflight(ctx context.Context, caller iaas.APICaller) error {
+	p := service.NewPreflight()
+
+	p.Ref("ID", "local router", "", req.ID, func() error {
+		_, err := iaas.NewLocalRouterOp(caller).Read(ctx, req.ID)
+		return err
+	})
+	p.Ref("IconID", "icon", "", req.IconID, func() error {
+		_, err := iaas.NewIconOp(caller).Read(ctx, req.IconID)
+		return err
+	})
+	for i, peer := range req.Peers {
+		p.Ref(fmt.Sprintf("Peers[%d].ID", i), "local router", "", peer.ID, func() error {
+			_, err := iaas.NewLocalRouterOp(caller).Read(ctx, peer.ID)
+			return err
+		})
+	}
+
+	return p.Err()
+}
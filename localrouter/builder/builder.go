@@ -104,10 +104,13 @@ func (b *Builder) Update(ctx context.Context, id types.ID) (*iaas.LocalRouter, e
 	}
 
 	// check Internet is exists
-	_, err := b.Client.LocalRouter.Read(ctx, id)
+	current, err := b.Client.LocalRouter.Read(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if !b.plan(current).HasChanges() {
+		return current, nil
+	}
 
 	localRouter, err := b.Client.LocalRouter.Update(ctx, id, &iaas.LocalRouterUpdateRequest{
 		Switch:       b.Switch,
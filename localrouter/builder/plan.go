@@ -0,0 +1,61 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localrouter
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+// Plan Build/Update時の変更内容を算出する
+//
+// idが空の場合は新規作成を表すPlanを返す。APIによるリソースの変更は行わない。
+func (b *Builder) Plan(ctx context.Context, id types.ID) (*service.Plan, error) {
+	if id.IsEmpty() {
+		return service.NewCreatePlan(map[string]interface{}{
+			"Name":         b.Name,
+			"Description":  b.Description,
+			"Tags":         b.Tags,
+			"IconID":       b.IconID,
+			"Switch":       b.Switch,
+			"Interface":    b.Interface,
+			"Peers":        b.Peers,
+			"StaticRoutes": b.StaticRoutes,
+		}), nil
+	}
+
+	current, err := b.Client.LocalRouter.Read(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return b.plan(current), nil
+}
+
+func (b *Builder) plan(current *iaas.LocalRouter) *service.Plan {
+	plan := service.NewPlan()
+
+	plan.Diff("Name", current.Name, b.Name)
+	plan.Diff("Description", current.Description, b.Description)
+	plan.Diff("Tags", current.Tags, b.Tags)
+	plan.Diff("IconID", current.IconID, b.IconID)
+	plan.Diff("Switch", current.Switch, b.Switch)
+	plan.Diff("Interface", current.Interface, b.Interface)
+	plan.Diff("Peers", current.Peers, b.Peers)
+	plan.Diff("StaticRoutes", current.StaticRoutes, b.StaticRoutes)
+	return plan
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Health(req *HealthRequest) (*iaas.LocalRouterHealth, error) {
@@ -25,8 +26,12 @@ func (s *Service) Health(req *HealthRequest) (*iaas.LocalRouterHealth, error) {
 }
 
 func (s *Service) HealthWithContext(ctx context.Context, req *HealthRequest) (*iaas.LocalRouterHealth, error) {
+	return service.Invoke(ctx, s.interceptors, "localrouter", "health", req, s.health)
+}
+
+func (s *Service) health(ctx context.Context, req *HealthRequest) (*iaas.LocalRouterHealth, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewLocalRouterOp(s.caller)
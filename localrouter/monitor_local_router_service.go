@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 )
 
@@ -26,8 +27,12 @@ func (s *Service) MonitorLocalRouter(req *MonitorLocalRouterRequest) ([]*iaas.Mo
 }
 
 func (s *Service) MonitorLocalRouterWithContext(ctx context.Context, req *MonitorLocalRouterRequest) ([]*iaas.MonitorLocalRouterValue, error) {
+	return service.Invoke(ctx, s.interceptors, "localrouter", "monitor_local_router", req, s.monitorLocalRouter)
+}
+
+func (s *Service) monitorLocalRouter(ctx context.Context, req *MonitorLocalRouterRequest) ([]*iaas.MonitorLocalRouterValue, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewLocalRouterOp(s.caller)
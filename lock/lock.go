@@ -0,0 +1,279 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lock タグを用いたリソースの勧告ロック(リース)を提供する
+//
+// ロックはリソースのタグに保持者と有効期限を書き込むことで表現する。
+// 同じリソースに対するApplyなどの並行実行を避けるためのもので、ロックを取得しない操作を妨げるものではない。
+//
+// サーバのタグの更新は読み込み後の変更を検出できない(SettingsHashを持たない)ため、
+// 取得時は書き込み後にLocker.SettleDelayだけ待ってから再度読み込み、他の保持者に上書きされていないことを確認する。
+// また取得後もHeld.Verifyで変更を伴う処理の前ごとにリースを確認する。
+// それでもSettleDelayを超えて遅延した他の保持者の書き込みと、確認から変更までの間に行われた書き込みは検出できない。
+// 厳密な排他が必要な場合はSettingsHashを持つVPCルータなどを対象とするか、外部のロック機構を用いる。
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+const (
+	// TagPrefix ロックを表すタグのプレフィックス
+	//
+	// タグは"lock=<保持者>/<有効期限(UNIX時間)>"の形式となる
+	TagPrefix = "lock="
+	// DefaultTTL Locker.TTLのデフォルト値
+	DefaultTTL = 30 * time.Minute
+	// DefaultPollInterval Locker.PollIntervalのデフォルト値
+	DefaultPollInterval = 5 * time.Second
+	// DefaultSettleDelay Locker.SettleDelayのデフォルト値
+	DefaultSettleDelay = 3 * time.Second
+)
+
+// Lease ロックの保持者と有効期限
+type Lease struct {
+	Owner     string
+	ExpiresAt time.Time
+}
+
+// Tag リソースに書き込むタグを返す
+func (l *Lease) Tag() string {
+	return fmt.Sprintf("%s%s/%d", TagPrefix, l.Owner, l.ExpiresAt.Unix())
+}
+
+// Active 指定時刻においてリースが有効か
+func (l *Lease) Active(now time.Time) bool {
+	return now.Before(l.ExpiresAt)
+}
+
+// Equal 保持者と有効期限が同じか
+func (l *Lease) Equal(other *Lease) bool {
+	return l != nil && other != nil && l.Owner == other.Owner && l.ExpiresAt.Unix() == other.ExpiresAt.Unix()
+}
+
+// ParseTag ロックを表すタグをLeaseに変換する、ロックを表すタグでない場合はnilを返す
+func ParseTag(tag string) *Lease {
+	if !strings.HasPrefix(tag, TagPrefix) {
+		return nil
+	}
+	v := strings.TrimPrefix(tag, TagPrefix)
+	i := strings.LastIndex(v, "/")
+	if i <= 0 {
+		return nil
+	}
+	expiresAt, err := strconv.ParseInt(v[i+1:], 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &Lease{Owner: v[:i], ExpiresAt: time.Unix(expiresAt, 0)}
+}
+
+// FindLease タグからリースを探す、存在しない場合はnilを返す
+func FindLease(tags types.Tags) *Lease {
+	for _, tag := range tags {
+		if lease := ParseTag(tag); lease != nil {
+			return lease
+		}
+	}
+	return nil
+}
+
+// StripTags ロックを表すタグを取り除いたタグを返す
+func StripTags(tags types.Tags) types.Tags {
+	var stripped types.Tags
+	for _, tag := range tags {
+		if ParseTag(tag) == nil {
+			stripped = append(stripped, tag)
+		}
+	}
+	return stripped
+}
+
+// WithLease ロックを表すタグをleaseのものに置き換えたタグを返す
+func WithLease(tags types.Tags, lease *Lease) types.Tags {
+	return append(StripTags(tags), lease.Tag())
+}
+
+// LockedError 他の保持者のリースが有効な場合のエラー
+type LockedError struct {
+	// Lease 有効なリース、書き込み直後にロックを失った場合はnil
+	Lease *Lease
+}
+
+// Error errorの実装
+func (e *LockedError) Error() string {
+	if e.Lease == nil {
+		return "lock was taken by another owner"
+	}
+	return fmt.Sprintf("locked by %q until %s", e.Lease.Owner, e.Lease.ExpiresAt.Format(time.RFC3339))
+}
+
+// Locker リースの取得を行う
+type Locker struct {
+	// Owner ロックの保持者を識別する名前、"/"以外の任意の文字列
+	//
+	// CIのジョブIDなど実行ごとに一意な値を指定する。同じOwnerのリースは再取得(延長)できる
+	Owner string
+	// TTL リースの有効期間、省略時はDefaultTTL
+	//
+	// 解放されなかったリースは有効期限を過ぎると失効する。対象の操作に要する時間より長い値を指定する
+	TTL time.Duration
+
+	// Wait trueの場合、他の保持者のリースが有効な間は解放/失効を待つ
+	//
+	// falseの場合はErrConflictに分類された*LockedErrorを返す
+	Wait bool
+	// WaitTimeout Waitがtrueの場合の待ち時間のタイムアウト、0の場合はcontextが終了するまで待つ
+	WaitTimeout time.Duration
+	// PollInterval Waitがtrueの場合のポーリング間隔、省略時はDefaultPollInterval
+	PollInterval time.Duration
+	// SettleDelay リースの書き込みから上書きの確認までの待ち時間、省略時はDefaultSettleDelay
+	//
+	// 同時に取得を試みた他の保持者の書き込みがこの時間内に反映されれば検出できる
+	SettleDelay time.Duration
+}
+
+// Held 取得したロック
+type Held struct {
+	Lease  *Lease
+	target Target
+}
+
+var errNotHeld = errors.New("lock is not held")
+
+// Acquire targetのロックを取得する
+func (l *Locker) Acquire(ctx context.Context, target Target) (*Held, error) {
+	if l.Owner == "" || strings.Contains(l.Owner, "/") {
+		return nil, service.ValidationError(fmt.Errorf("invalid lock owner: %q", l.Owner))
+	}
+	if l.Wait && l.WaitTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.WaitTimeout)
+		defer cancel()
+	}
+
+	for {
+		lease, err := l.tryAcquire(ctx, target)
+		if err == nil {
+			return &Held{Lease: lease, target: target}, nil
+		}
+		var locked *LockedError
+		if !l.Wait || !errors.As(err, &locked) {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, service.NewError(service.ErrTimeout, fmt.Errorf("%s: %w", locked, ctx.Err()))
+		case <-time.After(l.pollInterval()):
+		}
+	}
+}
+
+func (l *Locker) tryAcquire(ctx context.Context, target Target) (*Lease, error) {
+	now := time.Now()
+	lease := &Lease{Owner: l.Owner, ExpiresAt: now.Add(l.ttl())}
+
+	err := target.UpdateTags(ctx, func(tags types.Tags) (types.Tags, error) {
+		if current := FindLease(tags); current != nil && current.Owner != l.Owner && current.Active(now) {
+			return nil, service.NewError(service.ErrConflict, &LockedError{Lease: current})
+		}
+		return WithLease(tags, lease), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 同時に書き込んだ他の保持者に上書きされていないか、書き込みが反映されるのを待ってから確認する
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(l.settleDelay()):
+	}
+	if err := verify(ctx, target, lease); err != nil {
+		return nil, err
+	}
+	return lease, nil
+}
+
+func verify(ctx context.Context, target Target, lease *Lease) error {
+	tags, err := target.ReadTags(ctx)
+	if err != nil {
+		return err
+	}
+	if current := FindLease(tags); !current.Equal(lease) {
+		return service.NewError(service.ErrConflict, &LockedError{Lease: current})
+	}
+	return nil
+}
+
+func (l *Locker) ttl() time.Duration {
+	if l.TTL <= 0 {
+		return DefaultTTL
+	}
+	return l.TTL
+}
+
+func (l *Locker) settleDelay() time.Duration {
+	if l.SettleDelay <= 0 {
+		return DefaultSettleDelay
+	}
+	return l.SettleDelay
+}
+
+func (l *Locker) pollInterval() time.Duration {
+	if l.PollInterval <= 0 {
+		return DefaultPollInterval
+	}
+	return l.PollInterval
+}
+
+// Verify リースが現在も保持されているか確認する
+//
+// 他の保持者に上書きされている場合はErrConflictに分類された*LockedErrorを返す。変更を伴う処理の前ごとに呼び出す
+func (h *Held) Verify(ctx context.Context) error {
+	return verify(ctx, h.target, h.Lease)
+}
+
+// Move 解放対象のリソースを変更する
+//
+// プラン変更などでリースを引き継いだまま対象リソースのIDが変わった場合に用いる
+func (h *Held) Move(target Target) {
+	h.target = target
+}
+
+// Release ロックを解放する
+//
+// 既にリースが失効し他の保持者に取得されている場合や、対象リソースが削除されている場合は何もしない
+func (h *Held) Release(ctx context.Context) error {
+	err := h.target.UpdateTags(ctx, func(tags types.Tags) (types.Tags, error) {
+		if !FindLease(tags).Equal(h.Lease) {
+			return nil, errNotHeld
+		}
+		return StripTags(tags), nil
+	})
+	if errors.Is(err, errNotHeld) || iaas.IsNotFoundError(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,196 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/lock"
+	"github.com/sacloud/iaas-service-go/server"
+	"github.com/sacloud/iaas-service-go/vpcrouter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTag(t *testing.T) {
+	lease := &lock.Lease{Owner: "ci/job", ExpiresAt: time.Unix(1700000000, 0)}
+	require.Equal(t, "lock=ci/job/1700000000", lease.Tag())
+	require.True(t, lease.Equal(lock.ParseTag(lease.Tag())))
+
+	require.Nil(t, lock.ParseTag("env=pr-1234"))
+	require.Nil(t, lock.ParseTag("lock=owner"))
+
+	tags := lock.WithLease(types.Tags{"env=pr-1234", "lock=other/1"}, lease)
+	require.Equal(t, types.Tags{"env=pr-1234", lease.Tag()}, tags)
+	require.Equal(t, types.Tags{"env=pr-1234"}, lock.StripTags(tags))
+}
+
+func TestLocker(t *testing.T) {
+	ctx := context.Background()
+	caller := testutil.SingletonAPICaller()
+	zone := testutil.TestZone()
+
+	created, err := iaas.NewServerOp(caller).Create(ctx, zone, &iaas.ServerCreateRequest{
+		Name:     testutil.ResourceName("lock"),
+		CPU:      1,
+		MemoryMB: 1024,
+		Tags:     types.Tags{"env=pr-1234"},
+	})
+	require.NoError(t, err)
+	target := lock.NewServerTarget(caller, zone, created.ID)
+
+	a := &lock.Locker{Owner: "pipeline-a", TTL: time.Hour, SettleDelay: time.Millisecond}
+	held, err := a.Acquire(ctx, target)
+	require.NoError(t, err)
+	tags, err := target.ReadTags(ctx)
+	require.NoError(t, err)
+	require.Contains(t, tags, "env=pr-1234")
+	require.True(t, held.Lease.Equal(lock.FindLease(tags)))
+
+	// 他の保持者のリースが有効な間は取得できない
+	b := &lock.Locker{Owner: "pipeline-b", SettleDelay: time.Millisecond}
+	_, err = b.Acquire(ctx, target)
+	require.True(t, errors.Is(err, service.ErrConflict))
+	var locked *lock.LockedError
+	require.True(t, errors.As(err, &locked))
+	require.Equal(t, "pipeline-a", locked.Lease.Owner)
+
+	b.Wait = true
+	b.WaitTimeout = 50 * time.Millisecond
+	b.PollInterval = 10 * time.Millisecond
+	_, err = b.Acquire(ctx, target)
+	require.True(t, errors.Is(err, service.ErrTimeout))
+
+	// ロックを取得していないApplyは実行できない
+	_, err = server.New(caller).ApplyWithContext(ctx, &server.ApplyRequest{
+		Zone:     zone,
+		ID:       created.ID,
+		Name:     created.Name,
+		CPU:      2,
+		MemoryGB: 4,
+		Lock:     &lock.Locker{Owner: "pipeline-b", SettleDelay: time.Millisecond},
+	})
+	require.True(t, errors.Is(err, service.ErrConflict))
+	read, err := iaas.NewServerOp(caller).Read(ctx, zone, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, read.CPU)
+
+	// 解放後は他の保持者が取得できる
+	require.NoError(t, held.Release(ctx))
+	tags, err = target.ReadTags(ctx)
+	require.NoError(t, err)
+	require.Equal(t, types.Tags{"env=pr-1234"}, tags)
+
+	held, err = b.Acquire(ctx, target)
+	require.NoError(t, err)
+	require.Equal(t, "pipeline-b", held.Lease.Owner)
+
+	// 期限切れのリースは無視される
+	require.NoError(t, target.UpdateTags(ctx, func(tags types.Tags) (types.Tags, error) {
+		return lock.WithLease(tags, &lock.Lease{Owner: "pipeline-b", ExpiresAt: time.Now().Add(-time.Minute)}), nil
+	}))
+	_, err = a.Acquire(ctx, target)
+	require.NoError(t, err)
+
+	// 失効後に他の保持者が取得したロックは解放しない
+	require.NoError(t, held.Release(ctx))
+	tags, err = target.ReadTags(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "pipeline-a", lock.FindLease(tags).Owner)
+}
+
+func TestLocker_planChange(t *testing.T) {
+	ctx := context.Background()
+	caller := testutil.SingletonAPICaller()
+	zone := testutil.TestZone()
+
+	created, err := iaas.NewServerOp(caller).Create(ctx, zone, &iaas.ServerCreateRequest{
+		Name:     testutil.ResourceName("lock"),
+		CPU:      1,
+		MemoryMB: 1024,
+		Tags:     types.Tags{"env=pr-1234"},
+	})
+	require.NoError(t, err)
+
+	updated, err := server.New(caller).ApplyWithContext(ctx, &server.ApplyRequest{
+		Zone:     zone,
+		ID:       created.ID,
+		Name:     created.Name,
+		Tags:     types.Tags{"env=pr-1234"},
+		CPU:      2,
+		MemoryGB: 4,
+		Lock:     &lock.Locker{Owner: "pipeline-a", SettleDelay: time.Millisecond},
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, created.ID, updated.ID)
+
+	// プラン変更後のサーバにリースが残らない
+	tags, err := lock.NewServerTarget(caller, zone, updated.ID).ReadTags(ctx)
+	require.NoError(t, err)
+	require.Nil(t, lock.FindLease(tags))
+	require.Contains(t, tags, "env=pr-1234")
+}
+
+func TestHeld_Verify(t *testing.T) {
+	ctx := context.Background()
+	caller := testutil.SingletonAPICaller()
+	zone := testutil.TestZone()
+
+	created, err := iaas.NewServerOp(caller).Create(ctx, zone, &iaas.ServerCreateRequest{
+		Name:     testutil.ResourceName("lock"),
+		CPU:      1,
+		MemoryMB: 1024,
+	})
+	require.NoError(t, err)
+	target := lock.NewServerTarget(caller, zone, created.ID)
+
+	held, err := (&lock.Locker{Owner: "pipeline-a", SettleDelay: time.Millisecond}).Acquire(ctx, target)
+	require.NoError(t, err)
+	require.NoError(t, held.Verify(ctx))
+
+	// 取得の確認後に遅れて書き込んだ他の保持者の上書きは変更前の確認で検出する
+	require.NoError(t, target.UpdateTags(ctx, func(tags types.Tags) (types.Tags, error) {
+		return lock.WithLease(tags, &lock.Lease{Owner: "pipeline-b", ExpiresAt: time.Now().Add(time.Hour)}), nil
+	}))
+	err = held.Verify(ctx)
+	require.True(t, errors.Is(err, service.ErrConflict))
+	var locked *lock.LockedError
+	require.True(t, errors.As(err, &locked))
+	require.Equal(t, "pipeline-b", locked.Lease.Owner)
+}
+
+func TestLocker_notSerialized(t *testing.T) {
+	// Lockは実行時のみ利用するためリクエストのエクスポートには含めない
+	locker := &lock.Locker{Owner: "ci/job"}
+	for _, req := range []interface{}{
+		&server.ApplyRequest{Name: "example", Lock: locker},
+		&vpcrouter.ApplyRequest{Name: "example", Lock: locker},
+	} {
+		data, err := service.MarshalRequestJSON(req)
+		require.NoError(t, err)
+		require.NotContains(t, string(data), "Lock")
+		require.NotContains(t, string(data), "ci/job")
+
+		data, err = service.MarshalRequestYAML(req)
+		require.NoError(t, err)
+		require.NotContains(t, string(data), "Lock")
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lock
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// Target ロックの対象となるリソース
+type Target interface {
+	// ReadTags 現在のタグを返す
+	ReadTags(ctx context.Context) (types.Tags, error)
+	// UpdateTags 現在のタグをfnに渡し、fnの戻り値でタグを更新する
+	//
+	// fnがエラーを返した場合は更新を行わずにそのエラーを返す
+	UpdateTags(ctx context.Context, fn func(tags types.Tags) (types.Tags, error)) error
+}
+
+// NewServerTarget サーバをロックの対象とするTargetを返す
+func NewServerTarget(caller iaas.APICaller, zone string, id types.ID) Target {
+	return &serverTarget{client: iaas.NewServerOp(caller), zone: zone, id: id}
+}
+
+type serverTarget struct {
+	client iaas.ServerAPI
+	zone   string
+	id     types.ID
+}
+
+func (t *serverTarget) ReadTags(ctx context.Context) (types.Tags, error) {
+	server, err := t.client.Read(ctx, t.zone, t.id)
+	if err != nil {
+		return nil, err
+	}
+	return server.Tags, nil
+}
+
+func (t *serverTarget) UpdateTags(ctx context.Context, fn func(tags types.Tags) (types.Tags, error)) error {
+	server, err := t.client.Read(ctx, t.zone, t.id)
+	if err != nil {
+		return err
+	}
+	tags, err := fn(server.Tags)
+	if err != nil {
+		return err
+	}
+	_, err = t.client.Update(ctx, t.zone, t.id, &iaas.ServerUpdateRequest{
+		Name:            server.Name,
+		Description:     server.Description,
+		Tags:            tags,
+		IconID:          server.IconID,
+		PrivateHostID:   server.PrivateHostID,
+		InterfaceDriver: server.InterfaceDriver,
+	})
+	return err
+}
+
+// NewVPCRouterTarget VPCルータをロックの対象とするTargetを返す
+//
+// タグの更新時はSettingsHashを指定し、読み込み後に設定が変更されていた場合は更新を行わない
+func NewVPCRouterTarget(caller iaas.APICaller, zone string, id types.ID) Target {
+	return &vpcRouterTarget{client: iaas.NewVPCRouterOp(caller), zone: zone, id: id}
+}
+
+type vpcRouterTarget struct {
+	client iaas.VPCRouterAPI
+	zone   string
+	id     types.ID
+}
+
+func (t *vpcRouterTarget) ReadTags(ctx context.Context) (types.Tags, error) {
+	router, err := t.client.Read(ctx, t.zone, t.id)
+	if err != nil {
+		return nil, err
+	}
+	return router.Tags, nil
+}
+
+func (t *vpcRouterTarget) UpdateTags(ctx context.Context, fn func(tags types.Tags) (types.Tags, error)) error {
+	router, err := t.client.Read(ctx, t.zone, t.id)
+	if err != nil {
+		return err
+	}
+	tags, err := fn(router.Tags)
+	if err != nil {
+		return err
+	}
+	_, err = t.client.Update(ctx, t.zone, t.id, &iaas.VPCRouterUpdateRequest{
+		Name:         router.Name,
+		Description:  router.Description,
+		Tags:         tags,
+		IconID:       router.IconID,
+		Settings:     router.Settings,
+		SettingsHash: router.SettingsHash,
+	})
+	return err
+}
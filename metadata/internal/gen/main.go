@@ -0,0 +1,172 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// gen 構造体/フィールドのコメントを収集しmetadata/zz_descriptions.goを生成する
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	moduleRoot  = "../"
+	destination = "zz_descriptions.go"
+)
+
+func main() {
+	descriptions := make(map[string]string)
+
+	err := filepath.Walk(moduleRoot, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			name := info.Name()
+			if path != moduleRoot && (strings.HasPrefix(name, ".") || name == "internal" || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") || strings.HasPrefix(info.Name(), "zz_") {
+			return nil
+		}
+		return collect(path, descriptions)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := write(descriptions); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func collect(path string, descriptions map[string]string) error {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(moduleRoot, filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	prefix := ""
+	if rel != "." {
+		prefix = filepath.ToSlash(rel) + "."
+	}
+
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if !ts.Name.IsExported() {
+				continue
+			}
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+
+			doc := ts.Doc
+			if doc == nil && len(gd.Specs) == 1 {
+				doc = gd.Doc
+			}
+			typeKey := prefix + ts.Name.Name
+			if text := description(ts.Name.Name, doc); text != "" {
+				descriptions[typeKey] = text
+			}
+
+			for _, field := range st.Fields.List {
+				for _, name := range field.Names {
+					if !name.IsExported() {
+						continue
+					}
+					text := description(name.Name, field.Doc)
+					if text == "" {
+						text = description(name.Name, field.Comment)
+					}
+					if text != "" {
+						descriptions[typeKey+"."+name.Name] = text
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// description コメントから説明文を取り出す、先頭の識別子は取り除く
+func description(name string, cg *ast.CommentGroup) string {
+	if cg == nil {
+		return ""
+	}
+	text := strings.TrimSpace(cg.Text())
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Note:") || strings.HasPrefix(line, "TODO") {
+			break
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	text = strings.Join(lines, " ")
+	text = strings.TrimSpace(strings.TrimPrefix(text, name+" "))
+	if text == name {
+		return ""
+	}
+	return text
+}
+
+func write(descriptions map[string]string) error {
+	var keys []string
+	for k := range descriptions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	buf := &bytes.Buffer{}
+	header, err := os.ReadFile(filepath.Join(moduleRoot, "update_level.go"))
+	if err != nil {
+		return err
+	}
+	buf.WriteString(strings.SplitN(string(header), "package", 2)[0])
+	buf.WriteString("// generated by 'metadata/internal/gen/main.go'; DO NOT EDIT\n\n")
+	buf.WriteString("package metadata\n\n")
+	buf.WriteString("var descriptions = map[string]string{\n")
+	for _, k := range keys {
+		fmt.Fprintf(buf, "\t%q: %q,\n", k, descriptions[k])
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(destination, src, 0644) //nolint:gosec
+}
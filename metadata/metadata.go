@@ -0,0 +1,125 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/sacloud/iaas-service-go/services"
+)
+
+const modulePath = "github.com/sacloud/iaas-service-go"
+
+// Metadata 全サービスの操作とリクエストのJSON Schema
+//
+// CLIやTerraformプロバイダー、UIなどでのコード生成/入力補完に利用することを想定している
+type Metadata struct {
+	Services []*Service `json:"services"`
+}
+
+// Service リソース種別ごとのメタデータ
+type Service struct {
+	// Kind リソース種別 services.Registryで利用するものと同じ値
+	Kind string `json:"kind"`
+	// Package サービスを提供するパッケージのインポートパス
+	Package    string       `json:"package"`
+	Operations []*Operation `json:"operations"`
+}
+
+// Operation サービスが提供する操作
+type Operation struct {
+	// Name 操作名 例: create, list_parameter
+	Name string `json:"name"`
+	// Method サービスのメソッド名 例: CreateWithContext
+	Method string `json:"method"`
+	// Request リクエストのJSON Schema
+	Request *Schema `json:"request"`
+	// Result 戻り値の型名、戻り値を持たない操作の場合は空
+	Result string `json:"result,omitempty"`
+}
+
+// Load 全サービスのメタデータを返す
+func Load() (*Metadata, error) {
+	return FromRegistry(services.New(nil).Registry())
+}
+
+// FromRegistry Registryに登録されたサービスのメタデータを返す
+func FromRegistry(registry *services.Registry) (*Metadata, error) {
+	md := &Metadata{}
+	for _, kind := range registry.Kinds() {
+		svc, err := serviceMetadata(registry, kind)
+		if err != nil {
+			return nil, err
+		}
+		md.Services = append(md.Services, svc)
+	}
+	return md, nil
+}
+
+func serviceMetadata(registry *services.Registry, kind string) (*Service, error) {
+	v, err := registry.Service(kind)
+	if err != nil {
+		return nil, err
+	}
+	operations, err := registry.Operations(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := &Service{Kind: kind, Package: indirect(reflect.TypeOf(v)).PkgPath()}
+	for _, name := range operations {
+		method, err := registry.Method(kind, name)
+		if err != nil {
+			return nil, err
+		}
+		op := &Operation{
+			Name:    name,
+			Method:  method.Name,
+			Request: SchemaOf(reflect.New(method.Type.In(2).Elem()).Interface()),
+		}
+		if method.Type.NumOut() == 2 {
+			op.Result = method.Type.Out(0).String()
+		}
+		svc.Operations = append(svc.Operations, op)
+	}
+	return svc, nil
+}
+
+// Service リソース種別に対応するメタデータを返す
+func (m *Metadata) Service(kind string) (*Service, error) {
+	for _, s := range m.Services {
+		if s.Kind == kind {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown resource kind: %s", kind)
+}
+
+// Operation 操作名に対応するメタデータを返す
+func (s *Service) Operation(name string) (*Operation, error) {
+	for _, op := range s.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("operation %q is not supported by %s", name, s.Kind)
+}
+
+// JSON メタデータを整形済みのJSONとして返す
+func (m *Metadata) JSON() ([]byte, error) {
+	return json.MarshalIndent(m, "", "  ")
+}
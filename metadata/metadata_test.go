@@ -0,0 +1,101 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	md, err := Load()
+	require.NoError(t, err)
+	require.Len(t, md.Services, 46)
+
+	svc, err := md.Service("database")
+	require.NoError(t, err)
+	require.Equal(t, "github.com/sacloud/iaas-service-go/database", svc.Package)
+
+	op, err := svc.Operation("apply")
+	require.NoError(t, err)
+	require.Equal(t, "ApplyWithContext", op.Method)
+	require.Equal(t, "*iaas.Database", op.Result)
+
+	schema := op.Request
+	require.Equal(t, SchemaVersion, schema.Schema)
+	require.Equal(t, "object", schema.Type)
+	require.Contains(t, schema.Required, "Zone")
+	require.Contains(t, schema.Required, "PlanID")
+
+	require.Equal(t, []interface{}{"mariadb", "postgres"}, schema.Properties["DatabaseType"].Enum)
+	require.Equal(t, []interface{}{int64(0), int64(15), int64(30), int64(45)}, schema.Properties["BackupStartTimeMinute"].Enum)
+
+	ips := schema.Properties["IPAddresses"]
+	require.Equal(t, "array", ips.Type)
+	require.Equal(t, 1, *ips.MinItems)
+	require.Equal(t, 2, *ips.MaxItems)
+	require.Equal(t, "ipv4", ips.Items.Format)
+
+	op, err = svc.Operation("delete")
+	require.NoError(t, err)
+	require.Empty(t, op.Result)
+
+	_, err = svc.Operation("unknown")
+	require.Error(t, err)
+}
+
+func TestSchemaOf_Description(t *testing.T) {
+	md, err := Load()
+	require.NoError(t, err)
+
+	svc, err := md.Service("archive")
+	require.NoError(t, err)
+	op, err := svc.Operation("find")
+	require.NoError(t, err)
+
+	require.Equal(t, "OS種別、NamesやTagsを指定した場合はそちらが優先される", op.Request.Properties["OSType"].Description)
+}
+
+func TestMetadata_JSON(t *testing.T) {
+	md, err := Load()
+	require.NoError(t, err)
+
+	data, err := md.JSON()
+	require.NoError(t, err)
+
+	var decoded Metadata
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Len(t, decoded.Services, len(md.Services))
+}
+
+func TestDescriptions_metadata(t *testing.T) {
+	// zz_descriptions.goにmetadataパッケージ自身の型の説明も含まれていること
+	for _, key := range []string{
+		"metadata.Metadata",
+		"metadata.Operation",
+		"metadata.Operation.Method",
+		"metadata.Operation.Name",
+		"metadata.Operation.Request",
+		"metadata.Operation.Result",
+		"metadata.Schema",
+		"metadata.Service",
+		"metadata.Service.Kind",
+		"metadata.Service.Package",
+	} {
+		require.NotEmpty(t, descriptions[key], key)
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// SchemaVersion 出力するJSON Schemaのバージョン
+const SchemaVersion = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	idType       = reflect.TypeOf(types.ID(0))
+)
+
+// Schema JSON Schema
+//
+// リクエストの型とvalidateタグから組み立てられる。validateタグのうちJSON Schemaで表現できないもの(required_withなど)は含まれない。
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	UniqueItems          bool               `json:"uniqueItems,omitempty"`
+}
+
+// SchemaOf 値の型からJSON Schemaを組み立てる
+func SchemaOf(v interface{}) *Schema {
+	t := reflect.TypeOf(v)
+	schema := schemaOf(t, map[reflect.Type]bool{})
+	schema.Schema = SchemaVersion
+	schema.Title = indirect(t).Name()
+	if schema.Description == "" {
+		schema.Description = describe(indirect(t), "")
+	}
+	return schema
+}
+
+// JSON JSON Schemaを整形済みのJSONとして返す
+func (s *Schema) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// schemaOf visitingは再帰的な型の無限ループを防ぐために利用する
+func schemaOf(t reflect.Type, visiting map[reflect.Type]bool) *Schema {
+	t = indirect(t)
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case durationType:
+		return &Schema{Type: "integer", Description: "nanoseconds"}
+	case idType:
+		// types.IDはJSONの数値/文字列の双方を受け付けるが、Marshal時は数値となる
+		return &Schema{Type: "integer"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: schemaOf(t.Elem(), visiting)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaOf(t.Elem(), visiting)}
+	case reflect.Struct:
+		if visiting[t] {
+			return &Schema{Type: "object"}
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+		return structSchema(t, visiting)
+	}
+	// interfaceなど型が確定しないもの
+	return &Schema{}
+}
+
+func structSchema(t reflect.Type, visiting map[reflect.Type]bool) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, skip := jsonName(f)
+		if skip {
+			continue
+		}
+
+		if f.Anonymous && name == "" && indirect(f.Type).Kind() == reflect.Struct {
+			embedded := schemaOf(f.Type, visiting)
+			for k, v := range embedded.Properties {
+				schema.Properties[k] = v
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := schemaOf(f.Type, visiting)
+		if desc := describe(t, f.Name); desc != "" {
+			prop.Description = desc
+		}
+		if applyValidateTag(prop, f.Type, f.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = prop
+	}
+	return schema
+}
+
+func jsonName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	return strings.Split(tag, ",")[0], false
+}
+
+// applyValidateTag validateタグの内容をスキーマへ反映する、requiredが指定されていた場合はtrueを返す
+func applyValidateTag(schema *Schema, t reflect.Type, tag string) bool {
+	if tag == "" {
+		return false
+	}
+	required := false
+	target, targetType := schema, indirect(t)
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			if target == schema {
+				required = true
+			}
+		case "dive":
+			if target.Items == nil && target.AdditionalProperties == nil {
+				return required
+			}
+			if target.Items != nil {
+				target = target.Items
+			} else {
+				target = target.AdditionalProperties
+			}
+			targetType = indirect(targetType.Elem())
+		case "oneof":
+			for _, v := range strings.Fields(param) {
+				target.Enum = append(target.Enum, enumValue(target.Type, v))
+			}
+		case "min", "max", "len", "gte", "lte", "gt", "lt":
+			applyRange(target, name, param)
+		case "unique":
+			target.UniqueItems = true
+		case "ipv4", "ipv6", "email", "hostname":
+			target.Format = name
+		case "fqdn":
+			target.Format = "hostname"
+		case "url":
+			target.Format = "uri"
+		case "cidrv4", "cidrv6", "mac", "file":
+			// JSON Schemaに対応するformatが存在しないため独自の値とする
+			target.Format = name
+		}
+	}
+	return required
+}
+
+func enumValue(typ, v string) interface{} {
+	switch typ {
+	case "integer":
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	}
+	return v
+}
+
+func applyRange(schema *Schema, rule, param string) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	i := int(n)
+
+	switch schema.Type {
+	case "string":
+		switch rule {
+		case "min", "gte":
+			schema.MinLength = &i
+		case "max", "lte":
+			schema.MaxLength = &i
+		case "len":
+			schema.MinLength, schema.MaxLength = &i, &i
+		}
+	case "array":
+		switch rule {
+		case "min", "gte":
+			schema.MinItems = &i
+		case "max", "lte":
+			schema.MaxItems = &i
+		case "len":
+			schema.MinItems, schema.MaxItems = &i, &i
+		}
+	case "integer", "number":
+		switch rule {
+		case "min", "gte":
+			schema.Minimum = &n
+		case "max", "lte":
+			schema.Maximum = &n
+		case "gt":
+			schema.ExclusiveMinimum = &n
+		case "lt":
+			schema.ExclusiveMaximum = &n
+		case "len":
+			schema.Minimum, schema.Maximum = &n, &n
+		}
+	}
+}
+
+// describe 型またはフィールドの説明を返す、fieldが空の場合は型の説明を返す
+func describe(t reflect.Type, field string) string {
+	key := strings.TrimPrefix(strings.TrimPrefix(t.PkgPath(), modulePath), "/")
+	if key != "" {
+		key += "."
+	}
+	key += t.Name()
+	if field != "" {
+		key += "." + field
+	}
+	return descriptions[key]
+}
@@ -0,0 +1,481 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// generated by 'metadata/internal/gen/main.go'; DO NOT EDIT
+
+package metadata
+
+var descriptions = map[string]string{
+	"Error":                                  "各サービスが返すエラー 対象リソースや操作の情報を保持し、元のエラー(iaas-api-goのAPIErrorなど)をラップする。 分類はerrors.Is(err, ErrNotFound)のように判定する。",
+	"Error.Category":                         "エラーの分類、ErrNotFoundなど 未指定の場合でも元のエラーがAPIの404/409エラーやタイムアウトであればerrors.Isで判定できる",
+	"Error.Kind":                             "リソース種別 例: server",
+	"Error.Operation":                        "操作名 例: update",
+	"Error.Step":                             "操作内で失敗した処理 例: read",
+	"FieldChange":                            "フィールド単位の変更内容",
+	"FieldChange.Name":                       "フィールド名 例: NetworkInterfaces[0].SwitchID",
+	"FieldChange.Replace":                    "trueの場合このフィールドの反映には再作成が必要",
+	"FieldChange.UpdateLevel":                "このフィールドの反映に必要な更新レベル",
+	"FindResult":                             "FindIterator.Chanで返される検索結果",
+	"Invocation":                             "サービスの操作の呼び出し内容",
+	"Invocation.Kind":                        "リソース種別 例: server",
+	"Invocation.Operation":                   "操作名 例: update",
+	"Invocation.Request":                     "操作に渡されるリクエスト 例: *server.UpdateRequest Interceptorで同じ型の値に置き換えることでリクエストを書き換えられる。 リクエストを受け取らない操作の場合はnil",
+	"Options":                                "各サービスの生成時に指定するオプション",
+	"Plan":                                   "Apply時の変更内容",
+	"Plan.ReplaceReasons":                    "再作成が必要な理由",
+	"Plan.ShutdownReasons":                   "シャットダウンが必要な理由",
+	"Preflight":                              "Apply前に参照先のリソースやプランを確認し、検出した問題を収集する 確認は最初の問題で中断せずに全て行う。 ただし404以外のAPIエラーなど、リクエスト内容の問題ではないエラーが発生した場合は以降の確認を行わない。",
+	"PreflightError":                         "Preflightで検出された全ての問題",
+	"Problem":                                "Preflightで検出された問題",
+	"Problem.Field":                          "問題のあるフィールド 例: NetworkInterfaces[0].PacketFilterID",
+	"ZoneError":                              "ゾーンごとの処理で発生したエラー",
+	"Zoned":                                  "ゾーンを付与した検索結果",
+	"archive.DeleteRequest.FailIfReferenced": "trueの場合、他リソースから参照されている間は削除せずに参照元の一覧を含むエラーを返す",
+	"archive.FindInZonesRequest.OSType":      "OS種別、NamesやTagsを指定した場合はそちらが優先される",
+	"archive.FindInZonesRequest.Zones":       "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"archive.FindRequest.OSType":             "OS種別、NamesやTagsを指定した場合はそちらが優先される",
+	"archive.Service":                        "provides a high-level API of for Archive",
+	"archive/builder.APIClient":              "builderが利用するAPIクライアント",
+	"archive/builder.BlankArchiveBuilder":    "ブランクアーカイブの作成〜FTPSでのファイルアップロードを行う",
+	"archive/builder.Director":               "パラメータに応じて適切なアーカイブビルダーを返す",
+	"archive/builder.Director.NoWait":        "trueの場合アーカイブ作成完了まで待たずにreturnする。SourceReaderを指定する場合(BlankArchiveBuilder)にNoWaitをtrueにするとエラーとする",
+	"archive/builder.Director.SourceArchiveZone":            "transfer archive builder",
+	"archive/builder.Director.SourceDiskID":                 "for standard builder",
+	"archive/builder.Director.SourceReader":                 "for blank builder",
+	"archive/builder.Director.SourceSharedKey":              "for shared archive builder",
+	"archive/builder.FromSharedArchiveBuilder":              "共有アーカイブからアーカイブの作成を行う",
+	"archive/builder.StandardArchiveBuilder":                "同一アカウント/同一ゾーンのディスク/アーカイブからアーカイブの作成を行う",
+	"archive/builder.TransferArchiveBuilder":                "共有アーカイブからアーカイブの作成を行う",
+	"authstatus.Service":                                    "provides a high-level API of for AuthStatus",
+	"autobackup.FindInZonesRequest.Zones":                   "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"autobackup.Service":                                    "provides a high-level API of for AutoBackup",
+	"autoscale.Service":                                     "provides a high-level API of for AutoScale",
+	"bill.Service":                                          "provides a high-level API of for Bill",
+	"bridge.DeleteRequest.FailIfReferenced":                 "trueの場合、他リソースから参照されている間は削除せずに参照元の一覧を含むエラーを返す",
+	"bridge.DeleteRequest.WaitForRelease":                   "trueの場合、他リソースから参照されている間は削除を待ち合わせし続ける",
+	"bridge.DeleteRequest.WaitForReleaseTick":               "WaitForReleaseがtrueの場合の待ち処理のポーリング間隔(デフォルト:5秒)",
+	"bridge.DeleteRequest.WaitForReleaseTimeout":            "WaitForReleaseがtrueの場合の待ち時間タイムアウト(デフォルト:1時間)",
+	"bridge.DeleteRequest.Zones":                            "WaitForRelease/FailIfReferencedがtrueの場合に対象リソースを参照しているリソースを検索するゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"bridge.FindInZonesRequest.Zones":                       "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"bridge.Service":                                        "provides a high-level API of for Bridge",
+	"bulk.Options":                                          "一括実行のオプション",
+	"bulk.Options.Mode":                                     "エラー発生時の動作",
+	"bulk.Options.Parallelism":                              "全体での同時実行数、省略時はDefaultParallelism",
+	"bulk.Options.PerZoneParallelism":                       "ゾーンごとの同時実行数、0の場合はゾーンごとの制限を行わない リクエストのゾーンはZoneフィールドから判定する",
+	"bulk.Options.RetryCount":                               "失敗時のリトライ回数、0の場合はリトライしない",
+	"bulk.Options.RetryInterval":                            "リトライ間隔、省略時はDefaultRetryInterval",
+	"bulk.Options.Retryable":                                "リトライ対象のエラーであるかを判定する関数 省略時はバリデーションエラー/NotFound/シャットダウンが必要なエラー以外をリトライする",
+	"bulk.Result":                                           "リクエストごとの実行結果",
+	"bulk.Result.Attempts":                                  "試行回数",
+	"bulk.Result.Index":                                     "リクエストの添字",
+	"bulk.Result.Skipped":                                   "StopOnFirstErrorにより実行されなかった場合true",
+	"cassette.Cassette":                                     "記録されたAPI呼び出しのリスト",
+	"cassette.Error":                                        "記録されたエラー",
+	"cassette.Error.StatusCode":                             "APIエラーの場合のレスポンスコード、APIエラー以外の場合は0",
+	"cassette.Interaction":                                  "1回分のAPI呼び出し",
+	"cassette.Interaction.Error":                            "API呼び出しがエラーとなった場合のエラー",
+	"cassette.Interaction.Request":                          "リクエストボディ、GETの場合はクエリ文字列として送信されるJSON",
+	"cassette.Interaction.Response":                         "レスポンスボディ",
+	"cassette.Player":                                       "Cassetteに記録されたレスポンスを返すiaas.APICaller",
+	"cassette.Recorder":                                     "実際のAPI呼び出しをCassetteへ記録するiaas.APICaller 記録時にはScrubFieldsに該当する値を伏せる。呼び出し元には伏せる前のレスポンスを返す。",
+	"cdrom.DeleteRequest.FailIfReferenced":                  "trueの場合、他リソースから参照されている間は削除せずに参照元の一覧を含むエラーを返す",
+	"cdrom.DeleteRequest.WaitForRelease":                    "trueの場合、他リソースから参照されている間は削除を待ち合わせし続ける",
+	"cdrom.DeleteRequest.WaitForReleaseTick":                "WaitForReleaseがtrueの場合の待ち処理のポーリング間隔(デフォルト:5秒)",
+	"cdrom.DeleteRequest.WaitForReleaseTimeout":             "WaitForReleaseがtrueの場合の待ち時間タイムアウト(デフォルト:1時間)",
+	"cdrom.FindInZonesRequest.Zones":                        "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"cdrom.Service":                                         "provides a high-level API of for CDROM",
+	"certificateauthority.ApplyRequest.PollingInterval":     "証明書発行待ちのポーリング間隔",
+	"certificateauthority.ApplyRequest.PollingTimeout":      "証明書発行待ちのタイムアウト",
+	"certificateauthority.CreateRequest.PollingInterval":    "証明書発行待ちのポーリング間隔",
+	"certificateauthority.CreateRequest.PollingTimeout":     "証明書発行待ちのタイムアウト",
+	"certificateauthority.Service":                          "provides a high-level API of for CertificateAuthority",
+	"certificateauthority.UpdateRequest.PollingInterval":    "証明書発行待ちのポーリング間隔",
+	"certificateauthority.UpdateRequest.PollingTimeout":     "証明書発行待ちのタイムアウト",
+	"certificateauthority/builder.Builder":                  "マネージドPKI(CA)のビルダー",
+	"certificateauthority/builder.Builder.ID":               "新規登録時は空にする",
+	"certificateauthority/builder.Builder.PollingInterval":  "証明書発行待ちのポーリング間隔",
+	"certificateauthority/builder.Builder.PollingTimeout":   "証明書発行待ちのタイムアウト",
+	"certificateauthority/builder.CertificateAuthority":     "sacloud/CertificateAuthorityのラッパー CA/クライアント/サーバの詳細情報を保持する",
+	"certificateauthority/builder.ClientCert":               "クライアント証明書のリクエストパラメータ",
+	"certificateauthority/builder.ClientCert.Hold":          "一時停止する時はTrue",
+	"certificateauthority/builder.ClientCert.ID":            "新規登録時は空にする",
+	"certificateauthority/builder.ServerCert":               "サーバ証明書のリクエストパラメータ",
+	"certificateauthority/builder.ServerCert.Hold":          "一時停止する時はTrue",
+	"certificateauthority/builder.ServerCert.ID":            "新規作成時は空にする",
+	"checkpoint.Checkpoint":                                 "1回のビルドの進捗をStoreに記録する 同じStoreとKeyで再実行した場合、完了済みのステップを省略して再開できる。 nilの*Checkpointに対する操作は全て何もせず、Doneは常にfalseを、IDは常に空のIDを返す。",
+	"checkpoint.FileStore":                                  "ディレクトリ配下にキーごとのJSONファイルとして進捗を保存するStore",
+	"checkpoint.FileStore.Dir":                              "保存先ディレクトリ、存在しない場合は保存時に作成される",
+	"checkpoint.MemoryStore":                                "プロセス内のメモリに進捗を保持するStore",
+	"checkpoint.State":                                      "チェックポイントに記録される進捗",
+	"checkpoint.State.IDs":                                  "作成したリソースのID、キーはbuilderごとに定められたリソース名",
+	"checkpoint.State.Steps":                                "完了したステップ名",
+	"cleanup.Candidate":                                     "削除候補のリソース",
+	"cleanup.Candidate.Age":                                 "最終更新(停止中のサーバの場合は停止)からの経過時間、日時を持たないリソースの場合は0",
+	"cleanup.Candidate.ID":                                  "KindIPv6Addrの場合は空",
+	"cleanup.Candidate.Name":                                "リソース名、KindIPv6Addrの場合はIPv6アドレス",
+	"cleanup.Candidate.Reason":                              "削除候補とした理由",
+	"cleanup.Candidate.Zone":                                "グローバルリソースの場合は空",
+	"cleanup.Finder":                                        "削除候補のリソースを検出する",
+	"cleanup.Finder.AllowTags":                              "いずれかのタグを持つリソースは削除候補に含めない",
+	"cleanup.Finder.Kinds":                                  "対象とするリソース種別、デフォルトは全て",
+	"cleanup.Finder.MinAge":                                 "最終更新からの経過時間がこれより短いリソースは削除候補に含めない 構築中のリソースを削除候補としないために利用する。日時を持たないリソースには適用されない",
+	"cleanup.Finder.SSHKeyName":                             "名前がマッチするSSHキーを削除候補とする SSHキーは他のリソースから参照されないため利用状況を判定できない。 ディスクの修正時に生成したSSHキーの名前などを指定する。nilの場合SSHキーは削除候補に含めない",
+	"cleanup.Finder.StoppedServerAge":                       "停止してからの経過時間がこれ以上のサーバを削除候補とする、デフォルトはDefaultStoppedServerAge",
+	"cleanup.Finder.Zones":                                  "対象ゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"containerregistry.Service":                             "provides a high-level API of for ContainerRegistry",
+	"containerregistry/builder.Builder":                     "コンテナレジストリのビルダー",
+	"containerregistry/builder.User":                        "represents API parameter/response structure",
+	"cost.Estimate":                                         "見積もり",
+	"cost.Estimate.Current":                                 "更新対象の現在のリソースの見積もり、新規作成の場合はnil",
+	"cost.Estimate.Delta":                                   "現在のリソースとの差額、新規作成の場合はTotalと同じ",
+	"cost.Estimate.Total":                                   "料金を特定できた内訳の合計",
+	"cost.Estimate.Unpriced":                                "サービスクラスが見つからず料金を特定できなかった内訳",
+	"cost.Estimator":                                        "リクエストから見積もりを算出する 以下のリクエストに対応する。 - server: CreateRequest/ApplyRequest(ディスクを含む) - disk: CreateRequest/ApplyRequest - database: CreateRequest/ApplyRequest - vpcrouter: CreateRequest/CreateStandardRequest/ApplyRequest - loadbalancer: CreateRequest/ApplyRequest - nfs: CreateRequest/ApplyRequest - proxylb: CreateRequest IDが指定されたApplyRequestの場合は現在のリソースの見積もりと差額も算出する。",
+	"cost.Estimator.Aliases":                                "サービスクラスのパスの読み替え、キーはこのパッケージが組み立てたパス",
+	"cost.Item":                                             "見積もりの内訳",
+	"cost.Item.Name":                                        "内訳の名前 例: server, Disks[0]",
+	"cost.Item.Price":                                       "サービスクラスが見つからなかった場合はゼロ値",
+	"cost.Item.ServiceClass":                                "サービスクラスのパス、対応するサービスクラスを特定できない場合は空",
+	"cost.Price":                                            "料金(円)",
+	"coupon.Service":                                        "provides a high-level API of for Coupon",
+	"database.DeleteRequest.Force":                          "trueの場合は電源OFF(強制終了)してから削除",
+	"database.FindInZonesRequest.Zones":                     "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"database.Service":                                      "provides a high-level API of for Database",
+	"database/builder.APIClient":                            "builderが利用するAPIクライアント",
+	"database/builder.Builder":                              "データベースの構築を行う",
+	"database/builder.Builder.Hooks":                        "処理中の各箇所で呼び出すフック hook.BeforeUpdateSettings/hook.AfterConfig(Build/Update)、hook.BeforeBoot(Update)を呼び出す。 フックがエラーを返した場合はそれ以降の処理を中断する",
+	"database/builder.Builder.Parameters":                   "RDBMS固有のパラメータ設定 キーにはiaas.DatabaseParameterMetaのLabelを指定する - 例: effective_cache_size: 10",
+	"disk.CreateRequest":                                    "ディスク作成リクエスト",
+	"disk.DeleteRequest.FailIfReferenced":                   "trueの場合、他リソースから参照されている間は削除せずに参照元の一覧を含むエラーを返す",
+	"disk.DeleteRequest.WaitForRelease":                     "trueの場合、他リソースから参照されている間は削除を待ち合わせし続ける",
+	"disk.DeleteRequest.WaitForReleaseTick":                 "WaitForReleaseがtrueの場合の待ち処理のポーリング間隔(デフォルト:5秒)",
+	"disk.DeleteRequest.WaitForReleaseTimeout":              "WaitForReleaseがtrueの場合の待ち時間タイムアウト(デフォルト:1時間)",
+	"disk.EditParameter":                                    "ディスクの修正用パラメータ",
+	"disk.EditParameter.IsSSHKeysEphemeral":                 "trueの場合、SSHキーを生成する場合に生成したSSHキーリソースをサーバ作成後に削除する",
+	"disk.EditRequest.NoWait":                               "trueの場合ディスクの修正完了まで待たずに即時復帰する",
+	"disk.EditRequest.Notes":                                "スタートアップスクリプトをIDで指定(変数や埋め込むAPIキーを指定可能)",
+	"disk.FindInZonesRequest.Zones":                         "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"disk.Service":                                          "provides a high-level API of for Disk",
+	"disk/builder.APIClient":                                "builderが利用するAPIクライアント群",
+	"disk/builder.BlankBuilder":                             "ブランクディスクを作成する場合のリクエスト",
+	"disk/builder.BuildResult":                              "ディスク構築結果",
+	"disk/builder.ConnectedDiskBuilder":                     "既存ディスクを接続する場合のリクエスト",
+	"disk/builder.Director":                                 "パラメータに応じて適切なDiskBuilderを構築する",
+	"disk/builder.FromDiskOrArchiveBuilder":                 "ディスクorアーカイブからディスクを作成するリクエスト ディスクの修正が可能かは実行時にさくらのクラウドAPI側にて判定される",
+	"disk/builder.FromFixedArchiveBuilder":                  "ディスクの修正をサポートしないパブリックアーカイブからディスクを作成するリクエスト",
+	"disk/builder.FromUnixBuilder":                          "Unix系パブリックアーカイブからディスクを作成するリクエスト",
+	"disk/builder.UnixEditRequest":                          "Unix系の場合のディスクの修正リクエスト",
+	"disk/builder.UnixEditRequest.GenerateSSHKeyName":       "設定されていた場合、クラウドAPIを用いてキーペアを生成する。",
+	"disk/builder.UnixEditRequest.IsSSHKeysEphemeral":       "trueの場合、SSHキーを生成する場合に生成したSSHキーリソースをサーバ作成後に削除する",
+	"disk/builder.UpdateResult":                             "ディスク更新結果",
+	"diskplan.FindInZonesRequest.Zones":                     "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"diskplan.Service":                                      "provides a high-level API of for DiskPlan",
+	"dns.Service":                                           "provides a high-level API of for DNS",
+	"enhanceddb.Service":                                    "provides a high-level API of for EnhancedDB",
+	"enhanceddb/builder.Builder":                            "エンハンスドデータベースのビルダー",
+	"esme.Service":                                          "provides a high-level API of for ESME",
+	"gslb.Service":                                          "provides a high-level API of for GSLB",
+	"hook.Event":                                            "フックに渡される情報",
+	"hook.Event.Index":                                      "AfterDiskの場合は対象ディスクのインデックス、それ以外は0",
+	"hook.Event.Target":                                     "対象リソースの値 例えばサーバのAfterCreateでは*iaas.Server、AfterDiskでは*disk.BuildResultとなる。 値が得られない箇所ではnilとなる",
+	"hook.Hooks":                                            "呼び出し箇所ごとのフックの集合 nilの*Hooksに対するRunは何もしない",
+	"icon.Service":                                          "provides a high-level API of for Icon",
+	"iface.FindInZonesRequest.Zones":                        "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"iface.Service":                                         "provides a high-level API of for Interface",
+	"interceptor.AuditEntry":                                "監査ログの1エントリ",
+	"interceptor.AuditEntry.ID":                             "対象リソースのID、作成系の操作の場合は作成されたリソースのID",
+	"interceptor.JSONJournal":                               "監査ログを1行1エントリのJSONで出力するJournal",
+	"interceptor.Measurement":                               "操作1回分の計測値",
+	"interceptor.Measurement.Retries":                       "操作中に発生したリトライの回数、progress.EventRetryの数",
+	"interceptor.OperationStats":                            "操作ごとの集計値",
+	"interceptor.Stats":                                     "計測値を操作ごとにメモリ上で集計するRecorder",
+	"internet.CreateRequest.NotFoundRetry":                  "スイッチ+ルータは作成直後だと404を返すことがあることへの対応でリトライする際のリトライ上限回数、省略時はDefaultNotFoundRetry",
+	"internet.DeleteRequest.FailIfReferenced":               "trueの場合、他リソースから参照されている間は削除せずに参照元の一覧を含むエラーを返す",
+	"internet.DeleteRequest.Force":                          "trueの場合IPv6やサブネットも一緒に削除する(falseの場合これらがあるとDeleteでエラーとなる)",
+	"internet.FindInZonesRequest.Zones":                     "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"internet.Service":                                      "provides a high-level API of for Internet",
+	"internet/builder.APIClient":                            "builderが利用するAPIクライアント",
+	"internet/builder.Builder":                              "スイッチ+ルータの構築を行う",
+	"internetplan.FindInZonesRequest.Zones":                 "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"internetplan.Service":                                  "provides a high-level API of for InternetPlan",
+	"ipaddress.Service":                                     "provides a high-level API of for IPAddress",
+	"ipv6addr.FindInZonesRequest.Zones":                     "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"ipv6addr.Service":                                      "provides a high-level API of for IPv6Addr",
+	"ipv6net.FindInZonesRequest.Zones":                      "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"ipv6net.Service":                                       "provides a high-level API of for IPv6Net",
+	"license.Service":                                       "provides a high-level API of for License",
+	"licenseinfo.Service":                                   "provides a high-level API of for LicenseInfo",
+	"loadbalancer.ApplyRequest.ID":                          "for update",
+	"loadbalancer.ApplyRequest.SettingsHash":                "for update",
+	"loadbalancer.DeleteRequest.Force":                      "trueの場合は電源OFF(強制終了)してから削除",
+	"loadbalancer.FindInZonesRequest.Zones":                 "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"loadbalancer.Service":                                  "provides a high-level API of for LoadBalancer",
+	"loadbalancer/builder.Builder.RollbackOnFailure":        "trueの場合、作成後の起動待ちに失敗した際に作成したリソースを削除する",
+	"loadbalancer/builder.Builder.SettingsHash":             "for update",
+	"localrouter.Builder":                                   "ローカルルータの構築を行う",
+	"localrouter.Service":                                   "provides a high-level API of for LocalRouter",
+	"localrouter/builder.APIClient":                         "builderが利用するAPIクライアント",
+	"localrouter/builder.Builder":                           "ローカルルータの構築を行う",
+	"lock.Held":                                             "取得したロック",
+	"lock.Lease":                                            "ロックの保持者と有効期限",
+	"lock.LockedError":                                      "他の保持者のリースが有効な場合のエラー",
+	"lock.LockedError.Lease":                                "有効なリース、書き込み直後にロックを失った場合はnil",
+	"lock.Locker":                                           "リースの取得を行う",
+	"lock.Locker.Owner":                                     "ロックの保持者を識別する名前、\"/\"以外の任意の文字列 CIのジョブIDなど実行ごとに一意な値を指定する。同じOwnerのリースは再取得(延長)できる",
+	"lock.Locker.PollInterval":                              "Waitがtrueの場合のポーリング間隔、省略時はDefaultPollInterval",
+	"lock.Locker.SettleDelay":                               "リースの書き込みから上書きの確認までの待ち時間、省略時はDefaultSettleDelay 同時に取得を試みた他の保持者の書き込みがこの時間内に反映されれば検出できる",
+	"lock.Locker.TTL":                                       "リースの有効期間、省略時はDefaultTTL 解放されなかったリースは有効期限を過ぎると失効する。対象の操作に要する時間より長い値を指定する",
+	"lock.Locker.Wait":                                      "trueの場合、他の保持者のリースが有効な間は解放/失効を待つ falseの場合はErrConflictに分類された*LockedErrorを返す",
+	"lock.Locker.WaitTimeout":                               "Waitがtrueの場合の待ち時間のタイムアウト、0の場合はcontextが終了するまで待つ",
+	"metadata.Metadata":                                     "全サービスの操作とリクエストのJSON Schema CLIやTerraformプロバイダー、UIなどでのコード生成/入力補完に利用することを想定している",
+	"metadata.Operation":                                    "サービスが提供する操作",
+	"metadata.Operation.Method":                             "サービスのメソッド名 例: CreateWithContext",
+	"metadata.Operation.Name":                               "操作名 例: create, list_parameter",
+	"metadata.Operation.Request":                            "リクエストのJSON Schema",
+	"metadata.Operation.Result":                             "戻り値の型名、戻り値を持たない操作の場合は空",
+	"metadata.Schema":                                       "JSON Schema リクエストの型とvalidateタグから組み立てられる。validateタグのうちJSON Schemaで表現できないもの(required_withなど)は含まれない。",
+	"metadata.Service":                                      "リソース種別ごとのメタデータ",
+	"metadata.Service.Kind":                                 "リソース種別 services.Registryで利用するものと同じ値",
+	"metadata.Service.Package":                              "サービスを提供するパッケージのインポートパス",
+	"mobilegateway.DeleteRequest.Force":                     "trueの場合は電源OFF(強制終了)し、SIMルートやSIMの登録を削除してからDeleteする",
+	"mobilegateway.FindInZonesRequest.Zones":                "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"mobilegateway.PrivateInterfaceSetting":                 "represents API parameter/response structure",
+	"mobilegateway.PrivateInterfaceSettingUpdate":           "PrivateInterfaceSetting represents API parameter/response structure",
+	"mobilegateway.SIMRouteSetting":                         "represents API parameter/response structure",
+	"mobilegateway.SIMSetting":                              "represents API parameter/response structure",
+	"mobilegateway.Service":                                 "provides a high-level API of for MobileGateway",
+	"mobilegateway/builder.APIClient":                       "builderが利用するAPIクライアント",
+	"mobilegateway/builder.Builder":                         "モバイルゲートウェイの構築を行う",
+	"mobilegateway/builder.Builder.Checkpoint":              "作成時の進捗の記録先 指定した場合、作成したモバイルゲートウェイのIDと完了したステップ(スイッチ接続/各種設定/SIM登録/起動)を記録する。 同じチェックポイントで再度Buildを行うと、完了済みのステップを省略して再開する。",
+	"mobilegateway/builder.Builder.Hooks":                   "処理中の各箇所で呼び出すフック hook.BeforeUpdateSettings/hook.AfterConfig/hook.BeforeBoot(Build/Update)を呼び出す。 フックがエラーを返した場合はそれ以降の処理を中断する",
+	"mobilegateway/builder.PrivateInterfaceSetting":         "モバイルゲートウェイのプライベート側インターフェース設定",
+	"mobilegateway/builder.SIMRouteSetting":                 "SIMルート設定",
+	"mobilegateway/builder.SIMSetting":                      "モバイルゲートウェイに接続するSIM設定",
+	"nfs.ApplyRequest.ID":                                   "for update",
+	"nfs.ApplyRequest.Plan":                                 "types.NFSPlans.HDD or types.NFSPlans.SSD",
+	"nfs.ApplyRequest.Size":                                 "types.NFSPlans.HDD or types.NFSPlans.SSD",
+	"nfs.CreateRequest.Plan":                                "types.NFSPlans.HDD or types.NFSPlans.SSD",
+	"nfs.CreateRequest.Size":                                "types.NFSPlans.HDD or types.NFSPlans.SSD",
+	"nfs.DeleteRequest.Force":                               "trueの場合は電源OFF(強制終了)してから削除",
+	"nfs.FindInZonesRequest.Zones":                          "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"nfs.Service":                                           "provides a high-level API of for NFS",
+	"nfs/builder.Builder.RollbackOnFailure":                 "trueの場合、作成後の起動待ちに失敗した際に作成したリソースを削除する",
+	"note.Service":                                          "provides a high-level API of for Note",
+	"packetfilter.DeleteRequest.FailIfReferenced":           "trueの場合、他リソースから参照されている間は削除せずに参照元の一覧を含むエラーを返す",
+	"packetfilter.DeleteRequest.WaitForRelease":             "trueの場合、他リソースから参照されている間は削除を待ち合わせし続ける",
+	"packetfilter.DeleteRequest.WaitForReleaseTick":         "WaitForReleaseがtrueの場合の待ち処理のポーリング間隔(デフォルト:5秒)",
+	"packetfilter.DeleteRequest.WaitForReleaseTimeout":      "WaitForReleaseがtrueの場合の待ち時間タイムアウト(デフォルト:1時間)",
+	"packetfilter.FindInZonesRequest.Zones":                 "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"packetfilter.Service":                                  "provides a high-level API of for PacketFilter",
+	"privatehost.DeleteRequest.FailIfReferenced":            "trueの場合、他リソースから参照されている間は削除せずに参照元の一覧を含むエラーを返す",
+	"privatehost.DeleteRequest.WaitForRelease":              "trueの場合、他リソースから参照されている間は削除を待ち合わせし続ける",
+	"privatehost.DeleteRequest.WaitForReleaseTick":          "WaitForReleaseがtrueの場合の待ち処理のポーリング間隔(デフォルト:5秒)",
+	"privatehost.DeleteRequest.WaitForReleaseTimeout":       "WaitForReleaseがtrueの場合の待ち時間タイムアウト(デフォルト:1時間)",
+	"privatehost.FindInZonesRequest.Zones":                  "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"privatehost.Service":                                   "provides a high-level API of for PrivateHost",
+	"privatehostplan.FindInZonesRequest.Zones":              "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"privatehostplan.Service":                               "provides a high-level API of for PrivateHostPlan",
+	"progress.Event":                                        "進捗イベント",
+	"progress.Event.Attempt":                                "何回目の試行か、EventRetryの場合に設定される",
+	"progress.Event.Current":                                "現在の進捗、EventProgressの場合に設定される",
+	"progress.Event.ID":                                     "対象リソースのID、未確定の場合は空",
+	"progress.Event.MaxAttempts":                            "試行回数の上限",
+	"progress.Event.Message":                                "人が読むためのメッセージ",
+	"progress.Event.Resource":                               "対象リソースの種別 例: server, disk, archive",
+	"progress.Event.Scope":                                  "呼び出し元でWithScopeにより付与された処理の範囲 例: disk 2/3",
+	"progress.Event.Step":                                   "処理の段階 例: created, copying, uploading, boot",
+	"progress.Event.Total":                                  "全体量、不明な場合は0",
+	"progress.Event.Unit":                                   "Current/Totalの単位 例: MB, bytes",
+	"progress.Reader":                                       "読み込んだバイト数を進捗として通知するio.Reader",
+	"progress.Writer":                                       "書き込んだバイト数を進捗として通知するio.Writer",
+	"proxylb.Service":                                       "provides a high-level API of for ProxyLB",
+	"recommend.Recommendation":                              "提案されたゾーンごとのサーバプラン",
+	"recommend.Recommendation.Price":                        "料金を特定できない場合はnil",
+	"recommend.Recommendation.ServiceClass":                 "サービスクラスのパス",
+	"recommend.Recommender":                                 "要件を満たすサーバプランを提案する",
+	"recommend.Recommender.Aliases":                         "サービスクラスのパスの読み替え、cost.Estimator.Aliasesと同じ",
+	"recommend.Requirement":                                 "サーバプランに対する要件",
+	"recommend.Requirement.CPU":                             "最小コア数",
+	"recommend.Requirement.CPUModel":                        "指定した場合は一致するプランのみ対象とする",
+	"recommend.Requirement.Commitment":                      "指定した場合は一致するプランのみ対象とする、コア専有プランの場合はtypes.Commitments.DedicatedCPU",
+	"recommend.Requirement.GPU":                             "最小GPU数",
+	"recommend.Requirement.Generation":                      "指定した場合は一致するプランのみ対象とする",
+	"recommend.Requirement.MaxMonthlyPrice":                 "月額料金の上限、0の場合は上限なし 指定した場合は料金を特定できないプランは対象外となる",
+	"recommend.Requirement.MemoryGB":                        "最小メモリサイズ(GB)",
+	"recommend.Requirement.Zones":                           "対象ゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"recommend.Suggestion":                                  "既存サーバに対するより安価なプランの提案",
+	"recommend.Suggestion.Candidates":                       "現在のプラン以上のスペックでより安価なプラン、安価な順",
+	"recommend.Suggestion.Current":                          "現在のプラン、料金を特定できない場合はCurrent.Priceがnilとなり候補は提案されない",
+	"reference.Dependent":                                   "対象リソースを参照しているリソース",
+	"reference.Dependent.Blocking":                          "trueの場合この参照が存在する間は対象リソースを削除できない コピー元としての参照などはコピー完了後は削除を妨げないためfalseとなる",
+	"reference.Dependent.Relation":                          "参照の内容 例: nic[0] is connected",
+	"reference.Dependent.Zone":                              "グローバルリソースの場合は空",
+	"reference.DependentsError":                             "削除を妨げる参照元リソースが存在する場合のエラー",
+	"reference.Inspector":                                   "リソースの参照元を調べる",
+	"reference.Inspector.Zones":                             "SIMなどゾーンをまたいで参照されるリソースを調べる際の対象ゾーン 空の場合はiaas.SakuraCloudZonesが用いられる",
+	"region.Service":                                        "provides a high-level API of for Region",
+	"rollback.Error":                                        "ロールバックを行った場合に返されるエラー Unwrapで構築処理の元のエラーを返す",
+	"rollback.Result":                                       "ロールバックの結果",
+	"rollback.Result.Steps":                                 "実行順の取り消し処理の結果",
+	"rollback.StepResult":                                   "取り消し処理ごとの結果",
+	"rollback.StepResult.Err":                               "取り消しに失敗した場合のエラー、成功した場合はnil",
+	"rollback.Tracker":                                      "構築中に作成したリソースを記録し、失敗時に逆順で取り消す",
+	"secret.Generated":                                      "生成したシークレットの値",
+	"secret.Generated.Field":                                "フィールドのパス 例: RouterSetting.RemoteAccessUsers[0].Password",
+	"secret.Policy":                                         "生成するパスワードのポリシー",
+	"secret.Policy.Length":                                  "長さ",
+	"secret.Policy.MinDigits":                               "数字の最小文字数",
+	"secret.Policy.MinLower":                                "英小文字の最小文字数",
+	"secret.Policy.MinSymbols":                              "記号の最小文字数",
+	"secret.Policy.MinUpper":                                "英大文字の最小文字数",
+	"secret.Policy.Symbols":                                 "記号として利用する文字、空の場合は記号を含めない",
+	"secret.Resolver":                                       "リクエスト中のシークレットの参照を解決する",
+	"secret.Resolver.GenerateOmitted":                       "trueの場合、新規作成となるリクエストで空のパスワードフィールドに値を生成する IDが指定されたリクエスト(既存リソースに対するApplyなど)では現在の値を変更しないよう生成しない。 またリクエストがUnusedSecretsを実装している場合、送信されないフィールドには生成しない",
+	"secret.Resolver.OnGenerated":                           "Interceptorが値を生成した場合に呼び出される関数 生成した値を受け取れるのはこの関数のみとなる。途中まで作成されたリソースに設定されている可能性があるため、 操作が失敗した場合も呼び出される",
+	"secret.Resolver.Policy":                                "値を生成する際のポリシー、省略時はDefaultPolicy",
+	"secret.Resolver.Providers":                             "スキームごとのProvider",
+	"secret.Result":                                         "Resolveの結果",
+	"secret.Result.Generated":                               "生成した値",
+	"server.ApplyRequest.Lock":                              "指定した場合、既存サーバの更新前にタグを用いたロックを取得し、完了後に解放する",
+	"server.DeleteRequest.Force":                            "trueの場合は電源OFF(強制終了)してから削除",
+	"server.DeleteRequest.WithDisks":                        "ディスクを一緒に削除するか",
+	"server.FindInZonesRequest.Zones":                       "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"server.NetworkInterface.Upstream":                      "スイッチID or \"disconnected\"(切断) or \"shared\"(共有セグメント) 省略時は\"disconnected\"",
+	"server.Service":                                        "provides a high-level API of for Server",
+	"server/builder.APIClient":                              "builderが利用するAPIクライアント群",
+	"server/builder.BuildResult":                            "サーバ構築結果",
+	"server/builder.BuildResult.Rollback":                   "RollbackOnFailureがtrueでBuildが失敗した場合のロールバック結果",
+	"server/builder.Builder":                                "サーバ作成時のパラメータ",
+	"server/builder.Builder.Checkpoint":                     "Buildの進捗の記録先 指定した場合、作成したサーバ/ディスクのIDと完了したステップ(NIC設定/CD-ROM挿入/起動)を記録する。 同じチェックポイントで再度Buildを行うと、完了済みのステップを省略して再開する。",
+	"server/builder.Builder.Hooks":                          "処理中の各箇所で呼び出すフック hook.AfterCreate/hook.AfterDisk/hook.BeforeBoot(Build/Update)/hook.BeforeShutdown(Update)を呼び出す。 フックがエラーを返した場合はそれ以降の処理を中断する",
+	"server/builder.Builder.Observer":                       "進捗イベントの通知先 未指定の場合はcontextに設定されたObserverを利用する",
+	"server/builder.Builder.RollbackOnFailure":              "trueの場合、Buildが失敗した際にBuild中に作成したリソース(サーバ/ディスク/SSHキー/スタートアップスクリプト)を作成とは逆の順番で削除する ロールバックを行った場合、Buildは*rollback.Errorを返す",
+	"server/builder.ConnectedNICSetting":                    "サーバ作成時にスイッチに接続するためのパラメータ NICSettingHolderとAdditionalNICSettingHolderを実装し、Builder.NIC/Builder.AdditionalNICsに利用できる。",
+	"server/builder.DisconnectedNICSetting":                 "切断状態のNICを作成するためのパラメータ NICSettingHolderとAdditionalNICSettingHolderを実装し、Builder.NIC/Builder.AdditionalNICsに利用できる。",
+	"server/builder.SharedNICSetting":                       "サーバ作成時に共有セグメントに接続するためのパラメータ NICSettingHolderを実装し、Builder.NICに利用できる。",
+	"serverplan.FindInZonesRequest.Zones":                   "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"serverplan.Service":                                    "provides a high-level API of for ServerPlan",
+	"serviceclass.FindInZonesRequest.Zones":                 "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"serviceclass.Service":                                  "provides a high-level API of for ServiceClass",
+	"services.Registry":                                     "リソース種別をキーとしてサービスを保持する 各サービスのXxxWithContext(ctx, *XxxRequest)形式のメソッドを操作として登録し、 mapで表現されたリクエストから呼び出せるようにする。 操作名はメソッド名をスネークケースにしたもの 例: create, list_parameter",
+	"services.Services":                                     "全てのサービスをまとめて保持する 1つのiaas.APICallerから各パッケージのServiceを生成する",
+	"servicetest.Archive":                                   "disk.ArchiveFinderのfake実装 Findは検索条件によらずBackend.AddArchiveで登録したアーカイブを全て返す。操作名は\"Archive.<メソッド名>\"となる",
+	"servicetest.Backend":                                   "fake間で共有されるインメモリな状態 各fakeはBackendを介してリソースを参照/更新するため、例えばServerのfakeでの削除はDiskのfakeからも観測できる。 ゾーンは区別せずにIDのみでリソースを管理する。",
+	"servicetest.Disk":                                      "disk.CreateDiskHandler/server.DiskHandlerのfake実装 操作名は\"Disk.<メソッド名>\"となる",
+	"servicetest.DiskPlan":                                  "disk.PlanReaderのfake実装 任意のIDに対し一般的なサイズを持つプランを返す。操作名は\"DiskPlan.Read\"となる",
+	"servicetest.Fault":                                     "fakeの呼び出しに注入する障害",
+	"servicetest.Fault.Availability":                        "Read/Createの結果として返すリソースのAvailability 返却される値のみを上書きし、fakeが保持する状態は変更しない",
+	"servicetest.Fault.Calls":                               "障害を注入する呼び出し回数(1始まり) 未指定の場合は全ての呼び出しが対象となる",
+	"servicetest.Fault.Err":                                 "呼び出し結果として返すエラー",
+	"servicetest.Fault.Latency":                             "呼び出しの前に待機する時間",
+	"servicetest.Faults":                                    "操作ごとの呼び出し回数と注入する障害を管理する 操作は\"Server.Create\"や\"Disk.Read\"のように\"リソース名.メソッド名\"で指定する",
+	"servicetest.Interface":                                 "server.InterfaceHandlerのfake実装 操作名は\"Interface.<メソッド名>\"となる",
+	"servicetest.Note":                                      "disk.NoteHandlerのfake実装 操作名は\"Note.<メソッド名>\"となる",
+	"servicetest.PacketFilter":                              "server.PacketFilterReaderのfake実装 Backend.AddPacketFilterで登録したパケットフィルタを返す。操作名は\"PacketFilter.Read\"となる",
+	"servicetest.SSHKey":                                    "disk.SSHKeyHandlerのfake実装 Generateはダミーの鍵を返す。操作名は\"SSHKey.<メソッド名>\"となる",
+	"servicetest.Server":                                    "server.CreateServerHandlerのfake実装 操作名は\"Server.<メソッド名>\"となる",
+	"servicetest.ServerPlan":                                "query.ServerPlanFinderのfake実装 検索条件のCPU/MemoryMB/GPUを持つプランを常に1件返す。操作名は\"ServerPlan.Find\"となる",
+	"servicetest.Switch":                                    "server.SwitchReaderのfake実装 Backend.AddSwitchで登録したスイッチを返す。操作名は\"Switch.Read\"となる",
+	"servicetest.VPCRouter":                                 "iaas.VPCRouterAPIのfake実装 操作名は\"VPCRouter.<メソッド名>\"となる。 UpdateSettingsでSettingsHashが指定された場合、実際のAPIと同様に現在の値と異なれば409エラーとなる。",
+	"setup.ConstantBackoff":                                 "一定間隔で待機するBackoff",
+	"setup.ConstantBackoff.MaxElapsedTime":                  "最初の試行からこの時間を経過する場合はリトライしない、0の場合は無制限",
+	"setup.ExponentialBackoff":                              "リトライごとに待機時間を指数的に延ばすBackoff",
+	"setup.ExponentialBackoff.InitialInterval":              "初回リトライ前の待機時間",
+	"setup.ExponentialBackoff.Jitter":                       "待機時間をランダムに増減させる割合(0〜1) 例えば0.5の場合、待機時間は算出値の50%〜150%の範囲となる",
+	"setup.ExponentialBackoff.MaxElapsedTime":               "最初の試行からこの時間を経過する場合はリトライしない、0の場合は無制限",
+	"setup.ExponentialBackoff.MaxInterval":                  "待機時間の上限、0の場合は無制限",
+	"setup.ExponentialBackoff.Multiplier":                   "リトライごとに待機時間に掛ける倍率、0の場合はDefaultBackoffMultiplier",
+	"setup.Options":                                         "アプライアンス作成時に利用するsetup.RetryableSetupのパラメータ",
+	"setup.Options.Backoff":                                 "プロビジョニング/削除API呼び出しのリトライ時の待機時間を決定するストラテジー 未指定の場合はProvisioningRetryInterval/DeleteRetryIntervalの一定間隔で待機する",
+	"setup.Options.BootAfterBuild":                          "Buildの後に再起動を行うか",
+	"setup.Options.DeleteRetryCount":                        "削除リトライ回数",
+	"setup.Options.DeleteRetryInterval":                     "削除リトライ間隔",
+	"setup.Options.IsRetryable":                             "プロビジョニング/削除API呼び出しのエラーがリトライ可能か判定する 未指定の場合はDefaultErrorClassifierを利用する",
+	"setup.Options.NICUpdateWaitDuration":                   "NIC接続切断操作の後の待ち時間",
+	"setup.Options.Observer":                                "進捗イベントの通知先 未指定の場合はcontextに設定されたObserverを利用する",
+	"setup.Options.PollingInterval":                         "sacloud.StateWaiterによるステート待ちの間隔",
+	"setup.Options.ProvisioningRetryCount":                  "リトライ回数",
+	"setup.Options.RetryCount":                              "リトライ回数",
+	"setup.Options.RollbackOnFailure":                       "trueの場合、Setupが失敗した際に作成したリソースを削除する ロールバックを行った場合、Setupは*rollback.Errorを返す",
+	"setup.RetryableSetup":                                  "リソース作成時にコピー待ちや起動待ちが必要なリソースのビルダー。 リソースのビルドの際、必要に応じてリトライ(リソースの削除&再作成)を行う。",
+	"setup.RetryableSetup.Create":                           "リソース作成用関数",
+	"setup.RetryableSetup.Delete":                           "リソース削除用関数",
+	"setup.RetryableSetup.IsWaitForCopy":                    "コピー待ちを行うか",
+	"setup.RetryableSetup.IsWaitForUp":                      "起動待ちを行うか",
+	"setup.RetryableSetup.Options":                          ".",
+	"setup.RetryableSetup.ProvisionBeforeUp":                "リソース起動前のプロビジョニング関数",
+	"setup.RetryableSetup.Read":                             "リソース起動待ち関数",
+	"setup.RetryableSetup.Shutdown":                         "ロールバック時のリソース停止用関数、未指定の場合は停止せずに削除する",
+	"sim.ApplyRequest.PassCode":                             "Update時などは空になるためrequiredをはずしておく",
+	"sim.DeleteRequest.FailIfReferenced":                    "trueの場合、他リソースから参照されている間は削除せずに参照元の一覧を含むエラーを返す",
+	"sim.DeleteRequest.WaitForRelease":                      "trueの場合、他リソースから参照されている間は削除を待ち合わせし続ける",
+	"sim.DeleteRequest.WaitForReleaseTick":                  "WaitForReleaseがtrueの場合の待ち処理のポーリング間隔(デフォルト:5秒)",
+	"sim.DeleteRequest.WaitForReleaseTimeout":               "WaitForReleaseがtrueの場合の待ち時間タイムアウト(デフォルト:1時間)",
+	"sim.DeleteRequest.Zones":                               "WaitForRelease/FailIfReferencedがtrueの場合に対象リソースを参照しているリソースを検索するゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"sim.Service":                                           "provides a high-level API of for SIM",
+	"sim/builder.APIClient":                                 "builderが利用するAPIクライアント",
+	"sim/builder.Builder":                                   "SIMのセットアップを行う",
+	"simplemonitor.Service":                                 "provides a high-level API of for SimpleMonitor",
+	"sshkey.Service":                                        "provides a high-level API of for SSHKey",
+	"stack.Manifest":                                        "複数リソースをまとめて定義するマニフェスト YAMLまたはJSONで記述する。specの値には${type.name.attr}形式で他のリソースへの参照を記載できる。 zone: is1a resources: - type: switch name: web spec: name: web-switch - type: server name: web spec: name: web-server networkInterfaces: - upstream: ${switch.web.id}",
+	"stack.Manifest.Zone":                                   "ゾーン指定が必要なリソースでspecにzoneが含まれない場合に利用されるゾーン",
+	"stack.Resource":                                        "マニフェスト中の1リソースの定義",
+	"stack.Resource.DependsOn":                              "参照以外で明示的に依存するリソース 例: switch.web",
+	"stack.Resource.Name":                                   "マニフェスト内でリソースを識別するための論理名",
+	"stack.Resource.Spec":                                   "各パッケージのApplyRequest(またはCreate/UpdateRequest)に対応する値",
+	"stack.Resource.Type":                                   "リソース種別 例: switch, server",
+	"stack.ResourceState":                                   "作成済みリソースの情報",
+	"stack.Stack":                                           "マニフェストに記載された複数のリソースを依存関係の順にまとめて作成/更新する Stateに記録済みのリソースは各パッケージのApplyRequestを用いてその場で更新される。 マニフェストから削除されたリソースの削除は行わない。",
+	"stack.Stack.Parallelism":                               "同時にApplyするリソースの最大数、0以下の場合は制限しない",
+	"stack.Stack.StatePath":                                 "空でない場合、各リソースのApplyが完了するたびにStateを書き込む",
+	"stack.State":                                           "マニフェスト中の論理名と作成済みリソースのIDの対応を保持する",
+	"subnet.FindInZonesRequest.Zones":                       "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"subnet.Service":                                        "provides a high-level API of for Subnet",
+	"swytch.DeleteRequest.FailIfReferenced":                 "trueの場合、他リソースから参照されている間は削除せずに参照元の一覧を含むエラーを返す",
+	"swytch.DeleteRequest.WaitForRelease":                   "trueの場合、他リソースから参照されている間は削除を待ち合わせし続ける",
+	"swytch.DeleteRequest.WaitForReleaseTick":               "WaitForReleaseがtrueの場合の待ち処理のポーリング間隔(デフォルト:5秒)",
+	"swytch.DeleteRequest.WaitForReleaseTimeout":            "WaitForReleaseがtrueの場合の待ち時間タイムアウト(デフォルト:1時間)",
+	"swytch.FindInZonesRequest.Zones":                       "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"swytch.Service":                                        "provides a high-level API of for Switch",
+	"teardown.Resource":                                     "削除対象のリソース",
+	"teardown.Resource.Owner":                               "設定されている場合はOwnerの削除時に一緒に削除される",
+	"teardown.Resource.Phase":                               "Phasesにおける添字",
+	"teardown.Resource.Reason":                              "削除対象となった理由、Skippedの場合は削除しない理由",
+	"teardown.Resource.Skipped":                             "trueの場合はタグを持たないリソースから利用されているため削除しない",
+	"teardown.Teardown":                                     "Tagsを全て持つリソースを検索し、依存関係を考慮した順序で削除する パケットフィルタとブリッジはタグを持たないため、削除対象のリソースからのみ参照されているものを対象とする。 削除対象のサーバに接続されたディスクはサーバと一緒に削除される。 タグを持たないサーバやアプライアンスから利用されているスイッチ/ディスクは削除せず、Skippedとして返す。",
+	"teardown.Teardown.DryRun":                              "trueの場合は削除対象の一覧を返すのみで削除は行わない",
+	"teardown.Teardown.Parallelism":                         "フェーズ内での同時実行数、省略時はbulk.DefaultParallelism",
+	"teardown.Teardown.PerZoneParallelism":                  "ゾーンごとの同時実行数、0の場合はゾーンごとの制限を行わない",
+	"teardown.Teardown.Tags":                                "削除対象を選択するタグ、全てのタグを持つリソースが対象となる",
+	"teardown.Teardown.Zones":                               "対象ゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"vpcrouter.ApplyRequest":                                "Applyサービスへのパラメータ",
+	"vpcrouter.ApplyRequest.AdditionalNICSettings":          "AdditionalStandardNICSetting または AdditionalPremiumNICSetting を指定する",
+	"vpcrouter.ApplyRequest.Lock":                           "指定した場合、既存VPCルータの更新前にタグを用いたロックを取得し、完了後に解放する",
+	"vpcrouter.ApplyRequest.NICSetting":                     "StandardNICSetting または PremiumNICSetting を指定する",
+	"vpcrouter.DeleteRequest.Force":                         "trueの場合は電源OFF(強制終了)してから削除",
+	"vpcrouter.FindInZonesRequest.Zones":                    "検索対象のゾーンのリスト、デフォルトはiaas.SakuraCloudZones",
+	"vpcrouter.RouterSetting":                               "VPCルータの設定",
+	"vpcrouter.Service":                                     "provides a high-level API of for VPCRouter",
+	"vpcrouter.UpdateRequest.AdditionalNICSettings":         "Indexが同じものを手動でマージする",
+	"vpcrouter.UpdateStandardRequest.AdditionalNICSettings": "Indexが同じものを手動でマージする",
+	"vpcrouter/builder.AdditionalPremiumNICSetting":         "VPCルータのeth1-eth7の設定(プレミアム/ハイスペックプラン向け)",
+	"vpcrouter/builder.AdditionalStandardNICSetting":        "VPCルータのeth1-eth7の設定(スタンダードプラン向け)",
+	"vpcrouter/builder.Builder":                             "VPCルータの構築を行う",
+	"vpcrouter/builder.Builder.Checkpoint":                  "作成時の進捗の記録先 指定した場合、作成したVPCルータのIDと完了したステップ(スイッチ接続/設定投入/起動)を記録する。 同じチェックポイントで再度Buildを行うと、完了済みのステップを省略して再開する。",
+	"vpcrouter/builder.Builder.Hooks":                       "処理中の各箇所で呼び出すフック hook.BeforeUpdateSettings/hook.AfterConfig/hook.BeforeBoot(Build/Update)を呼び出す。 フックがエラーを返した場合はそれ以降の処理を中断する",
+	"vpcrouter/builder.PremiumNICSetting":                   "VPCルータのeth0をスイッチ+ルータに接続するためのSetting(プレミアム/ハイスペックプラン)",
+	"vpcrouter/builder.RouterSetting":                       "VPCルータの設定",
+	"vpcrouter/builder.StandardNICSetting":                  "VPCルータのeth0を共有セグメントに接続するためのSetting(スタンダードプラン)",
+	"zone.Service":                                          "provides a high-level API of for Zone",
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) AddSIMRoute(req *AddSIMRouteRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) AddSIMRoute(req *AddSIMRouteRequest) error {
 }
 
 func (s *Service) AddSIMRouteWithContext(ctx context.Context, req *AddSIMRouteRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "mobilegateway", "add_sim_route", req, s.addSIMRoute)
+}
+
+func (s *Service) addSIMRoute(ctx context.Context, req *AddSIMRouteRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewMobileGatewayOp(s.caller)
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) AddSIM(req *AddSIMRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) AddSIM(req *AddSIMRequest) error {
 }
 
 func (s *Service) AddSIMWithContext(ctx context.Context, req *AddSIMRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "mobilegateway", "add_sim", req, s.addSIM)
+}
+
+func (s *Service) addSIM(ctx context.Context, req *AddSIMRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	mgwOp := iaas.NewMobileGatewayOp(s.caller)
@@ -15,8 +15,12 @@
 package mobilegateway
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/mobilegateway/builder"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 	"github.com/sacloud/iaas-service-go/setup"
@@ -145,3 +149,33 @@ func (req *ApplyRequest) Builder(caller iaas.APICaller) (*builder.Builder, error
 		Client:                          builder.NewAPIClient(caller),
 	}, nil
 }
+
+// Preflight 参照先のリソースを確認し、検出した全ての問題を返す
+//
+// リソースの作成や更新は行わない
+func (req *ApplyRequest) Preflight(ctx context.Context, caller iaas.APICaller) error {
+	p := service.NewPreflight()
+
+	p.Ref("ID", "mobile gateway", req.Zone, req.ID, func() error {
+		_, err := iaas.NewMobileGatewayOp(caller).Read(ctx, req.Zone, req.ID)
+		return err
+	})
+	p.Ref("IconID", "icon", "", req.IconID, func() error {
+		_, err := iaas.NewIconOp(caller).Read(ctx, req.IconID)
+		return err
+	})
+	if req.PrivateInterface != nil {
+		p.Ref("PrivateInterface.SwitchID", "switch", req.Zone, req.PrivateInterface.SwitchID, func() error {
+			_, err := iaas.NewSwitchOp(caller).Read(ctx, req.Zone, req.PrivateInterface.SwitchID)
+			return err
+		})
+	}
+	for i, sim := range req.SIMs {
+		p.Ref(fmt.Sprintf("SIMs[%d].SIMID", i), "sim", "", sim.SIMID, func() error {
+			_, err := iaas.NewSIMOp(caller).Read(ctx, sim.SIMID)
+			return err
+		})
+	}
+
+	return p.Err()
+}
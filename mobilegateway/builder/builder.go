@@ -19,12 +19,15 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"time"
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/accessor"
 	"github.com/sacloud/iaas-api-go/helper/power"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/checkpoint"
+	"github.com/sacloud/iaas-service-go/hook"
+	"github.com/sacloud/iaas-service-go/rollback"
 	setup2 "github.com/sacloud/iaas-service-go/setup"
 )
 
@@ -51,6 +54,18 @@ type Builder struct {
 
 	SetupOptions *setup2.Options
 	Client       *APIClient
+
+	// Checkpoint 作成時の進捗の記録先
+	//
+	// 指定した場合、作成したモバイルゲートウェイのIDと完了したステップ(スイッチ接続/各種設定/SIM登録/起動)を記録する。
+	// 同じチェックポイントで再度Buildを行うと、完了済みのステップを省略して再開する。
+	Checkpoint *checkpoint.Checkpoint
+
+	// Hooks 処理中の各箇所で呼び出すフック
+	//
+	// hook.BeforeUpdateSettings/hook.AfterConfig/hook.BeforeBoot(Build/Update)を呼び出す。
+	// フックがエラーを返した場合はそれ以降の処理を中断する
+	Hooks *hook.Hooks
 }
 
 // PrivateInterfaceSetting モバイルゲートウェイのプライベート側インターフェース設定
@@ -196,108 +211,43 @@ func (b *Builder) create(ctx context.Context, zone string) (*iaas.MobileGateway,
 		return nil, err
 	}
 
+	if err := b.Checkpoint.Load(ctx); err != nil {
+		return nil, err
+	}
+
 	builder := &setup2.RetryableSetup{
 		Create: func(ctx context.Context, zone string) (accessor.ID, error) {
-			return b.Client.MobileGateway.Create(ctx, zone, &iaas.MobileGatewayCreateRequest{
-				Name:                            b.Name,
-				Description:                     b.Description,
-				Tags:                            b.Tags,
-				IconID:                          b.IconID,
-				InternetConnectionEnabled:       types.StringFlag(b.InternetConnectionEnabled),
-				InterDeviceCommunicationEnabled: types.StringFlag(b.InterDeviceCommunicationEnabled),
-			})
+			mgw, _, err := checkpoint.CreateOrResume(ctx, b.Checkpoint, "mobilegateway",
+				func(id types.ID) (*iaas.MobileGateway, error) {
+					return b.Client.MobileGateway.Read(ctx, zone, id)
+				},
+				func() (*iaas.MobileGateway, error) {
+					return b.Client.MobileGateway.Create(ctx, zone, &iaas.MobileGatewayCreateRequest{
+						Name:                            b.Name,
+						Description:                     b.Description,
+						Tags:                            b.Tags,
+						IconID:                          b.IconID,
+						InternetConnectionEnabled:       types.StringFlag(b.InternetConnectionEnabled),
+						InterDeviceCommunicationEnabled: types.StringFlag(b.InterDeviceCommunicationEnabled),
+					})
+				},
+			)
+			return mgw, err
 		},
 		ProvisionBeforeUp: func(ctx context.Context, zone string, id types.ID, target interface{}) error {
 			if b.NoWait {
 				return nil
 			}
-			mgw := target.(*iaas.MobileGateway)
-
-			// スイッチの接続
-			if b.PrivateInterface != nil {
-				if err := b.Client.MobileGateway.ConnectToSwitch(ctx, zone, id, b.PrivateInterface.SwitchID); err != nil {
-					return err
-				}
-			}
-
-			// [HACK] スイッチ接続直後だとエラーになることがあるため数秒待つ
-			time.Sleep(b.SetupOptions.NICUpdateWaitDuration)
-
-			// Interface設定
-			updated, err := b.Client.MobileGateway.UpdateSettings(ctx, zone, id, &iaas.MobileGatewayUpdateSettingsRequest{
-				InterfaceSettings:               b.getInterfaceSettings(),
-				InternetConnectionEnabled:       types.StringFlag(b.InternetConnectionEnabled),
-				InterDeviceCommunicationEnabled: types.StringFlag(b.InterDeviceCommunicationEnabled),
-				SettingsHash:                    mgw.SettingsHash,
-			})
-			if err != nil {
-				return err
-			}
-			// [HACK] インターフェースの設定をConfigで反映させておかないとエラーになることへの対応
-			// see: https://github.com/sacloud/libsacloud/issues/589
-			if err := b.Client.MobileGateway.Config(ctx, zone, id); err != nil {
-				return err
-			}
-			mgw = updated
-
-			// traffic config
-			if b.TrafficConfig != nil {
-				if err := b.Client.MobileGateway.SetTrafficConfig(ctx, zone, id, b.TrafficConfig); err != nil {
-					return err
-				}
-			}
-
-			// dns
-			if b.DNS != nil {
-				if err := b.Client.MobileGateway.SetDNS(ctx, zone, id, b.DNS); err != nil {
-					return err
-				}
-			}
-
-			// static route
-			if len(b.StaticRoutes) > 0 {
-				_, err := b.Client.MobileGateway.UpdateSettings(ctx, zone, id, &iaas.MobileGatewayUpdateSettingsRequest{
-					InterfaceSettings:               b.getInterfaceSettings(),
-					StaticRoutes:                    b.StaticRoutes,
-					InternetConnectionEnabled:       types.StringFlag(b.InternetConnectionEnabled),
-					InterDeviceCommunicationEnabled: types.StringFlag(b.InterDeviceCommunicationEnabled),
-					SettingsHash:                    mgw.SettingsHash,
-				})
-				if err != nil {
-					return err
-				}
-			}
-
-			// SIMs
-			if len(b.SIMs) > 0 {
-				for _, sim := range b.SIMs {
-					if err := b.Client.MobileGateway.AddSIM(ctx, zone, id, &iaas.MobileGatewayAddSIMRequest{SIMID: sim.SIMID.String()}); err != nil {
-						return err
-					}
-					if err := b.Client.SIM.AssignIP(ctx, sim.SIMID, &iaas.SIMAssignIPRequest{IP: sim.IPAddress}); err != nil {
-						return err
-					}
-				}
-			}
-
-			// SIM routes
-			if len(b.SIMRoutes) > 0 {
-				if err := b.Client.MobileGateway.SetSIMRoutes(ctx, zone, id, b.getSIMRouteSettings()); err != nil {
-					return err
-				}
-			}
-
-			if err := b.Client.MobileGateway.Config(ctx, zone, id); err != nil {
+			return b.provision(ctx, zone, id, target.(*iaas.MobileGateway))
+		},
+		Delete: func(ctx context.Context, zone string, id types.ID) error {
+			if err := b.Client.MobileGateway.Delete(ctx, zone, id); err != nil {
 				return err
 			}
-
-			if b.SetupOptions.BootAfterBuild {
-				return power.BootMobileGateway(ctx, b.Client.MobileGateway, zone, id)
-			}
-			return nil
+			return b.Checkpoint.Reset(ctx)
 		},
-		Delete: func(ctx context.Context, zone string, id types.ID) error {
-			return b.Client.MobileGateway.Delete(ctx, zone, id)
+		Shutdown: func(ctx context.Context, zone string, id types.ID) error {
+			return power.ShutdownMobileGateway(ctx, b.Client.MobileGateway, zone, id, true)
 		},
 		Read: func(ctx context.Context, zone string, id types.ID) (interface{}, error) {
 			return b.Client.MobileGateway.Read(ctx, zone, id)
@@ -313,7 +263,7 @@ func (b *Builder) create(ctx context.Context, zone string) (*iaas.MobileGateway,
 		mgw = result.(*iaas.MobileGateway)
 	}
 	if err != nil {
-		return mgw, err
+		return mgw, b.Checkpoint.ResetOnRollback(ctx, err)
 	}
 
 	// refresh
@@ -324,6 +274,143 @@ func (b *Builder) create(ctx context.Context, zone string) (*iaas.MobileGateway,
 	return refreshed, nil
 }
 
+// provision 作成したモバイルゲートウェイへのスイッチ接続/各種設定/SIM登録と起動を行う
+//
+// チェックポイントで完了済みのステップは省略する
+func (b *Builder) provision(ctx context.Context, zone string, id types.ID, mgw *iaas.MobileGateway) error {
+	// スイッチの接続
+	if b.PrivateInterface != nil {
+		err := b.Checkpoint.Run(ctx, "switch", func() error {
+			return b.Client.MobileGateway.ConnectToSwitch(ctx, zone, id, b.PrivateInterface.SwitchID)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err := b.Checkpoint.Run(ctx, "interface_settings", func() error {
+		// [HACK] スイッチ接続直後だとエラーになることがあるため数秒待つ
+		if err := setup2.Sleep(ctx, b.SetupOptions.NICUpdateWaitDuration); err != nil {
+			return err
+		}
+		if err := b.runHook(ctx, hook.BeforeUpdateSettings, zone, id, mgw); err != nil {
+			return err
+		}
+
+		// Interface設定
+		updated, err := b.Client.MobileGateway.UpdateSettings(ctx, zone, id, &iaas.MobileGatewayUpdateSettingsRequest{
+			InterfaceSettings:               b.getInterfaceSettings(),
+			InternetConnectionEnabled:       types.StringFlag(b.InternetConnectionEnabled),
+			InterDeviceCommunicationEnabled: types.StringFlag(b.InterDeviceCommunicationEnabled),
+			SettingsHash:                    mgw.SettingsHash,
+		})
+		if err != nil {
+			return err
+		}
+		// [HACK] インターフェースの設定をConfigで反映させておかないとエラーになることへの対応
+		// see: https://github.com/sacloud/libsacloud/issues/589
+		if err := b.Client.MobileGateway.Config(ctx, zone, id); err != nil {
+			return err
+		}
+		mgw = updated
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// traffic config
+	if b.TrafficConfig != nil {
+		err := b.Checkpoint.Run(ctx, "traffic_config", func() error {
+			return b.Client.MobileGateway.SetTrafficConfig(ctx, zone, id, b.TrafficConfig)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// dns
+	if b.DNS != nil {
+		err := b.Checkpoint.Run(ctx, "dns", func() error {
+			return b.Client.MobileGateway.SetDNS(ctx, zone, id, b.DNS)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// static route
+	if len(b.StaticRoutes) > 0 {
+		err := b.Checkpoint.Run(ctx, "static_routes", func() error {
+			_, err := b.Client.MobileGateway.UpdateSettings(ctx, zone, id, &iaas.MobileGatewayUpdateSettingsRequest{
+				InterfaceSettings:               b.getInterfaceSettings(),
+				StaticRoutes:                    b.StaticRoutes,
+				InternetConnectionEnabled:       types.StringFlag(b.InternetConnectionEnabled),
+				InterDeviceCommunicationEnabled: types.StringFlag(b.InterDeviceCommunicationEnabled),
+				SettingsHash:                    mgw.SettingsHash,
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// SIMs
+	for _, sim := range b.SIMs {
+		simID := sim.SIMID
+		// 登録とIPアドレスの割り当ては別々に記録し、割り当てに失敗した場合も再実行時に登録し直さないようにする
+		err := b.Checkpoint.Run(ctx, fmt.Sprintf("sim/%s/add", simID), func() error {
+			if err := b.Client.MobileGateway.AddSIM(ctx, zone, id, &iaas.MobileGatewayAddSIMRequest{SIMID: simID.String()}); err != nil {
+				return err
+			}
+			rollback.Track(ctx, "sim", simID, func(ctx context.Context) error {
+				return b.Client.MobileGateway.DeleteSIM(ctx, zone, id, simID)
+			})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		err = b.Checkpoint.Run(ctx, fmt.Sprintf("sim/%s/assign_ip", simID), func() error {
+			return b.Client.SIM.AssignIP(ctx, simID, &iaas.SIMAssignIPRequest{IP: sim.IPAddress})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// SIM routes
+	if len(b.SIMRoutes) > 0 {
+		err := b.Checkpoint.Run(ctx, "sim_routes", func() error {
+			return b.Client.MobileGateway.SetSIMRoutes(ctx, zone, id, b.getSIMRouteSettings())
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := b.Client.MobileGateway.Config(ctx, zone, id); err != nil {
+		return err
+	}
+	err = b.Checkpoint.Run(ctx, "hook/after_config", func() error {
+		return b.runHook(ctx, hook.AfterConfig, zone, id, mgw)
+	})
+	if err != nil {
+		return err
+	}
+
+	if b.SetupOptions.BootAfterBuild {
+		return b.Checkpoint.Run(ctx, "boot", func() error {
+			if err := b.runHook(ctx, hook.BeforeBoot, zone, id, mgw); err != nil {
+				return err
+			}
+			return power.BootMobileGateway(ctx, b.Client.MobileGateway, zone, id)
+		})
+	}
+	return nil
+}
+
 // Update モバイルゲートウェイの更新
 //
 // 更新中、SIMルートが一時的にクリアされます。また、接続先スイッチが変更されていた場合は再起動されます。
@@ -339,6 +426,14 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.M
 	if err != nil {
 		return nil, err
 	}
+	plan, err := b.plan(ctx, zone, mgw)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.HasChanges() {
+		return mgw, nil
+	}
+
 	mgw.SettingsHash = b.SettingsHash // 更新ルートが複数あるためここに設定しておく
 
 	isNeedShutdown, err := b.collectUpdateInfo(mgw)
@@ -349,7 +444,7 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.M
 	isNeedRestart := false
 	if mgw.InstanceStatus.IsUp() && isNeedShutdown {
 		if b.NoWait {
-			return nil, errors.New("NoWait option is not available due to the need to shut down")
+			return nil, service.NewError(service.ErrNeedsShutdown, errors.New("NoWait option is not available due to the need to shut down"))
 		}
 		isNeedRestart = true
 		if err := power.ShutdownMobileGateway(ctx, b.Client.MobileGateway, zone, id, false); err != nil {
@@ -357,6 +452,10 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.M
 		}
 	}
 
+	if err := b.runHook(ctx, hook.BeforeUpdateSettings, zone, id, mgw); err != nil {
+		return nil, err
+	}
+
 	// NICの切断/変更
 	if b.isPrivateInterfaceChanged(mgw) {
 		if len(mgw.Interfaces) > 1 && !mgw.Interfaces[1].SwitchID.IsEmpty() {
@@ -366,7 +465,9 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.M
 					return nil, err
 				}
 				// [HACK] スイッチ接続直後だとエラーになることがあるため数秒待つ
-				time.Sleep(b.SetupOptions.NICUpdateWaitDuration)
+				if err := setup2.Sleep(ctx, b.SetupOptions.NICUpdateWaitDuration); err != nil {
+					return nil, err
+				}
 
 				updated, err := b.Client.MobileGateway.UpdateSettings(ctx, zone, id, &iaas.MobileGatewayUpdateSettingsRequest{
 					InternetConnectionEnabled:       types.StringFlag(b.InternetConnectionEnabled),
@@ -394,7 +495,9 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.M
 				}
 
 				// [HACK] スイッチ接続直後だとエラーになることがあるため数秒待つ
-				time.Sleep(b.SetupOptions.NICUpdateWaitDuration)
+				if err := setup2.Sleep(ctx, b.SetupOptions.NICUpdateWaitDuration); err != nil {
+					return nil, err
+				}
 			}
 
 			// Interface設定
@@ -539,8 +642,14 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.M
 	if err := b.Client.MobileGateway.Config(ctx, zone, id); err != nil {
 		return nil, err
 	}
+	if err := b.runHook(ctx, hook.AfterConfig, zone, id, mgw); err != nil {
+		return nil, err
+	}
 
 	if isNeedRestart {
+		if err := b.runHook(ctx, hook.BeforeBoot, zone, id, mgw); err != nil {
+			return nil, err
+		}
 		if err := power.BootMobileGateway(ctx, b.Client.MobileGateway, zone, id); err != nil {
 			return nil, err
 		}
@@ -554,6 +663,16 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.M
 	return mgw, err
 }
 
+func (b *Builder) runHook(ctx context.Context, point hook.Point, zone string, id types.ID, mgw *iaas.MobileGateway) error {
+	return b.Hooks.Run(ctx, &hook.Event{
+		Point:    point,
+		Zone:     zone,
+		Resource: "mobilegateway",
+		ID:       id,
+		Target:   mgw,
+	})
+}
+
 func (b *Builder) getInterfaceSettings() []*iaas.MobileGatewayInterfaceSetting {
 	if b.PrivateInterface == nil {
 		return nil
@@ -15,13 +15,17 @@
 package builder
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/testutil"
 	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/checkpoint"
 	"github.com/sacloud/iaas-service-go/setup"
+	"github.com/stretchr/testify/require"
 )
 
 func getSetupOption() *setup.Options {
@@ -123,3 +127,78 @@ func TestMobileGatewayBuilder_Build(t *testing.T) {
 		},
 	})
 }
+
+type resumeTestSIMAPI struct {
+	iaas.SIMAPI
+	assignIPCalls int
+}
+
+func (s *resumeTestSIMAPI) AssignIP(ctx context.Context, id types.ID, param *iaas.SIMAssignIPRequest) error {
+	s.assignIPCalls++
+	if s.assignIPCalls == 1 {
+		return errors.New("dummy")
+	}
+	return s.SIMAPI.AssignIP(ctx, id, param)
+}
+
+type resumeTestMobileGatewayAPI struct {
+	iaas.MobileGatewayAPI
+	addSIMCalls int
+}
+
+func (m *resumeTestMobileGatewayAPI) AddSIM(ctx context.Context, zone string, id types.ID, param *iaas.MobileGatewayAddSIMRequest) error {
+	m.addSIMCalls++
+	if m.addSIMCalls > 1 {
+		// 登録済みのSIMを再度登録しようとした場合はAPIがエラーを返す
+		return errors.New("SIM is already registered")
+	}
+	return m.MobileGatewayAPI.AddSIM(ctx, zone, id, param)
+}
+
+func TestMobileGatewayBuilder_resume(t *testing.T) {
+	if testutil.IsAccTest() {
+		t.Skip("uses fake driver only")
+	}
+	ctx := context.Background()
+	caller := testutil.SingletonAPICaller()
+	zone := testutil.TestZone()
+
+	sim, err := iaas.NewSIMOp(caller).Create(ctx, &iaas.SIMCreateRequest{
+		Name:     testutil.ResourceName("mobile-gateway-builder"),
+		ICCID:    "aaaaaaaa",
+		PassCode: "bbbbbbbb",
+	})
+	require.NoError(t, err)
+
+	client := NewAPIClient(caller)
+	simAPI := &resumeTestSIMAPI{SIMAPI: client.SIM}
+	mgwAPI := &resumeTestMobileGatewayAPI{MobileGatewayAPI: client.MobileGateway}
+	client.SIM = simAPI
+	client.MobileGateway = mgwAPI
+	store := checkpoint.NewMemoryStore()
+
+	build := func() (*iaas.MobileGateway, error) {
+		builder := &Builder{
+			Zone:         zone,
+			Name:         testutil.ResourceName("mobile-gateway-builder"),
+			SIMs:         []*SIMSetting{{SIMID: sim.ID, IPAddress: "192.168.0.11"}},
+			SetupOptions: getSetupOption(),
+			Client:       client,
+			Checkpoint:   checkpoint.New(store, "mobilegateway"),
+		}
+		return builder.Build(ctx)
+	}
+
+	// IPアドレスの割り当てに失敗した場合、再実行時にSIMを登録し直さない
+	_, err = build()
+	require.Error(t, err)
+
+	mgw, err := build()
+	require.NoError(t, err)
+	require.Equal(t, 1, mgwAPI.addSIMCalls)
+	require.Equal(t, 2, simAPI.assignIPCalls)
+
+	sims, err := client.MobileGateway.ListSIM(ctx, zone, mgw.ID)
+	require.NoError(t, err)
+	require.Len(t, sims, 1)
+}
@@ -0,0 +1,110 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+// Plan Build時の変更内容を算出する
+//
+// IDが空の場合は新規作成を表すPlanを返す。APIによるリソースの変更は行わない。
+func (b *Builder) Plan(ctx context.Context) (*service.Plan, error) {
+	if b.ID.IsEmpty() {
+		return service.NewCreatePlan(map[string]interface{}{
+			"Name":                            b.Name,
+			"Description":                     b.Description,
+			"Tags":                            b.Tags,
+			"IconID":                          b.IconID,
+			"PrivateInterface":                b.PrivateInterface,
+			"StaticRoutes":                    b.StaticRoutes,
+			"SIMRoutes":                       b.SIMRoutes,
+			"InternetConnectionEnabled":       b.InternetConnectionEnabled,
+			"InterDeviceCommunicationEnabled": b.InterDeviceCommunicationEnabled,
+			"DNS":                             b.DNS,
+			"SIMs":                            b.SIMs,
+			"TrafficConfig":                   b.TrafficConfig,
+		}), nil
+	}
+
+	mgw, err := b.Client.MobileGateway.Read(ctx, b.Zone, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	return b.plan(ctx, b.Zone, mgw)
+}
+
+func (b *Builder) plan(ctx context.Context, zone string, mgw *iaas.MobileGateway) (*service.Plan, error) {
+	plan := service.NewPlan()
+
+	plan.Diff("Name", mgw.Name, b.Name)
+	plan.Diff("Description", mgw.Description, b.Description)
+	plan.Diff("Tags", mgw.Tags, b.Tags)
+	plan.Diff("IconID", mgw.IconID, b.IconID)
+	plan.Diff("InternetConnectionEnabled", mgw.InternetConnectionEnabled.Bool(), b.InternetConnectionEnabled)
+	plan.Diff("InterDeviceCommunicationEnabled", mgw.InterDeviceCommunicationEnabled.Bool(), b.InterDeviceCommunicationEnabled)
+	if len(b.StaticRoutes) > 0 {
+		plan.Diff("StaticRoutes", mgw.StaticRoutes, b.StaticRoutes)
+	}
+
+	// スイッチの変更/削除は再起動が必要
+	if b.isPrivateInterfaceChanged(mgw) {
+		plan.Add(&service.FieldChange{
+			Name:        "PrivateInterface",
+			Before:      b.currentPrivateInterfaceState(mgw),
+			After:       b.PrivateInterface,
+			UpdateLevel: service.UpdateLevelNeedShutdown,
+		})
+	}
+
+	trafficConfig, err := b.Client.MobileGateway.GetTrafficConfig(ctx, zone, mgw.ID)
+	if err != nil && !iaas.IsNotFoundError(err) {
+		return nil, err
+	}
+	plan.Diff("TrafficConfig", trafficConfig, b.TrafficConfig)
+
+	if b.DNS != nil {
+		dns, err := b.Client.MobileGateway.GetDNS(ctx, zone, mgw.ID)
+		if err != nil && !iaas.IsNotFoundError(err) {
+			return nil, err
+		}
+		plan.Diff("DNS", dns, b.DNS)
+	}
+
+	currentSIMs, err := b.currentConnectedSIMs(ctx, zone, mgw.ID)
+	if err != nil {
+		return nil, err
+	}
+	plan.Diff("SIMs", currentSIMs, b.SIMs)
+
+	currentSIMRoutes, err := b.currentSIMRoutes(ctx, zone, mgw.ID)
+	if err != nil {
+		return nil, err
+	}
+	var simRoutes []*SIMRouteSetting
+	for _, r := range currentSIMRoutes {
+		simRoutes = append(simRoutes, &SIMRouteSetting{
+			SIMID:  types.StringID(r.ResourceID),
+			Prefix: r.Prefix,
+		})
+	}
+	plan.Diff("SIMRoutes", simRoutes, b.SIMRoutes)
+
+	return plan, nil
+}
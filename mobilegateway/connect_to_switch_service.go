@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) ConnectToSwitch(req *ConnectToSwitchRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) ConnectToSwitch(req *ConnectToSwitchRequest) error {
 }
 
 func (s *Service) ConnectToSwitchWithContext(ctx context.Context, req *ConnectToSwitchRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "mobilegateway", "connect_to_switch", req, s.connectToSwitch)
+}
+
+func (s *Service) connectToSwitch(ctx context.Context, req *ConnectToSwitchRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewMobileGatewayOp(s.caller)
@@ -16,12 +16,12 @@ package mobilegateway
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/helper/cleanup"
 	"github.com/sacloud/iaas-api-go/helper/wait"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 )
 
@@ -30,8 +30,12 @@ func (s *Service) Delete(req *DeleteRequest) error {
 }
 
 func (s *Service) DeleteWithContext(ctx context.Context, req *DeleteRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "mobilegateway", "delete", req, s.delete)
+}
+
+func (s *Service) delete(ctx context.Context, req *DeleteRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewMobileGatewayOp(s.caller)
@@ -41,7 +45,7 @@ func (s *Service) DeleteWithContext(ctx context.Context, req *DeleteRequest) err
 	}
 
 	if !req.Force && target.InstanceStatus.IsUp() {
-		return fmt.Errorf("target %s:%q has not yet shut down", req.Zone, req.ID)
+		return service.Errorf(service.ErrNeedsShutdown, "target %s:%q has not yet shut down", req.Zone, req.ID)
 	}
 
 	if target.InstanceStatus.IsUp() {
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) DeleteSIMRoute(req *DeleteSIMRouteRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) DeleteSIMRoute(req *DeleteSIMRouteRequest) error {
 }
 
 func (s *Service) DeleteSIMRouteWithContext(ctx context.Context, req *DeleteSIMRouteRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "mobilegateway", "delete_sim_route", req, s.deleteSIMRoute)
+}
+
+func (s *Service) deleteSIMRoute(ctx context.Context, req *DeleteSIMRouteRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewMobileGatewayOp(s.caller)
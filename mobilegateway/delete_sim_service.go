@@ -16,9 +16,9 @@ package mobilegateway
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) DeleteSIM(req *DeleteSIMRequest) error {
@@ -26,8 +26,12 @@ func (s *Service) DeleteSIM(req *DeleteSIMRequest) error {
 }
 
 func (s *Service) DeleteSIMWithContext(ctx context.Context, req *DeleteSIMRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "mobilegateway", "delete_sim", req, s.deleteSIM)
+}
+
+func (s *Service) deleteSIM(ctx context.Context, req *DeleteSIMRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	mgwOp := iaas.NewMobileGatewayOp(s.caller)
@@ -38,7 +42,7 @@ func (s *Service) DeleteSIMWithContext(ctx context.Context, req *DeleteSIMReques
 		return err
 	}
 	if !sims.Exists(req.SIMID) {
-		return fmt.Errorf("SIM[%s] not found in MobileGatewaySIMs", req.SIMID.String())
+		return service.Errorf(service.ErrNotFound, "SIM[%s] not found in MobileGatewaySIMs", req.SIMID.String())
 	}
 
 	if err := simOp.ClearIP(ctx, req.SIMID); err != nil {
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) DeleteTrafficConfig(req *DeleteTrafficConfigRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) DeleteTrafficConfig(req *DeleteTrafficConfigRequest) error {
 }
 
 func (s *Service) DeleteTrafficConfigWithContext(ctx context.Context, req *DeleteTrafficConfigRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "mobilegateway", "delete_traffic_config", req, s.deleteTrafficConfig)
+}
+
+func (s *Service) deleteTrafficConfig(ctx context.Context, req *DeleteTrafficConfigRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewMobileGatewayOp(s.caller)
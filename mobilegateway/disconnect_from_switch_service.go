@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) DisconnectFromSwitch(req *DisconnectFromSwitchRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) DisconnectFromSwitch(req *DisconnectFromSwitchRequest) error {
 }
 
 func (s *Service) DisconnectFromSwitchWithContext(ctx context.Context, req *DisconnectFromSwitchRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "mobilegateway", "disconnect_from_switch", req, s.disconnectFromSwitch)
+}
+
+func (s *Service) disconnectFromSwitch(ctx context.Context, req *DisconnectFromSwitchRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewMobileGatewayOp(s.caller)
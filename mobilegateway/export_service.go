@@ -0,0 +1,110 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mobilegateway
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/serviceutil"
+)
+
+func (s *Service) Export(req *ExportRequest) (*ApplyRequest, error) {
+	return s.ExportWithContext(context.Background(), req)
+}
+
+func (s *Service) ExportWithContext(ctx context.Context, req *ExportRequest) (*ApplyRequest, error) {
+	return service.Invoke(ctx, s.interceptors, "mobilegateway", "export", req, s.export)
+}
+
+func (s *Service) export(ctx context.Context, req *ExportRequest) (*ApplyRequest, error) {
+	if err := req.Validate(); err != nil {
+		return nil, service.ValidationError(err)
+	}
+
+	client := iaas.NewMobileGatewayOp(s.caller)
+	current, err := client.Read(ctx, req.Zone, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := &ApplyRequest{
+		Zone:                            req.Zone,
+		ID:                              current.ID,
+		Name:                            current.Name,
+		Description:                     current.Description,
+		Tags:                            current.Tags,
+		IconID:                          current.IconID,
+		StaticRoutes:                    current.StaticRoutes,
+		InternetConnectionEnabled:       current.InternetConnectionEnabled.Bool(),
+		InterDeviceCommunicationEnabled: current.InterDeviceCommunicationEnabled.Bool(),
+		SettingsHash:                    current.SettingsHash,
+		BootAfterCreate:                 current.InstanceStatus.IsUp(),
+	}
+
+	for i, nic := range current.InterfaceSettings {
+		if nic.Index == 1 && i < len(current.Interfaces) && len(nic.IPAddress) > 0 {
+			exported.PrivateInterface = &PrivateInterfaceSetting{
+				SwitchID:       current.Interfaces[i].SwitchID,
+				IPAddress:      nic.IPAddress[0],
+				NetworkMaskLen: nic.NetworkMaskLen,
+			}
+		}
+	}
+
+	simRoutes, err := client.GetSIMRoutes(ctx, req.Zone, req.ID)
+	if err != nil && !iaas.IsNotFoundError(err) {
+		return nil, err
+	}
+	for _, r := range simRoutes {
+		exported.SIMRoutes = append(exported.SIMRoutes, &SIMRouteSetting{
+			SIMID:  types.StringID(r.ResourceID),
+			Prefix: r.Prefix,
+		})
+	}
+
+	dns, err := client.GetDNS(ctx, req.Zone, req.ID)
+	if err != nil && !iaas.IsNotFoundError(err) {
+		return nil, err
+	}
+	if dns != nil {
+		exported.DNS = &DNSSetting{DNS1: dns.DNS1, DNS2: dns.DNS2}
+	}
+
+	sims, err := client.ListSIM(ctx, req.Zone, req.ID)
+	if err != nil && !iaas.IsNotFoundError(err) {
+		return nil, err
+	}
+	for _, sim := range sims {
+		exported.SIMs = append(exported.SIMs, &SIMSetting{
+			SIMID:     types.StringID(sim.ResourceID),
+			IPAddress: sim.IP,
+		})
+	}
+
+	trafficConfig, err := client.GetTrafficConfig(ctx, req.Zone, req.ID)
+	if err != nil && !iaas.IsNotFoundError(err) {
+		return nil, err
+	}
+	if trafficConfig != nil {
+		exported.TrafficConfig = &TrafficConfig{}
+		if err := serviceutil.RequestConvertTo(trafficConfig, exported.TrafficConfig); err != nil {
+			return nil, err
+		}
+	}
+	return exported, nil
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) GetDNS(req *GetDNSRequest) (*iaas.MobileGatewayDNSSetting, error) {
@@ -25,8 +26,12 @@ func (s *Service) GetDNS(req *GetDNSRequest) (*iaas.MobileGatewayDNSSetting, err
 }
 
 func (s *Service) GetDNSWithContext(ctx context.Context, req *GetDNSRequest) (*iaas.MobileGatewayDNSSetting, error) {
+	return service.Invoke(ctx, s.interceptors, "mobilegateway", "get_dns", req, s.getDNS)
+}
+
+func (s *Service) getDNS(ctx context.Context, req *GetDNSRequest) (*iaas.MobileGatewayDNSSetting, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewMobileGatewayOp(s.caller)
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) GetTrafficConfig(req *GetTrafficConfigRequest) (*iaas.MobileGatewayTrafficControl, error) {
@@ -25,8 +26,12 @@ func (s *Service) GetTrafficConfig(req *GetTrafficConfigRequest) (*iaas.MobileGa
 }
 
 func (s *Service) GetTrafficConfigWithContext(ctx context.Context, req *GetTrafficConfigRequest) (*iaas.MobileGatewayTrafficControl, error) {
+	return service.Invoke(ctx, s.interceptors, "mobilegateway", "get_traffic_config", req, s.getTrafficConfig)
+}
+
+func (s *Service) getTrafficConfig(ctx context.Context, req *GetTrafficConfigRequest) (*iaas.MobileGatewayTrafficControl, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewMobileGatewayOp(s.caller)
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) ListSIMRoute(req *ListSIMRouteRequest) (iaas.MobileGatewaySIMRoutes, error) {
@@ -25,8 +26,12 @@ func (s *Service) ListSIMRoute(req *ListSIMRouteRequest) (iaas.MobileGatewaySIMR
 }
 
 func (s *Service) ListSIMRouteWithContext(ctx context.Context, req *ListSIMRouteRequest) (iaas.MobileGatewaySIMRoutes, error) {
+	return service.Invoke(ctx, s.interceptors, "mobilegateway", "list_sim_route", req, s.listSIMRoute)
+}
+
+func (s *Service) listSIMRoute(ctx context.Context, req *ListSIMRouteRequest) (iaas.MobileGatewaySIMRoutes, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewMobileGatewayOp(s.caller)
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) ListSIM(req *ListSIMRequest) (iaas.MobileGatewaySIMs, error) {
@@ -25,8 +26,12 @@ func (s *Service) ListSIM(req *ListSIMRequest) (iaas.MobileGatewaySIMs, error) {
 }
 
 func (s *Service) ListSIMWithContext(ctx context.Context, req *ListSIMRequest) (iaas.MobileGatewaySIMs, error) {
+	return service.Invoke(ctx, s.interceptors, "mobilegateway", "list_sim", req, s.listSIM)
+}
+
+func (s *Service) listSIM(ctx context.Context, req *ListSIMRequest) (iaas.MobileGatewaySIMs, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewMobileGatewayOp(s.caller)
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) SetDNS(req *SetDNSRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) SetDNS(req *SetDNSRequest) error {
 }
 
 func (s *Service) SetDNSWithContext(ctx context.Context, req *SetDNSRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "mobilegateway", "set_dns", req, s.setDNS)
+}
+
+func (s *Service) setDNS(ctx context.Context, req *SetDNSRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewMobileGatewayOp(s.caller)
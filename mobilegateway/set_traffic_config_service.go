@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) SetTrafficConfig(req *SetTrafficConfigRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) SetTrafficConfig(req *SetTrafficConfigRequest) error {
 }
 
 func (s *Service) SetTrafficConfigWithContext(ctx context.Context, req *SetTrafficConfigRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "mobilegateway", "set_traffic_config", req, s.setTrafficConfig)
+}
+
+func (s *Service) setTrafficConfig(ctx context.Context, req *SetTrafficConfigRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewMobileGatewayOp(s.caller)
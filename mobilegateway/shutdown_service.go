@@ -19,6 +19,7 @@ import (
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/helper/power"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Shutdown(req *ShutdownRequest) error {
@@ -26,8 +27,12 @@ func (s *Service) Shutdown(req *ShutdownRequest) error {
 }
 
 func (s *Service) ShutdownWithContext(ctx context.Context, req *ShutdownRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "mobilegateway", "shutdown", req, s.shutdown)
+}
+
+func (s *Service) shutdown(ctx context.Context, req *ShutdownRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewMobileGatewayOp(s.caller)
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) TrafficStatus(req *TrafficStatusRequest) (*iaas.MobileGatewayTrafficStatus, error) {
@@ -25,8 +26,12 @@ func (s *Service) TrafficStatus(req *TrafficStatusRequest) (*iaas.MobileGatewayT
 }
 
 func (s *Service) TrafficStatusWithContext(ctx context.Context, req *TrafficStatusRequest) (*iaas.MobileGatewayTrafficStatus, error) {
+	return service.Invoke(ctx, s.interceptors, "mobilegateway", "traffic_status", req, s.trafficStatus)
+}
+
+func (s *Service) trafficStatus(ctx context.Context, req *TrafficStatusRequest) (*iaas.MobileGatewayTrafficStatus, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewMobileGatewayOp(s.caller)
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) UpdateSIMRoute(req *UpdateSIMRouteRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) UpdateSIMRoute(req *UpdateSIMRouteRequest) error {
 }
 
 func (s *Service) UpdateSIMRouteWithContext(ctx context.Context, req *UpdateSIMRouteRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "mobilegateway", "update_sim_route", req, s.updateSIMRoute)
+}
+
+func (s *Service) updateSIMRoute(ctx context.Context, req *UpdateSIMRouteRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewMobileGatewayOp(s.caller)
@@ -16,9 +16,9 @@ package mobilegateway
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) UpdateSIM(req *UpdateSIMRequest) error {
@@ -26,8 +26,12 @@ func (s *Service) UpdateSIM(req *UpdateSIMRequest) error {
 }
 
 func (s *Service) UpdateSIMWithContext(ctx context.Context, req *UpdateSIMRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "mobilegateway", "update_sim", req, s.updateSIM)
+}
+
+func (s *Service) updateSIM(ctx context.Context, req *UpdateSIMRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	mgwOp := iaas.NewMobileGatewayOp(s.caller)
@@ -38,7 +42,7 @@ func (s *Service) UpdateSIMWithContext(ctx context.Context, req *UpdateSIMReques
 		return err
 	}
 	if !sims.Exists(req.SIMID) {
-		return fmt.Errorf("SIM[%s] not found in MobileGatewaySIMs", req.SIMID.String())
+		return service.Errorf(service.ErrNotFound, "SIM[%s] not found in MobileGatewaySIMs", req.SIMID.String())
 	}
 
 	return simOp.AssignIP(ctx, req.SIMID, &iaas.SIMAssignIPRequest{IP: req.IPAddress})
@@ -15,8 +15,13 @@
 package nfs
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/helper/query"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/nfs/builder"
 	"github.com/sacloud/packages-go/validate"
 )
@@ -61,3 +66,35 @@ func (req *ApplyRequest) Builder(caller iaas.APICaller) *builder.Builder {
 		NoWait:         req.NoWait,
 	}
 }
+
+// Preflight 参照先のリソースやプランを確認し、検出した全ての問題を返す
+//
+// リソースの作成や更新は行わない
+func (req *ApplyRequest) Preflight(ctx context.Context, caller iaas.APICaller) error {
+	p := service.NewPreflight()
+
+	p.Ref("ID", "nfs", req.Zone, req.ID, func() error {
+		_, err := iaas.NewNFSOp(caller).Read(ctx, req.Zone, req.ID)
+		return err
+	})
+	p.Ref("IconID", "icon", "", req.IconID, func() error {
+		_, err := iaas.NewIconOp(caller).Read(ctx, req.IconID)
+		return err
+	})
+	p.Ref("SwitchID", "switch", req.Zone, req.SwitchID, func() error {
+		_, err := iaas.NewSwitchOp(caller).Read(ctx, req.Zone, req.SwitchID)
+		return err
+	})
+	p.Check("Size", func() error {
+		planID, err := query.FindNFSPlanID(ctx, iaas.NewNoteOp(caller), req.Plan, req.Size)
+		if err != nil {
+			return err
+		}
+		if planID.IsEmpty() {
+			return fmt.Errorf("nfs plan is not available: plan:%s size:%d", req.Plan, req.Size)
+		}
+		return nil
+	})
+
+	return p.Err()
+}
@@ -20,9 +20,11 @@ import (
 	"reflect"
 
 	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/helper/power"
 	"github.com/sacloud/iaas-api-go/helper/query"
 	"github.com/sacloud/iaas-api-go/helper/wait"
 	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/rollback"
 )
 
 type Builder struct {
@@ -44,11 +46,22 @@ type Builder struct {
 
 	Caller iaas.APICaller
 	NoWait bool
+	// RollbackOnFailure trueの場合、作成後の起動待ちに失敗した際に作成したリソースを削除する
+	RollbackOnFailure bool
 }
 
 func (b *Builder) Build(ctx context.Context) (*iaas.NFS, error) {
 	if b.ID.IsEmpty() {
-		return b.create(ctx)
+		if !b.RollbackOnFailure {
+			return b.create(ctx)
+		}
+		var created *iaas.NFS
+		err := rollback.Run(ctx, func(ctx context.Context) error {
+			var err error
+			created, err = b.create(ctx)
+			return err
+		})
+		return created, err
 	}
 	return b.update(ctx)
 }
@@ -77,6 +90,12 @@ func (b *Builder) create(ctx context.Context) (*iaas.NFS, error) {
 	if err != nil {
 		return nil, err
 	}
+	rollback.Track(ctx, "nfs", created.ID, func(ctx context.Context) error {
+		if err := power.ShutdownNFS(ctx, client, b.Zone, created.ID, true); err != nil {
+			return err
+		}
+		return client.Delete(ctx, b.Zone, created.ID)
+	})
 	if b.NoWait {
 		return created, nil
 	}
@@ -116,6 +135,13 @@ func (b *Builder) update(ctx context.Context) (*iaas.NFS, error) {
 	if err := b.validateForUpdate(ctx, current); err != nil {
 		return nil, err
 	}
+	plan, err := b.plan(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.HasChanges() {
+		return current, nil
+	}
 
 	return client.Update(ctx, b.Zone, b.ID, &iaas.NFSUpdateRequest{
 		Name:        b.Name,
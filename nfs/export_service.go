@@ -0,0 +1,60 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nfs
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/helper/query"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+func (s *Service) Export(req *ExportRequest) (*ApplyRequest, error) {
+	return s.ExportWithContext(context.Background(), req)
+}
+
+func (s *Service) ExportWithContext(ctx context.Context, req *ExportRequest) (*ApplyRequest, error) {
+	return service.Invoke(ctx, s.interceptors, "nfs", "export", req, s.export)
+}
+
+func (s *Service) export(ctx context.Context, req *ExportRequest) (*ApplyRequest, error) {
+	if err := req.Validate(); err != nil {
+		return nil, service.ValidationError(err)
+	}
+
+	current, err := iaas.NewNFSOp(s.caller).Read(ctx, req.Zone, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	planInfo, err := query.GetNFSPlanInfo(ctx, iaas.NewNoteOp(s.caller), current.PlanID)
+	if err != nil {
+		return nil, err
+	}
+	return &ApplyRequest{
+		ID:             current.ID,
+		Zone:           req.Zone,
+		Name:           current.Name,
+		Description:    current.Description,
+		Tags:           current.Tags,
+		IconID:         current.IconID,
+		SwitchID:       current.SwitchID,
+		Plan:           planInfo.DiskPlanID,
+		Size:           planInfo.Size,
+		IPAddresses:    current.IPAddresses,
+		NetworkMaskLen: current.NetworkMaskLen,
+		DefaultRoute:   current.DefaultRoute,
+	}, nil
+}
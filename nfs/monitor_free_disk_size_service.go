@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 )
 
@@ -26,8 +27,12 @@ func (s *Service) MonitorFreeDiskSize(req *MonitorFreeDiskSizeRequest) ([]*iaas.
 }
 
 func (s *Service) MonitorFreeDiskSizeWithContext(ctx context.Context, req *MonitorFreeDiskSizeRequest) ([]*iaas.MonitorFreeDiskSizeValue, error) {
+	return service.Invoke(ctx, s.interceptors, "nfs", "monitor_free_disk_size", req, s.monitorFreeDiskSize)
+}
+
+func (s *Service) monitorFreeDiskSize(ctx context.Context, req *MonitorFreeDiskSizeRequest) ([]*iaas.MonitorFreeDiskSizeValue, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewNFSOp(s.caller)
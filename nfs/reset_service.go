@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Reset(req *ResetRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) Reset(req *ResetRequest) error {
 }
 
 func (s *Service) ResetWithContext(ctx context.Context, req *ResetRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "nfs", "reset", req, s.reset)
+}
+
+func (s *Service) reset(ctx context.Context, req *ResetRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewNFSOp(s.caller)
@@ -0,0 +1,114 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iaas
+
+import "context"
+
+// DefaultPageSize FindIter/FindAllで1回のAPI呼び出しで取得する件数のデフォルト値
+const DefaultPageSize = 100
+
+// FindIterator 検索結果を1件ずつ返すイテレータ
+//
+// Go1.23以降のiter.Seq2[T, error]と同じシグネチャのため、rangeで利用できる。
+// 検索中にエラーが発生した場合はゼロ値とエラーを渡して終了する。
+//
+//	for server, err := range svc.FindIter(req) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+type FindIterator[T any] func(yield func(T, error) bool)
+
+// FindPageFunc fromとcountを受け取り1ページ分の検索結果を返す
+type FindPageFunc[T any] func(ctx context.Context, from, count int) ([]T, error)
+
+// FindResult FindIterator.Chanで返される検索結果
+type FindResult[T any] struct {
+	Value T
+	Err   error
+}
+
+// NewFindIterator 検索結果を1ページずつ取得するFindIteratorを返す
+//
+// fromは開始位置、pageSizeは1ページあたりの件数(0以下の場合はDefaultPageSize)。
+// ページの件数がpageSizeに満たなかった時点で終了する。
+// ページの取得前にctxを確認し、キャンセルされていた場合はctx.Err()を返して終了する。
+func NewFindIterator[T any](ctx context.Context, from, pageSize int, find FindPageFunc[T]) FindIterator[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return func(yield func(T, error) bool) {
+		var zero T
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			values, err := find(ctx, from, pageSize)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, v := range values {
+				if !yield(v, nil) {
+					return
+				}
+			}
+			if len(values) < pageSize {
+				return
+			}
+			from += len(values)
+		}
+	}
+}
+
+// All 全ページの検索結果をまとめて返す
+func (it FindIterator[T]) All() ([]T, error) {
+	var results []T
+	var err error
+	it(func(v T, e error) bool {
+		if e != nil {
+			err = e
+			return false
+		}
+		results = append(results, v)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Chan 検索結果を受け取るチャネルを返す
+//
+// 全件を送信するかエラーを送信した時点でチャネルはcloseされる。
+// 途中で受信をやめる場合はctxをキャンセルすること。
+func (it FindIterator[T]) Chan(ctx context.Context) <-chan FindResult[T] {
+	ch := make(chan FindResult[T])
+	go func() {
+		defer close(ch)
+		it(func(v T, err error) bool {
+			select {
+			case ch <- FindResult[T]{Value: v, Err: err}:
+				return err == nil
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+	return ch
+}
@@ -0,0 +1,123 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iaas
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sliceFindPageFunc(values []int, calls *int) FindPageFunc[int] {
+	return func(ctx context.Context, from, count int) ([]int, error) {
+		*calls++
+		if from >= len(values) {
+			return nil, nil
+		}
+		to := from + count
+		if to > len(values) {
+			to = len(values)
+		}
+		return values[from:to], nil
+	}
+}
+
+func TestFindIterator_All(t *testing.T) {
+	values := []int{1, 2, 3, 4, 5, 6, 7}
+
+	cases := []struct {
+		name     string
+		from     int
+		pageSize int
+		expect   []int
+		calls    int
+	}{
+		{name: "default page size", pageSize: 0, expect: values, calls: 1},
+		{name: "multiple pages", pageSize: 3, expect: values, calls: 3},
+		{name: "exact multiple", pageSize: 7, expect: values, calls: 2},
+		{name: "with offset", from: 2, pageSize: 2, expect: []int{3, 4, 5, 6, 7}, calls: 3},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			got, err := NewFindIterator(context.Background(), tc.from, tc.pageSize, sliceFindPageFunc(values, &calls)).All()
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, got)
+			require.Equal(t, tc.calls, calls)
+		})
+	}
+}
+
+func TestFindIterator_Break(t *testing.T) {
+	calls := 0
+	it := NewFindIterator(context.Background(), 0, 2, sliceFindPageFunc([]int{1, 2, 3, 4, 5}, &calls))
+
+	var got []int
+	it(func(v int, err error) bool {
+		require.NoError(t, err)
+		got = append(got, v)
+		return len(got) < 3
+	})
+	require.Equal(t, []int{1, 2, 3}, got)
+	require.Equal(t, 2, calls)
+}
+
+func TestFindIterator_Error(t *testing.T) {
+	expectedErr := errors.New("dummy")
+	it := NewFindIterator(context.Background(), 0, 2, func(ctx context.Context, from, count int) ([]int, error) {
+		if from > 0 {
+			return nil, expectedErr
+		}
+		return []int{1, 2}, nil
+	})
+
+	_, err := it.All()
+	require.ErrorIs(t, err, expectedErr)
+}
+
+func TestFindIterator_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	it := NewFindIterator(ctx, 0, 1, sliceFindPageFunc([]int{1, 2, 3}, &calls))
+
+	var got []int
+	var err error
+	it(func(v int, e error) bool {
+		if e != nil {
+			err = e
+			return false
+		}
+		got = append(got, v)
+		cancel()
+		return true
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, []int{1}, got)
+	require.Equal(t, 1, calls)
+}
+
+func TestFindIterator_Chan(t *testing.T) {
+	calls := 0
+	it := NewFindIterator(context.Background(), 0, 2, sliceFindPageFunc([]int{1, 2, 3}, &calls))
+
+	var got []int
+	for res := range it.Chan(context.Background()) {
+		require.NoError(t, res.Err)
+		got = append(got, res.Value)
+	}
+	require.Equal(t, []int{1, 2, 3}, got)
+}
@@ -0,0 +1,202 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iaas
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// PlanAction Apply時に行われる操作の種別
+type PlanAction string
+
+const (
+	// PlanActionNone 変更なし
+	PlanActionNone = PlanAction("none")
+	// PlanActionCreate 新規作成
+	PlanActionCreate = PlanAction("create")
+	// PlanActionUpdate 既存リソースの更新
+	PlanActionUpdate = PlanAction("update")
+	// PlanActionReplace 再作成が必要な変更を含む
+	//
+	// Applyではこれらの変更を反映できないため、リソースの再作成が必要
+	PlanActionReplace = PlanAction("replace")
+)
+
+// FieldChange フィールド単位の変更内容
+type FieldChange struct {
+	// Name フィールド名 例: NetworkInterfaces[0].SwitchID
+	Name   string
+	Before interface{}
+	After  interface{}
+	// UpdateLevel このフィールドの反映に必要な更新レベル
+	UpdateLevel UpdateLevel
+	// Replace trueの場合このフィールドの反映には再作成が必要
+	Replace bool
+}
+
+// String fmt.Stringerの実装
+func (c *FieldChange) String() string {
+	return fmt.Sprintf("%s: %v => %v", c.Name, c.Before, c.After)
+}
+
+// Plan Apply時の変更内容
+type Plan struct {
+	Action      PlanAction
+	UpdateLevel UpdateLevel
+	Changes     []*FieldChange
+
+	// ShutdownReasons シャットダウンが必要な理由
+	ShutdownReasons []string
+	// ReplaceReasons 再作成が必要な理由
+	ReplaceReasons []string
+}
+
+// NewPlan 変更なしの状態のPlanを返す
+func NewPlan() *Plan {
+	return &Plan{Action: PlanActionNone, UpdateLevel: UpdateLevelNone}
+}
+
+// NewCreatePlan 新規作成を表すPlanを返す
+//
+// fieldsにはフィールド名と作成時の値を指定する。ゼロ値のフィールドは変更内容に含まれない。
+func NewCreatePlan(fields map[string]interface{}) *Plan {
+	plan := &Plan{Action: PlanActionCreate, UpdateLevel: UpdateLevelNone}
+
+	var keys []string
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := fields[k]
+		if isZero(v) {
+			continue
+		}
+		plan.Changes = append(plan.Changes, &FieldChange{Name: k, After: v})
+	}
+	return plan
+}
+
+// HasChanges 変更が含まれるか
+func (p *Plan) HasChanges() bool {
+	return p.Action != PlanActionNone
+}
+
+// IsNeedShutdown シャットダウンが必要な変更を含むか
+func (p *Plan) IsNeedShutdown() bool {
+	return p.UpdateLevel == UpdateLevelNeedShutdown
+}
+
+// Diff beforeとafterが異なる場合に再起動不要な変更として記録する
+func (p *Plan) Diff(name string, before, after interface{}) {
+	p.DiffWithLevel(name, before, after, UpdateLevelSimple)
+}
+
+// DiffNeedShutdown beforeとafterが異なる場合にシャットダウンが必要な変更として記録する
+func (p *Plan) DiffNeedShutdown(name string, before, after interface{}) {
+	p.DiffWithLevel(name, before, after, UpdateLevelNeedShutdown)
+}
+
+// DiffWithLevel beforeとafterが異なる場合に指定の更新レベルの変更として記録する
+func (p *Plan) DiffWithLevel(name string, before, after interface{}, level UpdateLevel) {
+	if Equal(before, after) {
+		return
+	}
+	p.Add(&FieldChange{Name: name, Before: before, After: after, UpdateLevel: level})
+}
+
+// DiffReplace beforeとafterが異なる場合に再作成が必要な変更として記録する
+func (p *Plan) DiffReplace(name string, before, after interface{}) {
+	if Equal(before, after) {
+		return
+	}
+	p.Add(&FieldChange{Name: name, Before: before, After: after, Replace: true})
+}
+
+// Add 変更内容を記録し、Action/UpdateLevel/各種理由を更新する
+func (p *Plan) Add(change *FieldChange) {
+	p.Changes = append(p.Changes, change)
+
+	if change.Replace {
+		p.Action = PlanActionReplace
+		p.ReplaceReasons = append(p.ReplaceReasons, fmt.Sprintf("%s cannot be changed", change.Name))
+		return
+	}
+	if p.Action == PlanActionNone {
+		p.Action = PlanActionUpdate
+	}
+	if change.UpdateLevel > p.UpdateLevel {
+		p.UpdateLevel = change.UpdateLevel
+	}
+	if change.UpdateLevel == UpdateLevelNeedShutdown {
+		p.ShutdownReasons = append(p.ShutdownReasons, fmt.Sprintf("%s is changed", change.Name))
+	}
+}
+
+// Merge 他のPlanの変更内容をprefixを付与して取り込む
+func (p *Plan) Merge(prefix string, other *Plan) {
+	if other == nil {
+		return
+	}
+	for _, c := range other.Changes {
+		p.Add(&FieldChange{
+			Name:        prefix + c.Name,
+			Before:      c.Before,
+			After:       c.After,
+			UpdateLevel: c.UpdateLevel,
+			Replace:     c.Replace,
+		})
+	}
+}
+
+// Equal Planでの比較に用いる等価判定
+//
+// 長さ0のスライス/マップとnilは等価とみなす。またtypes.Tagsは順序を無視して比較する。
+func Equal(a, b interface{}) bool {
+	if isZero(a) && isZero(b) {
+		return true
+	}
+	if ta, ok := a.(types.Tags); ok {
+		if tb, ok := b.(types.Tags); ok {
+			return reflect.DeepEqual(sortedTags(ta), sortedTags(tb))
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func sortedTags(tags types.Tags) []string {
+	sorted := append([]string{}, tags...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func isZero(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return rv.IsNil()
+	default:
+		return rv.IsZero()
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iaas
+
+import (
+	"testing"
+
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlan_Diff(t *testing.T) {
+	plan := NewPlan()
+	require.False(t, plan.HasChanges())
+
+	plan.Diff("Name", "name", "name")
+	plan.Diff("Tags", types.Tags{"tag1", "tag2"}, types.Tags{"tag2", "tag1"})
+	plan.Diff("Description", "", nil)
+	plan.Diff("IPAddresses", []string{}, []string(nil))
+	require.False(t, plan.HasChanges())
+	require.Equal(t, PlanActionNone, plan.Action)
+
+	plan.Diff("Name", "name", "name-upd")
+	require.True(t, plan.HasChanges())
+	require.Equal(t, PlanActionUpdate, plan.Action)
+	require.Equal(t, UpdateLevelSimple, plan.UpdateLevel)
+	require.False(t, plan.IsNeedShutdown())
+
+	plan.DiffNeedShutdown("CPU", 1, 2)
+	require.Equal(t, PlanActionUpdate, plan.Action)
+	require.True(t, plan.IsNeedShutdown())
+	require.Equal(t, []string{"CPU is changed"}, plan.ShutdownReasons)
+
+	plan.DiffReplace("PlanID", types.ID(1), types.ID(2))
+	require.Equal(t, PlanActionReplace, plan.Action)
+	require.Equal(t, []string{"PlanID cannot be changed"}, plan.ReplaceReasons)
+	require.Len(t, plan.Changes, 3)
+}
+
+func TestPlan_Merge(t *testing.T) {
+	child := NewPlan()
+	child.DiffNeedShutdown("Connection", types.DiskConnections.VirtIO, types.DiskConnections.IDE)
+
+	plan := NewPlan()
+	plan.Merge("Disks[0].", child)
+
+	require.True(t, plan.IsNeedShutdown())
+	require.Equal(t, "Disks[0].Connection", plan.Changes[0].Name)
+}
+
+func TestNewCreatePlan(t *testing.T) {
+	plan := NewCreatePlan(map[string]interface{}{
+		"Name":        "name",
+		"Description": "",
+		"Tags":        types.Tags{},
+		"CPU":         2,
+	})
+	require.Equal(t, PlanActionCreate, plan.Action)
+	require.Len(t, plan.Changes, 2)
+	require.Equal(t, "CPU", plan.Changes[0].Name)
+	require.Equal(t, "Name", plan.Changes[1].Name)
+}
@@ -0,0 +1,147 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iaas
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// Problem Preflightで検出された問題
+type Problem struct {
+	// Field 問題のあるフィールド 例: NetworkInterfaces[0].PacketFilterID
+	Field   string
+	Message string
+}
+
+// String fmt.Stringerの実装
+func (p *Problem) String() string {
+	if p.Field == "" {
+		return p.Message
+	}
+	return fmt.Sprintf("%s: %s", p.Field, p.Message)
+}
+
+// PreflightError Preflightで検出された全ての問題
+type PreflightError struct {
+	Problems []*Problem
+}
+
+// Error errorの実装
+func (e *PreflightError) Error() string {
+	var messages []string
+	for _, p := range e.Problems {
+		messages = append(messages, p.String())
+	}
+	return fmt.Sprintf("preflight found %d problem(s): %s", len(e.Problems), strings.Join(messages, ", "))
+}
+
+// Preflight Apply前に参照先のリソースやプランを確認し、検出した問題を収集する
+//
+// 確認は最初の問題で中断せずに全て行う。
+// ただし404以外のAPIエラーなど、リクエスト内容の問題ではないエラーが発生した場合は以降の確認を行わない。
+type Preflight struct {
+	problems []*Problem
+	err      error
+}
+
+// NewPreflight 問題を含まない状態のPreflightを返す
+func NewPreflight() *Preflight {
+	return &Preflight{}
+}
+
+// Add 問題を記録する
+func (p *Preflight) Add(field, format string, a ...interface{}) {
+	p.problems = append(p.problems, &Problem{Field: field, Message: fmt.Sprintf(format, a...)})
+}
+
+// Ref 参照先のリソースが存在するか確認する
+//
+// idが空の場合は何もしない。readが404エラーを返した場合は問題として記録する。
+// zoneを指定した場合、他のゾーンのリソースを参照している場合もここで検出される。
+func (p *Preflight) Ref(field, kind, zone string, id types.ID, read func() error) {
+	if id.IsEmpty() || p.err != nil {
+		return
+	}
+	err := read()
+	switch {
+	case err == nil:
+	case responseCode(err) == http.StatusNotFound:
+		if zone == "" {
+			p.Add(field, "%s[%s] not found", kind, id)
+		} else {
+			p.Add(field, "%s[%s] not found in zone %s", kind, id, zone)
+		}
+	default:
+		p.err = err
+	}
+}
+
+// Check fnが返したエラーを問題として記録する
+//
+// プランの利用可否など、存在確認以外の確認に利用する。404以外のAPIエラーは問題として扱わない。
+func (p *Preflight) Check(field string, fn func() error) {
+	if p.err != nil {
+		return
+	}
+	err := fn()
+	switch code := responseCode(err); {
+	case err == nil:
+	case code == 0 || code == http.StatusNotFound:
+		p.problems = append(p.problems, &Problem{Field: field, Message: err.Error()})
+	default:
+		p.err = err
+	}
+}
+
+// Merge 他のPreflightが返したエラーに含まれる問題をフィールド名にprefixを付与して取り込む
+//
+// errが*PreflightErrorを含まない場合はリクエスト内容の問題ではないエラーとして扱う
+func (p *Preflight) Merge(prefix string, err error) {
+	if err == nil || p.err != nil {
+		return
+	}
+	var preflightErr *PreflightError
+	if !errors.As(err, &preflightErr) {
+		p.err = err
+		return
+	}
+	for _, problem := range preflightErr.Problems {
+		p.problems = append(p.problems, &Problem{Field: prefix + problem.Field, Message: problem.Message})
+	}
+}
+
+// Problems 記録された問題を返す
+func (p *Preflight) Problems() []*Problem {
+	return p.problems
+}
+
+// Err 確認結果をエラーとして返す
+//
+// 問題が記録されている場合は*PreflightErrorを含むErrValidationFailedに分類されたエラーを返す。
+// 問題以外のエラーが発生していた場合はそのエラーを返す。
+func (p *Preflight) Err() error {
+	if p.err != nil {
+		return p.err
+	}
+	if len(p.problems) == 0 {
+		return nil
+	}
+	return NewError(ErrValidationFailed, &PreflightError{Problems: p.problems})
+}
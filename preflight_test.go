@@ -0,0 +1,79 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iaas
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	sacloud "github.com/sacloud/iaas-api-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreflight(t *testing.T) {
+	notFound := sacloud.NewAPIError("GET", nil, http.StatusNotFound, &sacloud.APIErrorResponse{})
+	forbidden := sacloud.NewAPIError("GET", nil, http.StatusForbidden, &sacloud.APIErrorResponse{})
+	found := func() error { return nil }
+
+	t.Run("no problems", func(t *testing.T) {
+		p := NewPreflight()
+		p.Ref("SwitchID", "switch", "is1a", 1, found)
+		p.Ref("IconID", "icon", "", 0, func() error { return notFound })
+		p.Check("Plan", found)
+		require.NoError(t, p.Err())
+	})
+
+	t.Run("collects all problems", func(t *testing.T) {
+		p := NewPreflight()
+		p.Ref("SwitchID", "switch", "is1a", 1, func() error { return notFound })
+		p.Ref("IconID", "icon", "", 2, func() error { return notFound })
+		p.Check("Plan", func() error { return errors.New("plan not found") })
+		p.Merge("Disks[0].", NewPreflight().Err())
+
+		child := NewPreflight()
+		child.Add("DistantFrom[0]", "dummy")
+		p.Merge("Disks[1].", child.Err())
+
+		err := p.Err()
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrValidationFailed))
+		require.False(t, errors.Is(err, ErrNotFound))
+
+		var preflightErr *PreflightError
+		require.True(t, errors.As(err, &preflightErr))
+		require.Equal(t, []*Problem{
+			{Field: "SwitchID", Message: "switch[1] not found in zone is1a"},
+			{Field: "IconID", Message: "icon[2] not found"},
+			{Field: "Plan", Message: "plan not found"},
+			{Field: "Disks[1].DistantFrom[0]", Message: "dummy"},
+		}, preflightErr.Problems)
+	})
+
+	t.Run("stops on other errors", func(t *testing.T) {
+		called := false
+		p := NewPreflight()
+		p.Ref("SwitchID", "switch", "is1a", 1, func() error { return notFound })
+		p.Ref("PacketFilterID", "packet filter", "is1a", 2, func() error { return forbidden })
+		p.Ref("IconID", "icon", "", 3, func() error {
+			called = true
+			return nil
+		})
+
+		require.False(t, called)
+		require.Equal(t, forbidden, p.Err())
+		require.Len(t, p.Problems(), 1)
+	})
+}
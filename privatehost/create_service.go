@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Create(req *CreateRequest) (*iaas.PrivateHost, error) {
@@ -26,8 +27,12 @@ func (s *Service) Create(req *CreateRequest) (*iaas.PrivateHost, error) {
 }
 
 func (s *Service) CreateWithContext(ctx context.Context, req *CreateRequest) (*iaas.PrivateHost, error) {
+	return service.Invoke(ctx, s.interceptors, "privatehost", "create", req, s.create)
+}
+
+func (s *Service) create(ctx context.Context, req *CreateRequest) (*iaas.PrivateHost, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	if req.PlanID.IsEmpty() {
@@ -0,0 +1,110 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package progress
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// NotifyInterval Reader/Writerが進捗を通知する最小間隔
+var NotifyInterval = time.Second
+
+type counter struct {
+	ctx      context.Context
+	resource string
+	id       types.ID
+	step     string
+	total    int64
+
+	current  int64
+	notified time.Time
+}
+
+func (c *counter) add(n int, eof bool) {
+	c.current += int64(n)
+	now := time.Now()
+	if !eof && now.Sub(c.notified) < NotifyInterval {
+		return
+	}
+	c.notified = now
+	Notify(c.ctx, &Event{
+		Type:     EventProgress,
+		Resource: c.resource,
+		ID:       c.id,
+		Step:     c.step,
+		Current:  c.current,
+		Total:    c.total,
+		Unit:     "bytes",
+		Time:     now,
+	})
+}
+
+// Reader 読み込んだバイト数を進捗として通知するio.Reader
+type Reader struct {
+	reader io.Reader
+	*counter
+}
+
+// NewReader 読み込んだバイト数を進捗として通知するReaderを返す
+//
+// totalは全体のバイト数、不明な場合は0を指定する。
+// contextにObserverが設定されていない場合はrをそのまま返す。
+func NewReader(ctx context.Context, r io.Reader, resource string, id types.ID, step string, total int64) io.Reader {
+	if ObserverFromContext(ctx) == nil {
+		return r
+	}
+	return &Reader{
+		reader:  r,
+		counter: &counter{ctx: ctx, resource: resource, id: id, step: step, total: total},
+	}
+}
+
+// Read io.Readerの実装
+func (r *Reader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.add(n, err == io.EOF)
+	return n, err
+}
+
+// Writer 書き込んだバイト数を進捗として通知するio.Writer
+type Writer struct {
+	writer io.Writer
+	*counter
+}
+
+// NewWriter 書き込んだバイト数を進捗として通知するWriterを返す
+//
+// totalは全体のバイト数、不明な場合は0を指定する。
+// contextにObserverが設定されていない場合はwをそのまま返す。
+func NewWriter(ctx context.Context, w io.Writer, resource string, id types.ID, step string, total int64) io.Writer {
+	if ObserverFromContext(ctx) == nil {
+		return w
+	}
+	return &Writer{
+		writer:  w,
+		counter: &counter{ctx: ctx, resource: resource, id: id, step: step, total: total},
+	}
+}
+
+// Write io.Writerの実装
+func (w *Writer) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.add(n, w.total > 0 && w.current+int64(n) >= w.total)
+	return n, err
+}
@@ -0,0 +1,195 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package progress
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// EventType 進捗イベントの種別
+type EventType string
+
+const (
+	// EventStep 処理の段階が完了/開始した 例: server created
+	EventStep = EventType("step")
+	// EventProgress 数値で表せる進捗 例: disk copying 45%, FTP upload 1.2GB/20GB
+	EventProgress = EventType("progress")
+	// EventRetry リトライを行う 例: retry 1/3 after Failed availability
+	EventRetry = EventType("retry")
+	// EventWaiting 状態の変化を待っている 例: waiting for boot
+	EventWaiting = EventType("waiting")
+)
+
+// Event 進捗イベント
+type Event struct {
+	Type EventType
+	// Scope 呼び出し元でWithScopeにより付与された処理の範囲 例: disk 2/3
+	Scope string
+	// Resource 対象リソースの種別 例: server, disk, archive
+	Resource string
+	// ID 対象リソースのID、未確定の場合は空
+	ID types.ID
+	// Step 処理の段階 例: created, copying, uploading, boot
+	Step string
+	// Message 人が読むためのメッセージ
+	Message string
+
+	// Current 現在の進捗、EventProgressの場合に設定される
+	Current int64
+	// Total 全体量、不明な場合は0
+	Total int64
+	// Unit Current/Totalの単位 例: MB, bytes
+	Unit string
+
+	// Attempt 何回目の試行か、EventRetryの場合に設定される
+	Attempt int
+	// MaxAttempts 試行回数の上限
+	MaxAttempts int
+
+	Time time.Time
+}
+
+// Percent 進捗率を返す、Totalが不明な場合は-1を返す
+func (e *Event) Percent() float64 {
+	if e.Total <= 0 {
+		return -1
+	}
+	return float64(e.Current) / float64(e.Total) * 100
+}
+
+// String fmt.Stringerの実装
+func (e *Event) String() string {
+	var parts []string
+	if e.Scope != "" {
+		parts = append(parts, e.Scope)
+	}
+	target := e.Resource
+	if !e.ID.IsEmpty() {
+		target = fmt.Sprintf("%s[%s]", e.Resource, e.ID)
+	}
+	if target != "" {
+		parts = append(parts, target)
+	}
+
+	switch e.Type {
+	case EventProgress:
+		progress := fmt.Sprintf("%d", e.Current)
+		if e.Total > 0 {
+			progress = fmt.Sprintf("%d/%d", e.Current, e.Total)
+		}
+		if e.Unit != "" {
+			progress += e.Unit
+		}
+		if e.Total > 0 {
+			progress += fmt.Sprintf(" (%.0f%%)", e.Percent())
+		}
+		parts = append(parts, e.Step, progress)
+	case EventRetry:
+		parts = append(parts, fmt.Sprintf("retry %d/%d", e.Attempt, e.MaxAttempts))
+	default:
+		parts = append(parts, e.Step)
+	}
+	if e.Message != "" {
+		parts = append(parts, e.Message)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Observer 進捗イベントを受け取る
+//
+// Observeは処理中のgoroutineから同期的に呼ばれるため、時間のかかる処理を行わないこと
+type Observer interface {
+	Observe(event *Event)
+}
+
+// ObserverFunc 関数をObserverとして扱うための型
+type ObserverFunc func(event *Event)
+
+// Observe Observerの実装
+func (f ObserverFunc) Observe(event *Event) {
+	f(event)
+}
+
+type observerKey struct{}
+
+type scopeKey struct{}
+
+// WithObserver Observerを設定したcontextを返す
+func WithObserver(ctx context.Context, observer Observer) context.Context {
+	return context.WithValue(ctx, observerKey{}, observer)
+}
+
+// ObserverFromContext contextに設定されたObserverを返す、未設定の場合はnil
+func ObserverFromContext(ctx context.Context) Observer {
+	if observer, ok := ctx.Value(observerKey{}).(Observer); ok {
+		return observer
+	}
+	return nil
+}
+
+// WithScope イベントに付与する処理の範囲を設定したcontextを返す
+//
+// 既に範囲が設定されている場合は空白区切りで連結される
+func WithScope(ctx context.Context, scope string) context.Context {
+	if parent, ok := ctx.Value(scopeKey{}).(string); ok && parent != "" {
+		scope = parent + " " + scope
+	}
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+// Notify contextに設定されたObserverへイベントを通知する
+//
+// Observerが設定されていない場合は何もしない
+func Notify(ctx context.Context, event *Event) {
+	observer := ObserverFromContext(ctx)
+	if observer == nil {
+		return
+	}
+	if scope, ok := ctx.Value(scopeKey{}).(string); ok && event.Scope == "" {
+		event.Scope = scope
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	observer.Observe(event)
+}
+
+// Step EventStepを通知する
+func Step(ctx context.Context, resource string, id types.ID, step string) {
+	Notify(ctx, &Event{Type: EventStep, Resource: resource, ID: id, Step: step})
+}
+
+// Waiting EventWaitingを通知する
+func Waiting(ctx context.Context, resource string, id types.ID, step string) {
+	Notify(ctx, &Event{Type: EventWaiting, Resource: resource, ID: id, Step: step, Message: "waiting"})
+}
+
+// ResourceName 値の型からイベントで用いるリソース種別を返す 例: *iaas.Disk -> disk
+func ResourceName(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return strings.ToLower(t.Name())
+}
@@ -0,0 +1,100 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package progress
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	events []*Event
+}
+
+func (r *recorder) Observe(event *Event) {
+	r.events = append(r.events, event)
+}
+
+func TestNotify(t *testing.T) {
+	// Observerが未設定の場合は何もしない
+	Notify(context.Background(), &Event{Type: EventStep})
+
+	rec := &recorder{}
+	ctx := WithObserver(context.Background(), rec)
+	ctx = WithScope(WithScope(ctx, "server"), "disk 2/3")
+
+	Step(ctx, "disk", 1, "created")
+	require.Len(t, rec.events, 1)
+	require.Equal(t, "server disk 2/3", rec.events[0].Scope)
+	require.False(t, rec.events[0].Time.IsZero())
+	require.Equal(t, "server disk 2/3 disk[1] created", rec.events[0].String())
+}
+
+func TestEvent_String(t *testing.T) {
+	cases := []struct {
+		in     *Event
+		expect string
+	}{
+		{
+			in:     &Event{Type: EventProgress, Resource: "disk", Step: "copy", Current: 45, Total: 100, Unit: "MB"},
+			expect: "disk copy 45/100MB (45%)",
+		},
+		{
+			in:     &Event{Type: EventProgress, Resource: "archive", Step: "upload", Current: 1024, Unit: "bytes"},
+			expect: "archive upload 1024bytes",
+		},
+		{
+			in:     &Event{Type: EventRetry, Resource: "database", ID: 1, Attempt: 1, MaxAttempts: 3, Message: "after Failed availability"},
+			expect: "database[1] retry 1/3 after Failed availability",
+		},
+		{
+			in:     &Event{Type: EventWaiting, Resource: "server", ID: 1, Step: "boot", Message: "waiting"},
+			expect: "server[1] boot waiting",
+		},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.expect, tc.in.String())
+	}
+}
+
+func TestReader(t *testing.T) {
+	NotifyInterval = 0
+	defer func() { NotifyInterval = time.Second }()
+
+	// Observerが未設定の場合はラップしない
+	src := strings.NewReader("dummy")
+	require.Equal(t, io.Reader(src), NewReader(context.Background(), src, "archive", 1, "upload", 5))
+
+	rec := &recorder{}
+	ctx := WithObserver(context.Background(), rec)
+	reader := NewReader(ctx, strings.NewReader(strings.Repeat("a", 1024)), "archive", 1, "upload", 1024)
+
+	buf := &bytes.Buffer{}
+	_, err := io.Copy(buf, reader)
+	require.NoError(t, err)
+	require.Equal(t, 1024, buf.Len())
+
+	require.NotEmpty(t, rec.events)
+	last := rec.events[len(rec.events)-1]
+	require.Equal(t, EventProgress, last.Type)
+	require.Equal(t, int64(1024), last.Current)
+	require.Equal(t, float64(100), last.Percent())
+}
@@ -0,0 +1,76 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package progress
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go/accessor"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/packages-go/wait"
+)
+
+// WaitForState waiterでリソースの状態を待ち、待機中の状態を進捗として通知する
+//
+// 待機開始時にEventWaitingを通知する。
+// ディスクやアーカイブなどコピー状況(MigratedMB/SizeMB)を持つリソースの場合はEventProgressを通知する。
+// エラーとなった場合は待機中に最後に取得した状態をエラーと共に返す。
+func WaitForState(ctx context.Context, waiter wait.StateWaiter, resource string, id types.ID, step string) (interface{}, error) {
+	Waiting(ctx, resource, id, step)
+
+	var lastState interface{}
+	compCh, progressCh, errCh := waiter.WaitForStateAsync(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return lastState, ctx.Err()
+		case state := <-compCh:
+			Step(ctx, resource, id, step+" completed")
+			return state, nil
+		case state := <-progressCh:
+			lastState = state
+			notifyMigration(ctx, state, resource, id, step)
+		case err := <-errCh:
+			return lastState, err
+		}
+	}
+}
+
+func notifyMigration(ctx context.Context, state interface{}, resource string, id types.ID, step string) {
+	migrated, ok1 := state.(accessor.MigratedMB)
+	size, ok2 := state.(accessor.SizeMB)
+	if !ok1 || !ok2 || size.GetSizeMB() == 0 {
+		return
+	}
+	Notify(ctx, &Event{
+		Type:     EventProgress,
+		Resource: resource,
+		ID:       id,
+		Step:     step,
+		Current:  int64(migrated.GetMigratedMB()),
+		Total:    int64(size.GetSizeMB()),
+		Unit:     "MB",
+	})
+}
+
+// Wait fnでの待機の前後にEventWaiting/EventStepを通知する
+func Wait(ctx context.Context, resource string, id types.ID, step string, fn func() error) error {
+	Waiting(ctx, resource, id, step)
+	if err := fn(); err != nil {
+		return err
+	}
+	Step(ctx, resource, id, step+" completed")
+	return nil
+}
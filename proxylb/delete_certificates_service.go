@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) DeleteCertificates(req *DeleteCertificatesRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) DeleteCertificates(req *DeleteCertificatesRequest) error {
 }
 
 func (s *Service) DeleteCertificatesWithContext(ctx context.Context, req *DeleteCertificatesRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "proxylb", "delete_certificates", req, s.deleteCertificates)
+}
+
+func (s *Service) deleteCertificates(ctx context.Context, req *DeleteCertificatesRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewProxyLBOp(s.caller)
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) GetCertificates(req *GetCertificatesRequest) (*iaas.ProxyLBCertificates, error) {
@@ -25,8 +26,12 @@ func (s *Service) GetCertificates(req *GetCertificatesRequest) (*iaas.ProxyLBCer
 }
 
 func (s *Service) GetCertificatesWithContext(ctx context.Context, req *GetCertificatesRequest) (*iaas.ProxyLBCertificates, error) {
+	return service.Invoke(ctx, s.interceptors, "proxylb", "get_certificates", req, s.getCertificates)
+}
+
+func (s *Service) getCertificates(ctx context.Context, req *GetCertificatesRequest) (*iaas.ProxyLBCertificates, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewProxyLBOp(s.caller)
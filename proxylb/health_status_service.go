@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) HealthStatus(req *HealthStatusRequest) (*iaas.ProxyLBHealth, error) {
@@ -25,8 +26,12 @@ func (s *Service) HealthStatus(req *HealthStatusRequest) (*iaas.ProxyLBHealth, e
 }
 
 func (s *Service) HealthStatusWithContext(ctx context.Context, req *HealthStatusRequest) (*iaas.ProxyLBHealth, error) {
+	return service.Invoke(ctx, s.interceptors, "proxylb", "health_status", req, s.healthStatus)
+}
+
+func (s *Service) healthStatus(ctx context.Context, req *HealthStatusRequest) (*iaas.ProxyLBHealth, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewProxyLBOp(s.caller)
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 )
 
@@ -26,8 +27,12 @@ func (s *Service) MonitorConnection(req *MonitorConnectionRequest) ([]*iaas.Moni
 }
 
 func (s *Service) MonitorConnectionWithContext(ctx context.Context, req *MonitorConnectionRequest) ([]*iaas.MonitorConnectionValue, error) {
+	return service.Invoke(ctx, s.interceptors, "proxylb", "monitor_connection", req, s.monitorConnection)
+}
+
+func (s *Service) monitorConnection(ctx context.Context, req *MonitorConnectionRequest) ([]*iaas.MonitorConnectionValue, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewProxyLBOp(s.caller)
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) RenewLetsEncryptCert(req *RenewLetsEncryptCertRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) RenewLetsEncryptCert(req *RenewLetsEncryptCertRequest) error {
 }
 
 func (s *Service) RenewLetsEncryptCertWithContext(ctx context.Context, req *RenewLetsEncryptCertRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "proxylb", "renew_lets_encrypt_cert", req, s.renewLetsEncryptCert)
+}
+
+func (s *Service) renewLetsEncryptCert(ctx context.Context, req *RenewLetsEncryptCertRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewProxyLBOp(s.caller)
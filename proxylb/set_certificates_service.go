@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) SetCertificates(req *SetCertificatesRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) SetCertificates(req *SetCertificatesRequest) error {
 }
 
 func (s *Service) SetCertificatesWithContext(ctx context.Context, req *SetCertificatesRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "proxylb", "set_certificates", req, s.setCertificates)
+}
+
+func (s *Service) setCertificates(ctx context.Context, req *SetCertificatesRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewProxyLBOp(s.caller)
@@ -16,10 +16,10 @@ package proxylb
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/helper/plans"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/packages-go/objutil"
 )
 
@@ -28,19 +28,23 @@ func (s *Service) Update(req *UpdateRequest) (*iaas.ProxyLB, error) {
 }
 
 func (s *Service) UpdateWithContext(ctx context.Context, req *UpdateRequest) (*iaas.ProxyLB, error) {
+	return service.Invoke(ctx, s.interceptors, "proxylb", "update", req, s.update)
+}
+
+func (s *Service) update(ctx context.Context, req *UpdateRequest) (*iaas.ProxyLB, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewProxyLBOp(s.caller)
 	current, err := client.Read(ctx, req.ID)
 	if err != nil {
-		return nil, fmt.Errorf("reading ProxyLB[%s] failed: %s", req.ID, err)
+		return nil, service.StepError("read", err)
 	}
 
 	params, err := req.ToRequestParameter(current)
 	if err != nil {
-		return nil, fmt.Errorf("processing request parameter failed: %s", err)
+		return nil, service.StepError("process request parameter", err)
 	}
 
 	updated, err := client.Update(ctx, req.ID, params)
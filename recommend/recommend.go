@@ -0,0 +1,264 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package recommend 要件を満たすサーバプランをゾーンごとの料金とともに提案する
+package recommend
+
+import (
+	"context"
+	"sort"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/cost"
+	"github.com/sacloud/iaas-service-go/server"
+	"github.com/sacloud/iaas-service-go/serverplan"
+	"github.com/sacloud/iaas-service-go/serviceclass"
+)
+
+// Requirement サーバプランに対する要件
+type Requirement struct {
+	// Zones 対象ゾーンのリスト、デフォルトはiaas.SakuraCloudZones
+	Zones []string
+
+	// CPU 最小コア数
+	CPU int
+	// MemoryGB 最小メモリサイズ(GB)
+	MemoryGB int
+	// GPU 最小GPU数
+	GPU int
+
+	// CPUModel 指定した場合は一致するプランのみ対象とする
+	CPUModel string
+	// Commitment 指定した場合は一致するプランのみ対象とする、コア専有プランの場合はtypes.Commitments.DedicatedCPU
+	Commitment types.ECommitment
+	// Generation 指定した場合は一致するプランのみ対象とする
+	Generation types.EPlanGeneration
+
+	// MaxMonthlyPrice 月額料金の上限、0の場合は上限なし
+	//
+	// 指定した場合は料金を特定できないプランは対象外となる
+	MaxMonthlyPrice int
+}
+
+func (r *Requirement) match(plan *iaas.ServerPlan) bool {
+	if plan.Availability != types.Availabilities.Available {
+		return false
+	}
+	if plan.CPU < r.CPU || plan.GetMemoryGB() < r.MemoryGB || plan.GPU < r.GPU {
+		return false
+	}
+	if r.CPUModel != "" && plan.CPUModel != r.CPUModel {
+		return false
+	}
+	if r.Commitment != "" && plan.Commitment != r.Commitment {
+		return false
+	}
+	if r.Generation != 0 && plan.Generation != r.Generation {
+		return false
+	}
+	return true
+}
+
+// Recommendation 提案されたゾーンごとのサーバプラン
+type Recommendation struct {
+	Zone string
+	Plan *iaas.ServerPlan
+	// ServiceClass サービスクラスのパス
+	ServiceClass string
+	// Price 料金を特定できない場合はnil
+	Price *cost.Price
+}
+
+// ApplyRequest Recommendationのプランを反映したserver.ApplyRequestを返す
+//
+// base がnilの場合はZoneとプランのみを設定したserver.ApplyRequestを返す
+func (r *Recommendation) ApplyRequest(base *server.ApplyRequest) *server.ApplyRequest {
+	req := &server.ApplyRequest{}
+	if base != nil {
+		copied := *base
+		req = &copied
+	}
+	req.Zone = r.Zone
+	req.CPU = r.Plan.CPU
+	req.MemoryGB = r.Plan.GetMemoryGB()
+	req.GPU = r.Plan.GPU
+	req.CPUModel = r.Plan.CPUModel
+	req.Commitment = r.Plan.Commitment
+	req.Generation = r.Plan.Generation
+	return req
+}
+
+// Suggestion 既存サーバに対するより安価なプランの提案
+type Suggestion struct {
+	Server *iaas.Server
+	// Current 現在のプラン、料金を特定できない場合はCurrent.Priceがnilとなり候補は提案されない
+	Current *Recommendation
+	// Candidates 現在のプラン以上のスペックでより安価なプラン、安価な順
+	Candidates []*Recommendation
+}
+
+// Recommender 要件を満たすサーバプランを提案する
+type Recommender struct {
+	Caller iaas.APICaller
+	// Aliases サービスクラスのパスの読み替え、cost.Estimator.Aliasesと同じ
+	Aliases map[string]string
+}
+
+// NewRecommender Recommenderを作成する
+func NewRecommender(caller iaas.APICaller) *Recommender {
+	return &Recommender{Caller: caller}
+}
+
+// Recommend 要件を満たす利用可能なプランを返す
+//
+// 月額料金の安い順に並び、料金を特定できないプランは末尾となる
+func (r *Recommender) Recommend(ctx context.Context, req *Requirement) ([]*Recommendation, error) {
+	plans, err := serverplan.New(r.Caller).FindInZonesWithContext(ctx, &serverplan.FindInZonesRequest{Zones: req.Zones})
+	if err != nil {
+		return nil, err
+	}
+	prices, err := r.prices(ctx, req.Zones)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*Recommendation
+	for _, plan := range plans {
+		if !req.match(plan.Value) {
+			continue
+		}
+		recommendation := r.recommendation(plan.Zone, plan.Value, prices)
+		if req.MaxMonthlyPrice > 0 && (recommendation.Price == nil || recommendation.Price.Monthly > req.MaxMonthlyPrice) {
+			continue
+		}
+		results = append(results, recommendation)
+	}
+	sortRecommendations(results)
+	return results, nil
+}
+
+// Suggest server.FindAllで見つかった既存サーバごとに同じゾーン内のより安価な同等以上のプランを提案する
+//
+// 候補にはCPUモデルや世代が異なるプランも含む
+func (r *Recommender) Suggest(ctx context.Context, req *server.FindRequest) ([]*Suggestion, error) {
+	servers, err := server.New(r.Caller).FindAllWithContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(servers) == 0 {
+		return nil, nil
+	}
+
+	zones := []string{req.Zone}
+	plans, err := serverplan.New(r.Caller).FindInZonesWithContext(ctx, &serverplan.FindInZonesRequest{Zones: zones})
+	if err != nil {
+		return nil, err
+	}
+	prices, err := r.prices(ctx, zones)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*Suggestion
+	for _, s := range servers {
+		current := r.recommendation(req.Zone, &iaas.ServerPlan{
+			ID:           s.ServerPlanID,
+			Name:         s.ServerPlanName,
+			CPU:          s.CPU,
+			MemoryMB:     s.MemoryMB,
+			GPU:          s.GPU,
+			CPUModel:     s.ServerPlanCPUModel,
+			Commitment:   s.ServerPlanCommitment,
+			Generation:   s.ServerPlanGeneration,
+			Availability: types.Availabilities.Available,
+		}, prices)
+		suggestion := &Suggestion{Server: s, Current: current}
+
+		if current.Price != nil {
+			requirement := &Requirement{
+				CPU:        s.CPU,
+				MemoryGB:   s.GetMemoryGB(),
+				GPU:        s.GPU,
+				Commitment: s.ServerPlanCommitment,
+			}
+			for _, plan := range plans {
+				if !requirement.match(plan.Value) {
+					continue
+				}
+				candidate := r.recommendation(plan.Zone, plan.Value, prices)
+				if candidate.Price != nil && candidate.Price.Monthly < current.Price.Monthly {
+					suggestion.Candidates = append(suggestion.Candidates, candidate)
+				}
+			}
+			sortRecommendations(suggestion.Candidates)
+		}
+		results = append(results, suggestion)
+	}
+	return results, nil
+}
+
+// prices ゾーンごとのサービスクラスの料金を返す
+func (r *Recommender) prices(ctx context.Context, zones []string) (map[string]map[string]*iaas.Price, error) {
+	classes, err := serviceclass.New(r.Caller).FindInZonesWithContext(ctx, &serviceclass.FindInZonesRequest{Zones: zones})
+	if err != nil {
+		return nil, err
+	}
+	prices := make(map[string]map[string]*iaas.Price)
+	for _, class := range classes {
+		if class.Value.Price == nil {
+			continue
+		}
+		if prices[class.Zone] == nil {
+			prices[class.Zone] = make(map[string]*iaas.Price)
+		}
+		prices[class.Zone][class.Value.ServiceClassPath] = class.Value.Price
+	}
+	return prices, nil
+}
+
+func (r *Recommender) recommendation(zone string, plan *iaas.ServerPlan, prices map[string]map[string]*iaas.Price) *Recommendation {
+	path := cost.ServerServiceClass(plan)
+	recommendation := &Recommendation{Zone: zone, Plan: plan, ServiceClass: path}
+
+	if alias, ok := r.Aliases[path]; ok {
+		path = alias
+	}
+	if price, ok := prices[zone][path]; ok {
+		recommendation.Price = &cost.Price{Hourly: price.Hourly, Daily: price.Daily, Monthly: price.Monthly}
+	}
+	return recommendation
+}
+
+func sortRecommendations(recommendations []*Recommendation) {
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if (a.Price == nil) != (b.Price == nil) {
+			return a.Price != nil
+		}
+		if a.Price != nil && a.Price.Monthly != b.Price.Monthly {
+			return a.Price.Monthly < b.Price.Monthly
+		}
+		if a.Plan.CPU != b.Plan.CPU {
+			return a.Plan.CPU < b.Plan.CPU
+		}
+		if a.Plan.MemoryMB != b.Plan.MemoryMB {
+			return a.Plan.MemoryMB < b.Plan.MemoryMB
+		}
+		if a.Plan.GPU != b.Plan.GPU {
+			return a.Plan.GPU < b.Plan.GPU
+		}
+		return a.Zone < b.Zone
+	})
+}
@@ -0,0 +1,137 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommend_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/cost"
+	"github.com/sacloud/iaas-service-go/recommend"
+	"github.com/sacloud/iaas-service-go/server"
+	"github.com/sacloud/iaas-service-go/serverplan"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecommender(t *testing.T) {
+	ctx := context.Background()
+	caller := testutil.SingletonAPICaller()
+	zone := testutil.TestZone()
+
+	plans, err := serverplan.New(caller).FindWithContext(ctx, &serverplan.FindRequest{Zone: zone})
+	require.NoError(t, err)
+	var small, large *iaas.ServerPlan
+	for _, plan := range plans {
+		switch {
+		case plan.CPU == 1 && plan.GPU == 0:
+			small = plan
+		case plan.CPU == 2 && plan.GPU == 0:
+			large = plan
+		}
+	}
+	require.NotNil(t, small)
+	require.NotNil(t, large)
+
+	// 大きいプランの方が安価になるようにサービスクラスを割り当てる
+	recommender := recommend.NewRecommender(caller)
+	recommender.Aliases = map[string]string{
+		cost.ServerServiceClass(small): "cloud/plan/2",
+		cost.ServerServiceClass(large): "cloud/plan/1",
+	}
+
+	t.Run("recommend", func(t *testing.T) {
+		results, err := recommender.Recommend(ctx, &recommend.Requirement{
+			Zones:      []string{zone},
+			CPU:        1,
+			Commitment: types.Commitments.Standard,
+		})
+		require.NoError(t, err)
+		require.True(t, len(results) >= 2)
+		require.Equal(t, large.ID, results[0].Plan.ID)
+		require.Equal(t, cost.Price{Hourly: 10, Daily: 108, Monthly: 2139}, *results[0].Price)
+		require.Equal(t, small.ID, results[1].Plan.ID)
+		for _, r := range results[2:] {
+			require.Nil(t, r.Price)
+		}
+
+		req := results[0].ApplyRequest(&server.ApplyRequest{Name: "recommend-test"})
+		require.Equal(t, zone, req.Zone)
+		require.Equal(t, "recommend-test", req.Name)
+		require.Equal(t, large.CPU, req.CPU)
+		require.Equal(t, large.GetMemoryGB(), req.MemoryGB)
+	})
+
+	t.Run("max price", func(t *testing.T) {
+		results, err := recommender.Recommend(ctx, &recommend.Requirement{
+			Zones:           []string{zone},
+			CPU:             1,
+			MaxMonthlyPrice: 3000,
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, large.ID, results[0].Plan.ID)
+	})
+
+	t.Run("suggest", func(t *testing.T) {
+		created, err := iaas.NewServerOp(caller).Create(ctx, zone, &iaas.ServerCreateRequest{
+			CPU:                  small.CPU,
+			MemoryMB:             small.MemoryMB,
+			ServerPlanCommitment: small.Commitment,
+			Name:                 "recommend-test",
+			Tags:                 types.Tags{"recommend-test"},
+		})
+		require.NoError(t, err)
+
+		suggestions, err := recommender.Suggest(ctx, &server.FindRequest{Zone: zone, Tags: []string{"recommend-test"}})
+		require.NoError(t, err)
+		require.Len(t, suggestions, 1)
+		require.Equal(t, created.ID, suggestions[0].Server.ID)
+		require.Equal(t, cost.Price{Hourly: 17, Daily: 172, Monthly: 3425}, *suggestions[0].Current.Price)
+		require.Len(t, suggestions[0].Candidates, 1)
+		require.Equal(t, large.ID, suggestions[0].Candidates[0].Plan.ID)
+	})
+
+	t.Run("suggest other generation", func(t *testing.T) {
+		_, err := iaas.NewServerOp(caller).Create(ctx, zone, &iaas.ServerCreateRequest{
+			CPU:                  small.CPU,
+			MemoryMB:             small.MemoryMB,
+			ServerPlanCommitment: small.Commitment,
+			ServerPlanGeneration: types.PlanGenerations.G200,
+			Name:                 "recommend-test",
+			Tags:                 types.Tags{"recommend-test-generation"},
+		})
+		require.NoError(t, err)
+
+		// 第2世代のプランより同じスペックの第1世代のプランが安価な場合
+		current := *small
+		current.Generation = types.PlanGenerations.G200
+		recommender := recommend.NewRecommender(caller)
+		recommender.Aliases = map[string]string{
+			cost.ServerServiceClass(&current): "cloud/plan/2",
+			cost.ServerServiceClass(small):    "cloud/plan/1",
+		}
+
+		suggestions, err := recommender.Suggest(ctx, &server.FindRequest{Zone: zone, Tags: []string{"recommend-test-generation"}})
+		require.NoError(t, err)
+		require.Len(t, suggestions, 1)
+		require.Equal(t, cost.Price{Hourly: 17, Daily: 172, Monthly: 3425}, *suggestions[0].Current.Price)
+		require.Len(t, suggestions[0].Candidates, 1)
+		require.Equal(t, small.ID, suggestions[0].Candidates[0].Plan.ID)
+		require.Equal(t, cost.Price{Hourly: 10, Daily: 108, Monthly: 2139}, *suggestions[0].Candidates[0].Price)
+	})
+}
@@ -0,0 +1,112 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reference
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+// 参照元の検索は全ページを対象とする。
+// 各リソースのサービスは削除時にこのパッケージを利用するため、サービスのFindAllではなくAPIを直接呼び出す
+
+// findAll findを全ページ分呼び出し、結果をまとめて返す
+func findAll[T any](ctx context.Context, find func(ctx context.Context, condition *iaas.FindCondition) ([]T, error)) ([]T, error) {
+	return service.NewFindIterator(ctx, 0, 0, func(ctx context.Context, from, count int) ([]T, error) {
+		return find(ctx, &iaas.FindCondition{From: from, Count: count})
+	}).All()
+}
+
+func findServers(ctx context.Context, caller iaas.APICaller, zone string) ([]*iaas.Server, error) {
+	return findAll(ctx, func(ctx context.Context, condition *iaas.FindCondition) ([]*iaas.Server, error) {
+		searched, err := iaas.NewServerOp(caller).Find(ctx, zone, condition)
+		if err != nil {
+			return nil, err
+		}
+		return searched.Servers, nil
+	})
+}
+
+func findDisks(ctx context.Context, caller iaas.APICaller, zone string) ([]*iaas.Disk, error) {
+	return findAll(ctx, func(ctx context.Context, condition *iaas.FindCondition) ([]*iaas.Disk, error) {
+		searched, err := iaas.NewDiskOp(caller).Find(ctx, zone, condition)
+		if err != nil {
+			return nil, err
+		}
+		return searched.Disks, nil
+	})
+}
+
+func findArchives(ctx context.Context, caller iaas.APICaller, zone string) ([]*iaas.Archive, error) {
+	return findAll(ctx, func(ctx context.Context, condition *iaas.FindCondition) ([]*iaas.Archive, error) {
+		searched, err := iaas.NewArchiveOp(caller).Find(ctx, zone, condition)
+		if err != nil {
+			return nil, err
+		}
+		return searched.Archives, nil
+	})
+}
+
+func findLoadBalancers(ctx context.Context, caller iaas.APICaller, zone string) ([]*iaas.LoadBalancer, error) {
+	return findAll(ctx, func(ctx context.Context, condition *iaas.FindCondition) ([]*iaas.LoadBalancer, error) {
+		searched, err := iaas.NewLoadBalancerOp(caller).Find(ctx, zone, condition)
+		if err != nil {
+			return nil, err
+		}
+		return searched.LoadBalancers, nil
+	})
+}
+
+func findDatabases(ctx context.Context, caller iaas.APICaller, zone string) ([]*iaas.Database, error) {
+	return findAll(ctx, func(ctx context.Context, condition *iaas.FindCondition) ([]*iaas.Database, error) {
+		searched, err := iaas.NewDatabaseOp(caller).Find(ctx, zone, condition)
+		if err != nil {
+			return nil, err
+		}
+		return searched.Databases, nil
+	})
+}
+
+func findNFS(ctx context.Context, caller iaas.APICaller, zone string) ([]*iaas.NFS, error) {
+	return findAll(ctx, func(ctx context.Context, condition *iaas.FindCondition) ([]*iaas.NFS, error) {
+		searched, err := iaas.NewNFSOp(caller).Find(ctx, zone, condition)
+		if err != nil {
+			return nil, err
+		}
+		return searched.NFS, nil
+	})
+}
+
+func findVPCRouters(ctx context.Context, caller iaas.APICaller, zone string) ([]*iaas.VPCRouter, error) {
+	return findAll(ctx, func(ctx context.Context, condition *iaas.FindCondition) ([]*iaas.VPCRouter, error) {
+		searched, err := iaas.NewVPCRouterOp(caller).Find(ctx, zone, condition)
+		if err != nil {
+			return nil, err
+		}
+		return searched.VPCRouters, nil
+	})
+}
+
+func findMobileGateways(ctx context.Context, caller iaas.APICaller, zone string) ([]*iaas.MobileGateway, error) {
+	return findAll(ctx, func(ctx context.Context, condition *iaas.FindCondition) ([]*iaas.MobileGateway, error) {
+		searched, err := iaas.NewMobileGatewayOp(caller).Find(ctx, zone, condition)
+		if err != nil {
+			return nil, err
+		}
+		return searched.MobileGateways, nil
+	})
+}
@@ -0,0 +1,38 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reference
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindAll(t *testing.T) {
+	var calls int
+	values, err := findAll(context.Background(), func(_ context.Context, condition *iaas.FindCondition) ([]int, error) {
+		calls++
+		var page []int
+		for i := condition.From; i < condition.From+condition.Count && i < 250; i++ {
+			page = append(page, i)
+		}
+		return page, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, values, 250)
+	require.Equal(t, 3, calls)
+}
@@ -0,0 +1,541 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reference リソース間の参照関係を調べ、削除を妨げる参照元リソースを検出する
+package reference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+// Kind 参照先/参照元のリソース種別
+type Kind string
+
+// 参照関係を扱うリソース種別
+//
+// Dependentsが対象として扱えるのはswitch/packetfilter/cdrom/archive/disk/bridge/sim/privatehost/internet
+const (
+	KindServer           = Kind("server")
+	KindDisk             = Kind("disk")
+	KindArchive          = Kind("archive")
+	KindCDROM            = Kind("cdrom")
+	KindSwitch           = Kind("switch")
+	KindPacketFilter     = Kind("packetfilter")
+	KindBridge           = Kind("bridge")
+	KindInternet         = Kind("internet")
+	KindSubnet           = Kind("subnet")
+	KindIPv6Net          = Kind("ipv6net")
+	KindSIM              = Kind("sim")
+	KindPrivateHost      = Kind("privatehost")
+	KindLoadBalancer     = Kind("loadbalancer")
+	KindVPCRouter        = Kind("vpcrouter")
+	KindDatabase         = Kind("database")
+	KindNFS              = Kind("nfs")
+	KindMobileGateway    = Kind("mobilegateway")
+	KindHybridConnection = Kind("hybridconnection")
+)
+
+// Dependent 対象リソースを参照しているリソース
+type Dependent struct {
+	Kind Kind
+	// Zone グローバルリソースの場合は空
+	Zone string
+	ID   types.ID
+	Name string
+	// Relation 参照の内容 例: nic[0] is connected
+	Relation string
+	// Blocking trueの場合この参照が存在する間は対象リソースを削除できない
+	//
+	// コピー元としての参照などはコピー完了後は削除を妨げないためfalseとなる
+	Blocking bool
+}
+
+// String fmt.Stringerの実装
+func (d *Dependent) String() string {
+	s := fmt.Sprintf("%s[%s]", d.Kind, d.ID)
+	if d.Name != "" {
+		s += fmt.Sprintf("(%s)", d.Name)
+	}
+	if d.Zone != "" {
+		s += " in " + d.Zone
+	}
+	return s + ": " + d.Relation
+}
+
+// DependentsError 削除を妨げる参照元リソースが存在する場合のエラー
+type DependentsError struct {
+	Kind       Kind
+	ID         types.ID
+	Dependents []*Dependent
+}
+
+// Error errorの実装
+func (e *DependentsError) Error() string {
+	var refs []string
+	for _, d := range e.Dependents {
+		refs = append(refs, d.String())
+	}
+	return fmt.Sprintf("%s[%s] is referenced by %d resource(s): %s", e.Kind, e.ID, len(e.Dependents), strings.Join(refs, ", "))
+}
+
+// Inspector リソースの参照元を調べる
+type Inspector struct {
+	Caller iaas.APICaller
+	// Zones SIMなどゾーンをまたいで参照されるリソースを調べる際の対象ゾーン
+	//
+	// 空の場合はiaas.SakuraCloudZonesが用いられる
+	Zones []string
+}
+
+// NewInspector Inspectorを作成する
+func NewInspector(caller iaas.APICaller) *Inspector {
+	return &Inspector{Caller: caller}
+}
+
+// Dependents 指定のリソースを参照しているリソースの一覧を返す
+//
+// 対象リソースが存在しない場合はiaas-api-goの404エラーを返す
+func (i *Inspector) Dependents(ctx context.Context, zone string, kind Kind, id types.ID) ([]*Dependent, error) {
+	switch kind {
+	case KindSwitch:
+		return i.switchDependents(ctx, zone, id)
+	case KindPacketFilter:
+		return i.packetFilterDependents(ctx, zone, id)
+	case KindCDROM:
+		return i.cdromDependents(ctx, zone, id)
+	case KindArchive:
+		return i.archiveDependents(ctx, zone, id)
+	case KindDisk:
+		return i.diskDependents(ctx, zone, id)
+	case KindBridge:
+		return i.bridgeDependents(ctx, zone, id)
+	case KindSIM:
+		return i.simDependents(ctx, zone, id)
+	case KindPrivateHost:
+		return i.privateHostDependents(ctx, zone, id)
+	case KindInternet:
+		return i.internetDependents(ctx, zone, id)
+	}
+	return nil, fmt.Errorf("unsupported kind: %s", kind)
+}
+
+// Check 削除を妨げる参照元が存在する場合はErrConflictに分類された*DependentsErrorを返す
+//
+// ignoreに指定した種別の参照元は無視する
+func (i *Inspector) Check(ctx context.Context, zone string, kind Kind, id types.ID, ignore ...Kind) error {
+	dependents, err := i.Dependents(ctx, zone, kind, id)
+	if err != nil {
+		return err
+	}
+	var blocking []*Dependent
+	for _, d := range dependents {
+		if d.Blocking && !containsKind(ignore, d.Kind) {
+			blocking = append(blocking, d)
+		}
+	}
+	if len(blocking) > 0 {
+		return service.NewError(service.ErrConflict, &DependentsError{Kind: kind, ID: id, Dependents: blocking})
+	}
+	return nil
+}
+
+func containsKind(kinds []Kind, kind Kind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *Inspector) switchDependents(ctx context.Context, zone string, id types.ID) ([]*Dependent, error) {
+	sw, err := iaas.NewSwitchOp(i.Caller).Read(ctx, zone, id)
+	if err != nil {
+		return nil, err
+	}
+
+	dependents, err := i.serverDependents(ctx, zone, func(server *iaas.Server) []string {
+		var relations []string
+		for j, nic := range server.Interfaces {
+			if nic.SwitchID == id {
+				relations = append(relations, fmt.Sprintf("nic[%d] is connected", j))
+			}
+		}
+		return relations
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	appliances, err := i.applianceDependents(ctx, zone, id)
+	if err != nil {
+		return nil, err
+	}
+	dependents = append(dependents, appliances...)
+
+	if !sw.BridgeID.IsEmpty() {
+		dependents = append(dependents, &Dependent{
+			Kind:     KindBridge,
+			ID:       sw.BridgeID,
+			Relation: "switch is connected to bridge",
+			Blocking: true,
+		})
+	}
+	if !sw.HybridConnectionID.IsEmpty() {
+		dependents = append(dependents, &Dependent{
+			Kind:     KindHybridConnection,
+			ID:       sw.HybridConnectionID,
+			Relation: "switch is connected to hybrid connection",
+			Blocking: true,
+		})
+	}
+	for _, subnet := range sw.Subnets {
+		if subnet.Internet != nil && !subnet.Internet.ID.IsEmpty() {
+			dependents = append(dependents, &Dependent{
+				Kind:     KindInternet,
+				Zone:     zone,
+				ID:       subnet.Internet.ID,
+				Name:     subnet.Internet.Name,
+				Relation: "switch belongs to router",
+				Blocking: true,
+			})
+			break
+		}
+	}
+	return dependents, nil
+}
+
+func (i *Inspector) applianceDependents(ctx context.Context, zone string, switchID types.ID) ([]*Dependent, error) {
+	var dependents []*Dependent
+	add := func(kind Kind, id types.ID, name, relation string) {
+		dependents = append(dependents, &Dependent{Kind: kind, Zone: zone, ID: id, Name: name, Relation: relation, Blocking: true})
+	}
+
+	lbs, err := findLoadBalancers(ctx, i.Caller, zone)
+	if err != nil {
+		return nil, err
+	}
+	for _, lb := range lbs {
+		if lb.SwitchID == switchID {
+			add(KindLoadBalancer, lb.ID, lb.Name, "is connected")
+		}
+	}
+
+	dbs, err := findDatabases(ctx, i.Caller, zone)
+	if err != nil {
+		return nil, err
+	}
+	for _, db := range dbs {
+		if db.SwitchID == switchID {
+			add(KindDatabase, db.ID, db.Name, "is connected")
+		}
+	}
+
+	nfsList, err := findNFS(ctx, i.Caller, zone)
+	if err != nil {
+		return nil, err
+	}
+	for _, nfs := range nfsList {
+		if nfs.SwitchID == switchID {
+			add(KindNFS, nfs.ID, nfs.Name, "is connected")
+		}
+	}
+
+	routers, err := findVPCRouters(ctx, i.Caller, zone)
+	if err != nil {
+		return nil, err
+	}
+	for _, router := range routers {
+		for _, nic := range router.Interfaces {
+			if nic.SwitchID == switchID {
+				add(KindVPCRouter, router.ID, router.Name, fmt.Sprintf("nic[%d] is connected", nic.Index))
+			}
+		}
+	}
+
+	mgws, err := findMobileGateways(ctx, i.Caller, zone)
+	if err != nil {
+		return nil, err
+	}
+	for _, mgw := range mgws {
+		for _, nic := range mgw.Interfaces {
+			if nic.SwitchID == switchID {
+				add(KindMobileGateway, mgw.ID, mgw.Name, fmt.Sprintf("nic[%d] is connected", nic.Index))
+			}
+		}
+	}
+	return dependents, nil
+}
+
+func (i *Inspector) packetFilterDependents(ctx context.Context, zone string, id types.ID) ([]*Dependent, error) {
+	if _, err := iaas.NewPacketFilterOp(i.Caller).Read(ctx, zone, id); err != nil {
+		return nil, err
+	}
+	return i.serverDependents(ctx, zone, func(server *iaas.Server) []string {
+		var relations []string
+		for j, nic := range server.Interfaces {
+			if nic.PacketFilterID == id {
+				relations = append(relations, fmt.Sprintf("nic[%d] uses packet filter", j))
+			}
+		}
+		return relations
+	})
+}
+
+func (i *Inspector) cdromDependents(ctx context.Context, zone string, id types.ID) ([]*Dependent, error) {
+	if _, err := iaas.NewCDROMOp(i.Caller).Read(ctx, zone, id); err != nil {
+		return nil, err
+	}
+	return i.serverDependents(ctx, zone, func(server *iaas.Server) []string {
+		if server.CDROMID == id {
+			return []string{"cdrom is inserted"}
+		}
+		return nil
+	})
+}
+
+func (i *Inspector) privateHostDependents(ctx context.Context, zone string, id types.ID) ([]*Dependent, error) {
+	if _, err := iaas.NewPrivateHostOp(i.Caller).Read(ctx, zone, id); err != nil {
+		return nil, err
+	}
+	return i.serverDependents(ctx, zone, func(server *iaas.Server) []string {
+		if server.PrivateHostID == id {
+			return []string{"runs on private host"}
+		}
+		return nil
+	})
+}
+
+func (i *Inspector) archiveDependents(ctx context.Context, zone string, id types.ID) ([]*Dependent, error) {
+	if _, err := iaas.NewArchiveOp(i.Caller).Read(ctx, zone, id); err != nil {
+		return nil, err
+	}
+	return i.sourceDependents(ctx, zone, func(sourceArchiveID, _ types.ID) bool {
+		return sourceArchiveID == id
+	}, "is created from archive")
+}
+
+func (i *Inspector) diskDependents(ctx context.Context, zone string, id types.ID) ([]*Dependent, error) {
+	disk, err := iaas.NewDiskOp(i.Caller).Read(ctx, zone, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependents []*Dependent
+	if !disk.ServerID.IsEmpty() {
+		dependents = append(dependents, &Dependent{
+			Kind:     KindServer,
+			Zone:     zone,
+			ID:       disk.ServerID,
+			Name:     disk.ServerName,
+			Relation: "disk is connected",
+			Blocking: true,
+		})
+	}
+	copies, err := i.sourceDependents(ctx, zone, func(_, sourceDiskID types.ID) bool {
+		return sourceDiskID == id
+	}, "is created from disk")
+	if err != nil {
+		return nil, err
+	}
+	return append(dependents, copies...), nil
+}
+
+func (i *Inspector) bridgeDependents(ctx context.Context, zone string, id types.ID) ([]*Dependent, error) {
+	bridge, err := iaas.NewBridgeOp(i.Caller).Read(ctx, zone, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependents []*Dependent
+	for _, info := range bridge.BridgeInfo {
+		dependents = append(dependents, &Dependent{
+			Kind:     KindSwitch,
+			Zone:     info.ZoneName,
+			ID:       info.ID,
+			Name:     info.Name,
+			Relation: "is connected to bridge",
+			Blocking: true,
+		})
+	}
+	return dependents, nil
+}
+
+func (i *Inspector) simDependents(ctx context.Context, zone string, id types.ID) ([]*Dependent, error) {
+	if _, err := iaas.NewSIMOp(i.Caller).Read(ctx, id); err != nil {
+		return nil, err
+	}
+
+	zones := i.Zones
+	if zone != "" {
+		zones = []string{zone}
+	}
+	if len(zones) == 0 {
+		zones = iaas.SakuraCloudZones
+	}
+
+	mgwOp := iaas.NewMobileGatewayOp(i.Caller)
+	var dependents []*Dependent
+	var errs []error
+	for _, z := range zones {
+		mgws, err := findMobileGateways(ctx, i.Caller, z)
+		if err != nil {
+			errs = append(errs, &service.ZoneError{Zone: z, Err: err})
+			continue
+		}
+		for _, mgw := range mgws {
+			sims, err := mgwOp.ListSIM(ctx, z, mgw.ID)
+			if err != nil {
+				if iaas.IsNotFoundError(err) {
+					continue
+				}
+				errs = append(errs, &service.ZoneError{Zone: z, Err: err})
+				continue
+			}
+			for _, sim := range sims {
+				if sim.ResourceID == id.String() {
+					dependents = append(dependents, &Dependent{
+						Kind:     KindMobileGateway,
+						Zone:     z,
+						ID:       mgw.ID,
+						Name:     mgw.Name,
+						Relation: "sim is attached",
+						Blocking: true,
+					})
+				}
+			}
+		}
+	}
+	return dependents, errors.Join(errs...)
+}
+
+func (i *Inspector) internetDependents(ctx context.Context, zone string, id types.ID) ([]*Dependent, error) {
+	internet, err := iaas.NewInternetOp(i.Caller).Read(ctx, zone, id)
+	if err != nil {
+		return nil, err
+	}
+	if internet.Switch == nil {
+		return nil, nil
+	}
+
+	var dependents []*Dependent
+	// 先頭のサブネットはルータ作成時に割り当てられるもので、ルータと共に削除される
+	for j, subnet := range internet.Switch.Subnets {
+		if j == 0 {
+			continue
+		}
+		dependents = append(dependents, &Dependent{
+			Kind:     KindSubnet,
+			Zone:     zone,
+			ID:       subnet.ID,
+			Name:     fmt.Sprintf("%s/%d", subnet.NetworkAddress, subnet.NetworkMaskLen),
+			Relation: "additional subnet is assigned",
+			Blocking: true,
+		})
+	}
+	for _, ipv6net := range internet.Switch.IPv6Nets {
+		dependents = append(dependents, &Dependent{
+			Kind:     KindIPv6Net,
+			Zone:     zone,
+			ID:       ipv6net.ID,
+			Name:     fmt.Sprintf("%s/%d", ipv6net.IPv6Prefix, ipv6net.IPv6PrefixLen),
+			Relation: "ipv6 is enabled",
+			Blocking: true,
+		})
+	}
+
+	servers, err := i.serverDependents(ctx, zone, func(server *iaas.Server) []string {
+		var relations []string
+		for j, nic := range server.Interfaces {
+			if nic.SwitchID == internet.Switch.ID {
+				relations = append(relations, fmt.Sprintf("nic[%d] is connected", j))
+			}
+		}
+		return relations
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(dependents, servers...), nil
+}
+
+// serverDependents relationsが返した参照内容ごとにサーバをDependentとして返す
+func (i *Inspector) serverDependents(ctx context.Context, zone string, relations func(server *iaas.Server) []string) ([]*Dependent, error) {
+	servers, err := findServers(ctx, i.Caller, zone)
+	if err != nil {
+		return nil, err
+	}
+	var dependents []*Dependent
+	for _, server := range servers {
+		for _, relation := range relations(server) {
+			dependents = append(dependents, &Dependent{
+				Kind:     KindServer,
+				Zone:     zone,
+				ID:       server.ID,
+				Name:     server.Name,
+				Relation: relation,
+				Blocking: true,
+			})
+		}
+	}
+	return dependents, nil
+}
+
+// sourceDependents コピー元としてmatchに一致するディスク/アーカイブをDependentとして返す
+//
+// コピー中のもののみ削除を妨げる参照とみなす
+func (i *Inspector) sourceDependents(ctx context.Context, zone string, match func(sourceArchiveID, sourceDiskID types.ID) bool, relation string) ([]*Dependent, error) {
+	disks, err := findDisks(ctx, i.Caller, zone)
+	if err != nil {
+		return nil, err
+	}
+	var dependents []*Dependent
+	for _, disk := range disks {
+		if match(disk.SourceArchiveID, disk.SourceDiskID) {
+			dependents = append(dependents, &Dependent{
+				Kind:     KindDisk,
+				Zone:     zone,
+				ID:       disk.ID,
+				Name:     disk.Name,
+				Relation: relation,
+				Blocking: disk.Availability.IsMigrating(),
+			})
+		}
+	}
+
+	archives, err := findArchives(ctx, i.Caller, zone)
+	if err != nil {
+		return nil, err
+	}
+	for _, archive := range archives {
+		if match(archive.SourceArchiveID, archive.SourceDiskID) {
+			dependents = append(dependents, &Dependent{
+				Kind:     KindArchive,
+				Zone:     zone,
+				ID:       archive.ID,
+				Name:     archive.Name,
+				Relation: relation,
+				Blocking: archive.Availability.IsMigrating(),
+			})
+		}
+	}
+	return dependents, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reference_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/reference"
+	"github.com/sacloud/iaas-service-go/swytch"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspector_Dependents(t *testing.T) {
+	ctx := context.Background()
+	caller := testutil.SingletonAPICaller()
+	zone := testutil.TestZone()
+
+	sw, err := iaas.NewSwitchOp(caller).Create(ctx, zone, &iaas.SwitchCreateRequest{
+		Name: testutil.ResourceName("reference"),
+	})
+	require.NoError(t, err)
+	pf, err := iaas.NewPacketFilterOp(caller).Create(ctx, zone, &iaas.PacketFilterCreateRequest{
+		Name: testutil.ResourceName("reference"),
+	})
+	require.NoError(t, err)
+	server, err := iaas.NewServerOp(caller).Create(ctx, zone, &iaas.ServerCreateRequest{
+		Name:              testutil.ResourceName("reference"),
+		CPU:               1,
+		MemoryMB:          1024,
+		ConnectedSwitches: []*iaas.ConnectedSwitch{{Scope: types.Scopes.Shared}, {ID: sw.ID}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, iaas.NewInterfaceOp(caller).ConnectToPacketFilter(ctx, zone, server.Interfaces[1].ID, pf.ID))
+
+	inspector := reference.NewInspector(caller)
+
+	dependents, err := inspector.Dependents(ctx, zone, reference.KindSwitch, sw.ID)
+	require.NoError(t, err)
+	require.Len(t, dependents, 1)
+	require.Equal(t, reference.KindServer, dependents[0].Kind)
+	require.Equal(t, server.ID, dependents[0].ID)
+	require.Equal(t, "nic[1] is connected", dependents[0].Relation)
+	require.True(t, dependents[0].Blocking)
+
+	dependents, err = inspector.Dependents(ctx, zone, reference.KindPacketFilter, pf.ID)
+	require.NoError(t, err)
+	require.Len(t, dependents, 1)
+	require.Equal(t, server.ID, dependents[0].ID)
+
+	err = inspector.Check(ctx, zone, reference.KindSwitch, sw.ID)
+	require.True(t, errors.Is(err, service.ErrConflict))
+	var depErr *reference.DependentsError
+	require.True(t, errors.As(err, &depErr))
+	require.Len(t, depErr.Dependents, 1)
+	require.Contains(t, err.Error(), server.ID.String())
+
+	// 未対応の種別
+	_, err = inspector.Dependents(ctx, zone, reference.KindServer, server.ID)
+	require.Error(t, err)
+
+	// FailIfReferencedが指定された場合は参照されている間は削除しない
+	svc := swytch.New(caller)
+	err = svc.Delete(&swytch.DeleteRequest{Zone: zone, ID: sw.ID, FailIfReferenced: true})
+	require.True(t, errors.Is(err, service.ErrConflict))
+	_, err = iaas.NewSwitchOp(caller).Read(ctx, zone, sw.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, iaas.NewServerOp(caller).Delete(ctx, zone, server.ID))
+	require.NoError(t, inspector.Check(ctx, zone, reference.KindSwitch, sw.ID))
+	require.NoError(t, svc.Delete(&swytch.DeleteRequest{Zone: zone, ID: sw.ID, FailIfReferenced: true}))
+}
@@ -0,0 +1,198 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// UndoFunc 作成したリソースを削除するなど、処理を取り消すための関数
+type UndoFunc func(ctx context.Context) error
+
+// Tracker 構築中に作成したリソースを記録し、失敗時に逆順で取り消す
+type Tracker struct {
+	steps []*step
+	mu    sync.Mutex
+}
+
+type step struct {
+	resource string
+	id       types.ID
+	undo     UndoFunc
+}
+
+// NewTracker 空のTrackerを返す
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+// Track 取り消し処理を記録する
+func (t *Tracker) Track(resource string, id types.ID, undo UndoFunc) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.steps = append(t.steps, &step{resource: resource, id: id, undo: undo})
+}
+
+// Untrack 記録済みの取り消し処理を破棄する
+//
+// 構築処理の中で既にリソースを削除した場合などに利用する
+func (t *Tracker) Untrack(resource string, id types.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var steps []*step
+	for _, s := range t.steps {
+		if s.resource != resource || s.id != id {
+			steps = append(steps, s)
+		}
+	}
+	t.steps = steps
+}
+
+// Rollback 記録された取り消し処理を記録とは逆の順番で実行する
+//
+// 途中の取り消し処理が失敗した場合も残りの取り消し処理は実行される。
+// 取り消し対象のリソースが既に存在しない(404)場合は成功とみなす。
+// ctxがキャンセルされている場合でも取り消し処理は実行される。
+func (t *Tracker) Rollback(ctx context.Context) *Result {
+	t.mu.Lock()
+	steps := t.steps
+	t.steps = nil
+	t.mu.Unlock()
+
+	ctx = context.WithoutCancel(ctx)
+	result := &Result{}
+	for i := len(steps) - 1; i >= 0; i-- {
+		s := steps[i]
+		err := s.undo(ctx)
+		if err != nil && iaas.IsNotFoundError(err) {
+			err = nil
+		}
+		result.Steps = append(result.Steps, &StepResult{Resource: s.resource, ID: s.id, Err: err})
+	}
+	return result
+}
+
+// Result ロールバックの結果
+type Result struct {
+	// Steps 実行順の取り消し処理の結果
+	Steps []*StepResult
+}
+
+// StepResult 取り消し処理ごとの結果
+type StepResult struct {
+	Resource string
+	ID       types.ID
+	// Err 取り消しに失敗した場合のエラー、成功した場合はnil
+	Err error
+}
+
+// Succeeded 全ての取り消し処理が成功したか
+func (r *Result) Succeeded() bool {
+	return r.Err() == nil
+}
+
+// Failed 失敗した取り消し処理を返す
+func (r *Result) Failed() []*StepResult {
+	var failed []*StepResult
+	for _, s := range r.Steps {
+		if s.Err != nil {
+			failed = append(failed, s)
+		}
+	}
+	return failed
+}
+
+// Err 失敗した取り消し処理のエラーをまとめて返す、全て成功した場合はnil
+func (r *Result) Err() error {
+	var errs []error
+	for _, s := range r.Failed() {
+		errs = append(errs, fmt.Errorf("%s[%s]: %w", s.Resource, s.ID, s.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// Error ロールバックを行った場合に返されるエラー
+//
+// Unwrapで構築処理の元のエラーを返す
+type Error struct {
+	Err    error
+	Result *Result
+}
+
+// Error errorの実装
+func (e *Error) Error() string {
+	if err := e.Result.Err(); err != nil {
+		return fmt.Sprintf("%s (rollback failed: %s)", e.Err, strings.ReplaceAll(err.Error(), "\n", ", "))
+	}
+	return fmt.Sprintf("%s (rolled back)", e.Err)
+}
+
+// Unwrap 構築処理の元のエラーを返す
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+type trackerKey struct{}
+
+// WithTracker Trackerを設定したcontextを返す
+func WithTracker(ctx context.Context, tracker *Tracker) context.Context {
+	return context.WithValue(ctx, trackerKey{}, tracker)
+}
+
+// TrackerFromContext contextに設定されたTrackerを返す、未設定の場合はnil
+func TrackerFromContext(ctx context.Context) *Tracker {
+	if tracker, ok := ctx.Value(trackerKey{}).(*Tracker); ok {
+		return tracker
+	}
+	return nil
+}
+
+// Track contextに設定されたTrackerへ取り消し処理を記録する
+//
+// Trackerが設定されていない場合は何もしない
+func Track(ctx context.Context, resource string, id types.ID, undo UndoFunc) {
+	if tracker := TrackerFromContext(ctx); tracker != nil {
+		tracker.Track(resource, id, undo)
+	}
+}
+
+// Untrack contextに設定されたTrackerから取り消し処理を破棄する
+func Untrack(ctx context.Context, resource string, id types.ID) {
+	if tracker := TrackerFromContext(ctx); tracker != nil {
+		tracker.Untrack(resource, id)
+	}
+}
+
+// Run ctxにTrackerが設定されていない場合は新たなTrackerを設定してfnを実行し、fnがエラーを返した場合はロールバックする
+//
+// 既にTrackerが設定されている場合はロールバックを呼び出し元に任せ、fnの結果をそのまま返す。
+// ロールバックを行った場合は*Errorを返す。
+func Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	if TrackerFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+	tracker := NewTracker()
+	if err := fn(WithTracker(ctx, tracker)); err != nil {
+		return &Error{Err: err, Result: tracker.Rollback(ctx)}
+	}
+	return nil
+}
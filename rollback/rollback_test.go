@@ -0,0 +1,72 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollback
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no error", func(t *testing.T) {
+		var undone []string
+		err := Run(ctx, func(ctx context.Context) error {
+			Track(ctx, "server", 1, func(context.Context) error {
+				undone = append(undone, "server")
+				return nil
+			})
+			return nil
+		})
+		require.NoError(t, err)
+		require.Empty(t, undone)
+	})
+
+	t.Run("rollback in reverse order", func(t *testing.T) {
+		var undone []string
+		undo := func(name string, err error) UndoFunc {
+			return func(context.Context) error {
+				undone = append(undone, name)
+				return err
+			}
+		}
+		buildErr := errors.New("build error")
+
+		err := Run(ctx, func(ctx context.Context) error {
+			Track(ctx, "server", 1, undo("server", nil))
+			Track(ctx, "disk", 2, undo("disk", errors.New("delete error")))
+			Track(ctx, "note", 3, undo("note", nil))
+			Untrack(ctx, "note", 3)
+			return buildErr
+		})
+
+		require.ErrorIs(t, err, buildErr)
+		require.Equal(t, []string{"disk", "server"}, undone)
+
+		var rbErr *Error
+		require.True(t, errors.As(err, &rbErr))
+		require.False(t, rbErr.Result.Succeeded())
+		require.Len(t, rbErr.Result.Steps, 2)
+		failed := rbErr.Result.Failed()
+		require.Len(t, failed, 1)
+		require.Equal(t, types.ID(2), failed[0].ID)
+		require.Equal(t, "build error (rollback failed: disk[2]: delete error)", err.Error())
+	})
+}
@@ -0,0 +1,111 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	lowerLetters = "abcdefghijklmnopqrstuvwxyz"
+	upperLetters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digits       = "0123456789"
+)
+
+var errInvalidPolicy = errors.New("invalid password policy")
+
+// DefaultPolicy Resolver.Policyのデフォルト値
+var DefaultPolicy = &Policy{
+	Length:    20,
+	MinLower:  1,
+	MinUpper:  1,
+	MinDigits: 1,
+}
+
+// Policy 生成するパスワードのポリシー
+type Policy struct {
+	// Length 長さ
+	Length int
+	// Symbols 記号として利用する文字、空の場合は記号を含めない
+	Symbols string
+
+	// MinLower 英小文字の最小文字数
+	MinLower int
+	// MinUpper 英大文字の最小文字数
+	MinUpper int
+	// MinDigits 数字の最小文字数
+	MinDigits int
+	// MinSymbols 記号の最小文字数
+	MinSymbols int
+}
+
+// Generate ポリシーに従ってランダムなパスワードを生成する
+func (p *Policy) Generate() (string, error) {
+	if p.Length <= 0 || p.MinLower+p.MinUpper+p.MinDigits+p.MinSymbols > p.Length {
+		return "", fmt.Errorf("%w: length %d is too short", errInvalidPolicy, p.Length)
+	}
+	if p.MinSymbols > 0 && p.Symbols == "" {
+		return "", fmt.Errorf("%w: symbols are required but not specified", errInvalidPolicy)
+	}
+
+	var chars []byte
+	for _, set := range []struct {
+		letters string
+		min     int
+	}{
+		{lowerLetters, p.MinLower},
+		{upperLetters, p.MinUpper},
+		{digits, p.MinDigits},
+		{p.Symbols, p.MinSymbols},
+	} {
+		for i := 0; i < set.min; i++ {
+			c, err := randomChar(set.letters)
+			if err != nil {
+				return "", err
+			}
+			chars = append(chars, c)
+		}
+	}
+
+	all := lowerLetters + upperLetters + digits + p.Symbols
+	for len(chars) < p.Length {
+		c, err := randomChar(all)
+		if err != nil {
+			return "", err
+		}
+		chars = append(chars, c)
+	}
+
+	// 文字種ごとに先頭へ並ばないようシャッフルする
+	for i := len(chars) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		chars[i], chars[j.Int64()] = chars[j.Int64()], chars[i]
+	}
+	return string(chars), nil
+}
+
+func randomChar(letters string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(letters))))
+	if err != nil {
+		return 0, err
+	}
+	return letters[n.Int64()], nil
+}
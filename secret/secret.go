@@ -0,0 +1,435 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secret リクエスト中のパスワードなどのシークレットの参照を実行時に解決する
+//
+// シークレットのフィールドには実際の値の代わりに"<スキーム>:<参照>"の形式の参照を指定できる。
+//
+//	env:DB_PASS              環境変数DB_PASSの値
+//	file:/run/secrets/db     ファイルの内容(末尾の改行は取り除く)
+//	generate:                Resolver.Policyに従って生成したランダムな値、"generate:32"のように長さを指定できる
+//
+// Resolver.Providersに登録されていないスキームで始まる値はそのまま実際の値として扱う。
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	service "github.com/sacloud/iaas-service-go"
+)
+
+// GenerateScheme ランダムな値を生成する参照のスキーム
+const GenerateScheme = "generate"
+
+// RedactedValue 秘匿情報を置き換える値
+const RedactedValue = "********"
+
+// SensitiveFields シークレットとして扱うフィールド名に含まれる文字列、大文字小文字は区別しない
+//
+// これらのフィールドの値は参照の解決とRedact/Redactedによる伏せ字の対象となる
+var SensitiveFields = []string{
+	"password",
+	"passcode",
+	"passphrase",
+	"secret",
+	"token",
+	"privatekey",
+	"apikey",
+}
+
+// GeneratableFields 値の生成対象となるフィールド名に含まれる文字列、大文字小文字は区別しない
+var GeneratableFields = []string{
+	"password",
+	"passcode",
+}
+
+// UnusedSecrets 設定により送信されないシークレットのフィールドを返すリクエストが実装するインターフェース
+//
+// 返したフィールドはGenerateOmittedによる値の生成対象外となる
+type UnusedSecrets interface {
+	// UnusedSecretFields 送信されないフィールドのパスのリスト 例: ReplicaUserPassword
+	UnusedSecretFields() []string
+}
+
+// Provider 参照をシークレットの値に解決する
+type Provider interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// ProviderFunc 関数をProviderとして扱うための型
+type ProviderFunc func(ctx context.Context, ref string) (string, error)
+
+// Resolve Providerの実装
+func (f ProviderFunc) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+// Env 環境変数の値を返すProvider
+var Env Provider = ProviderFunc(func(_ context.Context, name string) (string, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return v, nil
+})
+
+// File ファイルの内容を返すProvider、末尾の改行は取り除く
+var File Provider = ProviderFunc(func(_ context.Context, path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+})
+
+// Generated 生成したシークレットの値
+type Generated struct {
+	// Field フィールドのパス 例: RouterSetting.RemoteAccessUsers[0].Password
+	Field string
+	Value string
+}
+
+// Resolver リクエスト中のシークレットの参照を解決する
+type Resolver struct {
+	// Providers スキームごとのProvider
+	Providers map[string]Provider
+	// Policy 値を生成する際のポリシー、省略時はDefaultPolicy
+	Policy *Policy
+	// GenerateOmitted trueの場合、新規作成となるリクエストで空のパスワードフィールドに値を生成する
+	//
+	// IDが指定されたリクエスト(既存リソースに対するApplyなど)では現在の値を変更しないよう生成しない。
+	// またリクエストがUnusedSecretsを実装している場合、送信されないフィールドには生成しない
+	GenerateOmitted bool
+	// OnGenerated Interceptorが値を生成した場合に呼び出される関数
+	//
+	// 生成した値を受け取れるのはこの関数のみとなる。途中まで作成されたリソースに設定されている可能性があるため、
+	// 操作が失敗した場合も呼び出される
+	OnGenerated func(ctx context.Context, inv *service.Invocation, generated []*Generated)
+}
+
+// NewResolver env/fileのProviderを登録したResolverを返す
+func NewResolver() *Resolver {
+	return &Resolver{
+		Providers: map[string]Provider{
+			"env":  Env,
+			"file": File,
+		},
+	}
+}
+
+// Result Resolveの結果
+type Result struct {
+	// Generated 生成した値
+	Generated []*Generated
+
+	values  []string
+	restore []func()
+}
+
+// Restore リクエストを解決前の値に戻す
+func (r *Result) Restore() {
+	for i := len(r.restore) - 1; i >= 0; i-- {
+		r.restore[i]()
+	}
+	r.restore = nil
+}
+
+// minRedactLength エラーメッセージから伏せる値の最小長、短い値はメッセージ中の無関係な文字列と一致しやすいため対象外とする
+const minRedactLength = 4
+
+// RedactError errのメッセージにシークレットの値が含まれる場合は値を伏せたエラーを返す
+//
+// 返すエラーはerrors.Is/errors.Asで元のエラーを参照できる
+func (r *Result) RedactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	redacted := msg
+	for _, v := range r.values {
+		if len(v) >= minRedactLength {
+			redacted = strings.ReplaceAll(redacted, v, RedactedValue)
+		}
+	}
+	if redacted == msg {
+		return err
+	}
+	return &redactedError{msg: redacted, err: err}
+}
+
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// Resolve req中のシークレットの参照を解決し、フィールドを実際の値に書き換える
+//
+// reqには構造体へのポインタを指定する。GenerateOmittedがtrueかつreqのIDが空の場合は空のパスワードフィールドに値を生成する。
+// エクスポート時のservice.SecretPlaceholderが残っている場合はバリデーションエラーとなる。
+// 書き換えたフィールドは戻り値のRestoreで元に戻せる。
+func (r *Resolver) Resolve(ctx context.Context, req interface{}) (*Result, error) {
+	return r.resolve(ctx, req, r.GenerateOmitted)
+}
+
+func (r *Resolver) resolve(ctx context.Context, req interface{}, generate bool) (*Result, error) {
+	result := &Result{}
+	w := &walker{resolver: r, result: result, visited: make(map[uintptr]bool)}
+	if _, id := service.TargetOf(req); generate && id.IsEmpty() {
+		w.generate = true
+		if v, ok := req.(UnusedSecrets); ok {
+			w.unused = make(map[string]bool)
+			for _, field := range v.UnusedSecretFields() {
+				w.unused[field] = true
+			}
+		}
+	}
+	if err := w.walk(ctx, reflect.ValueOf(req), ""); err != nil {
+		result.Restore()
+		return nil, err
+	}
+	return result, nil
+}
+
+// Interceptor 操作のリクエスト中のシークレットの参照を解決するInterceptorを返す
+//
+// 操作の完了後にリクエストは解決前の値に戻される。また操作が返したエラーのメッセージからシークレットの値を伏せる。
+func (r *Resolver) Interceptor() service.Interceptor {
+	return func(ctx context.Context, inv *service.Invocation, next service.Handler) (interface{}, error) {
+		if inv.Request == nil {
+			return next(ctx, inv)
+		}
+
+		generate := r.GenerateOmitted && (strings.HasPrefix(inv.Operation, "create") || strings.HasPrefix(inv.Operation, "apply"))
+		result, err := r.resolve(ctx, inv.Request, generate)
+		if err != nil {
+			return nil, err
+		}
+		defer result.Restore()
+
+		res, err := next(ctx, inv)
+		if len(result.Generated) > 0 && r.OnGenerated != nil {
+			r.OnGenerated(ctx, inv, result.Generated)
+		}
+		return res, result.RedactError(err)
+	}
+}
+
+func (r *Resolver) policy() *Policy {
+	if r.Policy == nil {
+		return DefaultPolicy
+	}
+	return r.Policy
+}
+
+type walker struct {
+	resolver *Resolver
+	generate bool
+	unused   map[string]bool
+	result   *Result
+	visited  map[uintptr]bool
+}
+
+func (w *walker) walk(ctx context.Context, v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || w.visited[v.Pointer()] {
+			return nil
+		}
+		w.visited[v.Pointer()] = true
+		return w.walk(ctx, v.Elem(), path)
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return w.walk(ctx, v.Elem(), path)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name := f.Name
+			if path != "" {
+				name = path + "." + f.Name
+			}
+			fv := v.Field(i)
+			if containsAny(f.Name, SensitiveFields) && isString(fv) {
+				if err := w.resolveField(ctx, fv, name, containsAny(f.Name, GeneratableFields)); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := w.walk(ctx, fv, name); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := w.walk(ctx, v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *walker) resolveField(ctx context.Context, fv reflect.Value, path string, generatable bool) error {
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return nil
+		}
+		fv = fv.Elem()
+	}
+	if !fv.CanSet() {
+		return nil
+	}
+
+	original := fv.String()
+	var value string
+	switch {
+	case original == "":
+		if !w.generate || !generatable || w.unused[path] {
+			return nil
+		}
+		generated, err := w.resolver.policy().Generate()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		value = generated
+		w.result.Generated = append(w.result.Generated, &Generated{Field: path, Value: value})
+	case original == service.SecretPlaceholder:
+		return service.ValidationError(fmt.Errorf("%s: %q must be replaced with the actual value or a secret reference", path, service.SecretPlaceholder))
+	default:
+		resolved, err := w.resolveValue(ctx, original, path)
+		if err != nil {
+			return err
+		}
+		value = resolved
+	}
+
+	w.result.values = append(w.result.values, value)
+	if value != original {
+		fv.SetString(value)
+		w.result.restore = append(w.result.restore, func() { fv.SetString(original) })
+	}
+	return nil
+}
+
+func (w *walker) resolveValue(ctx context.Context, value, path string) (string, error) {
+	scheme, ref, ok := strings.Cut(value, ":")
+	if !ok {
+		return value, nil
+	}
+
+	if scheme == GenerateScheme {
+		policy := *w.resolver.policy()
+		if ref != "" {
+			length, err := strconv.Atoi(ref)
+			if err != nil {
+				return "", service.ValidationError(fmt.Errorf("%s: invalid length for %s reference: %q", path, GenerateScheme, ref))
+			}
+			policy.Length = length
+		}
+		generated, err := policy.Generate()
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", path, err)
+		}
+		w.result.Generated = append(w.result.Generated, &Generated{Field: path, Value: generated})
+		return generated, nil
+	}
+
+	provider, ok := w.resolver.Providers[scheme]
+	if !ok {
+		return value, nil
+	}
+	resolved, err := provider.Resolve(ctx, ref)
+	if err != nil {
+		return "", service.ValidationError(fmt.Errorf("%s: resolving %s reference: %w", path, scheme, err))
+	}
+	return resolved, nil
+}
+
+func isString(v reflect.Value) bool {
+	return v.Kind() == reflect.String || (v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.String)
+}
+
+func containsAny(name string, substrings []string) bool {
+	name = strings.ToLower(name)
+	for _, s := range substrings {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact vをmapに変換し、fieldsに該当するフィールドの値を伏せて返す
+func Redact(v interface{}, fields []string) map[string]interface{} {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil
+	}
+	redact(values, fields)
+	return values
+}
+
+func redact(v interface{}, fields []string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if containsAny(key, fields) && value != nil && value != "" {
+				v[key] = RedactedValue
+				continue
+			}
+			redact(value, fields)
+		}
+	case []interface{}:
+		for _, value := range v {
+			redact(value, fields)
+		}
+	}
+}
+
+// Redacted vをJSONに変換し、SensitiveFieldsに該当するフィールドの値を伏せた文字列を返す
+//
+// リクエストのString()の実装に利用する
+func Redacted(v interface{}) string {
+	data, err := json.Marshal(Redact(v, SensitiveFields))
+	if err != nil {
+		return fmt.Sprintf("%T", v)
+	}
+	return string(data)
+}
@@ -0,0 +1,217 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode"
+
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/database"
+	"github.com/sacloud/iaas-service-go/secret"
+	"github.com/stretchr/testify/require"
+)
+
+type user struct {
+	UserName string
+	Password string
+}
+
+type request struct {
+	Name            string
+	Password        string
+	ReplicaPassword *string
+	PreSharedSecret string
+	Users           []*user
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("TEST_DB_PASS", "db-password")
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte("file-secret\n"), 0600))
+
+	replica := "generate:8"
+	req := &request{
+		Name:            "env:NOT_A_SECRET",
+		Password:        "env:TEST_DB_PASS",
+		ReplicaPassword: &replica,
+		PreSharedSecret: "file:" + path,
+		Users: []*user{
+			{UserName: "literal", Password: "unknown:value"},
+			{UserName: "omitted"},
+		},
+	}
+
+	resolver := secret.NewResolver()
+	resolver.GenerateOmitted = true
+	result, err := resolver.Resolve(ctx, req)
+	require.NoError(t, err)
+
+	require.Equal(t, "env:NOT_A_SECRET", req.Name)
+	require.Equal(t, "db-password", req.Password)
+	require.Len(t, *req.ReplicaPassword, 8)
+	require.Equal(t, "file-secret", req.PreSharedSecret)
+	require.Equal(t, "unknown:value", req.Users[0].Password)
+	require.Len(t, req.Users[1].Password, secret.DefaultPolicy.Length)
+
+	require.Len(t, result.Generated, 2)
+	require.Equal(t, "ReplicaPassword", result.Generated[0].Field)
+	require.Equal(t, "Users[1].Password", result.Generated[1].Field)
+	require.Equal(t, req.Users[1].Password, result.Generated[1].Value)
+
+	err = result.RedactError(fmt.Errorf("invalid password: %s", req.Password))
+	require.Equal(t, "invalid password: "+secret.RedactedValue, err.Error())
+
+	result.Restore()
+	require.Equal(t, "env:TEST_DB_PASS", req.Password)
+	require.Equal(t, "generate:8", *req.ReplicaPassword)
+	require.Empty(t, req.Users[1].Password)
+}
+
+func TestResolver_Resolve_errors(t *testing.T) {
+	ctx := context.Background()
+	resolver := secret.NewResolver()
+
+	_, err := resolver.Resolve(ctx, &request{Password: "env:TEST_SECRET_NOT_EXIST"})
+	require.True(t, errors.Is(err, service.ErrValidationFailed))
+	require.Contains(t, err.Error(), "Password")
+
+	_, err = resolver.Resolve(ctx, &request{Password: service.SecretPlaceholder})
+	require.True(t, errors.Is(err, service.ErrValidationFailed))
+
+	// 解決に失敗した場合は書き換えたフィールドを元に戻す
+	t.Setenv("TEST_DB_PASS", "db-password")
+	req := &request{Password: "env:TEST_DB_PASS", PreSharedSecret: "generate:x"}
+	_, err = resolver.Resolve(ctx, req)
+	require.Error(t, err)
+	require.Equal(t, "env:TEST_DB_PASS", req.Password)
+}
+
+func TestResolver_Interceptor(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("TEST_DB_PASS", "db-password")
+
+	var generated []*secret.Generated
+	resolver := secret.NewResolver()
+	resolver.GenerateOmitted = true
+	resolver.OnGenerated = func(_ context.Context, _ *service.Invocation, g []*secret.Generated) {
+		generated = g
+	}
+	interceptors := []service.Interceptor{resolver.Interceptor()}
+
+	req := &request{Password: "env:TEST_DB_PASS", Users: []*user{{UserName: "user"}}}
+	_, err := service.Invoke(ctx, interceptors, "test", "create", req, func(ctx context.Context, req *request) (interface{}, error) {
+		require.Equal(t, "db-password", req.Password)
+		require.NotEmpty(t, req.Users[0].Password)
+		return nil, fmt.Errorf("rejected: %s/%s", req.Password, req.Users[0].Password)
+	})
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "db-password")
+	require.Len(t, generated, 1)
+	require.NotContains(t, err.Error(), generated[0].Value)
+
+	var serviceErr *service.Error
+	require.True(t, errors.As(err, &serviceErr))
+	require.Equal(t, "env:TEST_DB_PASS", req.Password)
+
+	// create/apply以外の操作では値を生成しない
+	generated = nil
+	_, err = service.Invoke(ctx, interceptors, "test", "update", req, func(ctx context.Context, req *request) (interface{}, error) {
+		require.Empty(t, req.Users[0].Password)
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.Empty(t, generated)
+
+	// 既存リソースに対するapplyでは現在のパスワードを変更しないよう生成しない
+	applyReq := &database.ApplyRequest{ID: 1, Name: "example"}
+	_, err = service.Invoke(ctx, interceptors, "database", "apply", applyReq, func(ctx context.Context, req *database.ApplyRequest) (interface{}, error) {
+		require.Empty(t, req.Password)
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.Empty(t, generated)
+
+	// 送信されないフィールドには生成しない
+	applyReq.ID = 0
+	_, err = service.Invoke(ctx, interceptors, "database", "apply", applyReq, func(ctx context.Context, req *database.ApplyRequest) (interface{}, error) {
+		require.NotEmpty(t, req.Password)
+		require.Empty(t, req.ReplicaUserPassword)
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, generated, 1)
+	require.Equal(t, "Password", generated[0].Field)
+
+	applyReq.EnableReplication = true
+	_, err = service.Invoke(ctx, interceptors, "database", "apply", applyReq, func(ctx context.Context, req *database.ApplyRequest) (interface{}, error) {
+		require.NotEmpty(t, req.ReplicaUserPassword)
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, generated, 2)
+}
+
+func TestPolicy_Generate(t *testing.T) {
+	policy := &secret.Policy{Length: 16, Symbols: "!#", MinLower: 2, MinUpper: 2, MinDigits: 2, MinSymbols: 2}
+	for i := 0; i < 10; i++ {
+		v, err := policy.Generate()
+		require.NoError(t, err)
+		require.Len(t, v, 16)
+
+		var lower, upper, digit, symbol int
+		for _, c := range v {
+			switch {
+			case unicode.IsLower(c):
+				lower++
+			case unicode.IsUpper(c):
+				upper++
+			case unicode.IsDigit(c):
+				digit++
+			case strings.ContainsRune(policy.Symbols, c):
+				symbol++
+			}
+		}
+		require.GreaterOrEqual(t, lower, 2)
+		require.GreaterOrEqual(t, upper, 2)
+		require.GreaterOrEqual(t, digit, 2)
+		require.GreaterOrEqual(t, symbol, 2)
+	}
+
+	_, err := (&secret.Policy{Length: 2, MinLower: 1, MinUpper: 1, MinDigits: 1}).Generate()
+	require.Error(t, err)
+	_, err = (&secret.Policy{Length: 8, MinSymbols: 1}).Generate()
+	require.Error(t, err)
+}
+
+func TestRedacted(t *testing.T) {
+	req := &database.CreateRequest{
+		Name:                "example",
+		Password:            "db-password",
+		ReplicaUserPassword: "replica-password",
+	}
+	for _, s := range []string{req.String(), fmt.Sprintf("%v", req), fmt.Sprintf("%s", req)} {
+		require.Contains(t, s, "example")
+		require.NotContains(t, s, "db-password")
+		require.NotContains(t, s, "replica-password")
+	}
+}
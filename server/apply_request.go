@@ -15,12 +15,16 @@
 package server
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
 	diskService "github.com/sacloud/iaas-service-go/disk"
 	diskBuilder "github.com/sacloud/iaas-service-go/disk/builder"
+	"github.com/sacloud/iaas-service-go/lock"
 	server "github.com/sacloud/iaas-service-go/server/builder"
 	"github.com/sacloud/packages-go/validate"
 )
@@ -50,6 +54,9 @@ type ApplyRequest struct {
 	NoWait            bool
 
 	ForceShutdown bool
+
+	// Lock 指定した場合、既存サーバの更新前にタグを用いたロックを取得し、完了後に解放する
+	Lock *lock.Locker `service:"-" json:"-"`
 }
 
 func (req *ApplyRequest) Validate() error {
@@ -68,6 +75,65 @@ func (req *ApplyRequest) Validate() error {
 	return nil
 }
 
+// Preflight 参照先のリソースやサーバプランを確認し、検出した全ての問題を返す
+//
+// リソースの作成や更新は行わない
+func (req *ApplyRequest) Preflight(ctx context.Context, caller iaas.APICaller) error {
+	p := service.NewPreflight()
+
+	p.Ref("ID", "server", req.Zone, req.ID, func() error {
+		_, err := iaas.NewServerOp(caller).Read(ctx, req.Zone, req.ID)
+		return err
+	})
+	p.Ref("IconID", "icon", "", req.IconID, func() error {
+		_, err := iaas.NewIconOp(caller).Read(ctx, req.IconID)
+		return err
+	})
+	p.Ref("CDROMID", "cdrom", req.Zone, req.CDROMID, func() error {
+		_, err := iaas.NewCDROMOp(caller).Read(ctx, req.Zone, req.CDROMID)
+		return err
+	})
+	p.Ref("PrivateHostID", "private host", req.Zone, req.PrivateHostID, func() error {
+		_, err := iaas.NewPrivateHostOp(caller).Read(ctx, req.Zone, req.PrivateHostID)
+		return err
+	})
+
+	builder, err := req.Builder(caller)
+	if err != nil {
+		return err
+	}
+	p.Check("CPU", func() error {
+		if _, err := builder.FindServerPlan(ctx, req.Zone); err != nil {
+			return fmt.Errorf("cpu:%d memory:%dGB gpu:%d: %s", builder.CPU, builder.MemoryGB, builder.GPU, err)
+		}
+		return nil
+	})
+
+	for i, nic := range req.NetworkInterfaces {
+		switchID := types.ID(0)
+		if nic.Upstream != "shared" && nic.Upstream != "disconnected" {
+			switchID = types.StringID(nic.Upstream)
+		}
+		p.Ref(fmt.Sprintf("NetworkInterfaces[%d].Upstream", i), "switch", req.Zone, switchID, func() error {
+			_, err := iaas.NewSwitchOp(caller).Read(ctx, req.Zone, switchID)
+			return err
+		})
+		p.Ref(fmt.Sprintf("NetworkInterfaces[%d].PacketFilterID", i), "packet filter", req.Zone, nic.PacketFilterID, func() error {
+			_, err := iaas.NewPacketFilterOp(caller).Read(ctx, req.Zone, nic.PacketFilterID)
+			return err
+		})
+	}
+
+	for i, d := range req.Disks {
+		// ディスクはサーバと同じゾーンに作成される
+		diskReq := *d
+		diskReq.Zone = req.Zone
+		p.Merge(fmt.Sprintf("Disks[%d].", i), diskReq.Preflight(ctx, caller))
+	}
+
+	return p.Err()
+}
+
 func (req *ApplyRequest) nicSetting() server.NICSettingHolder {
 	if len(req.NetworkInterfaces) == 0 {
 		return nil
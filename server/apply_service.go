@@ -18,6 +18,9 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/hook"
+	"github.com/sacloud/iaas-service-go/lock"
 	serverBuilder "github.com/sacloud/iaas-service-go/server/builder"
 )
 
@@ -26,8 +29,12 @@ func (s *Service) Apply(req *ApplyRequest) (*iaas.Server, error) {
 }
 
 func (s *Service) ApplyWithContext(ctx context.Context, req *ApplyRequest) (*iaas.Server, error) {
+	return service.Invoke(ctx, s.interceptors, "server", "apply", req, s.apply)
+}
+
+func (s *Service) apply(ctx context.Context, req *ApplyRequest) (*iaas.Server, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	builder, err := req.Builder(s.caller)
@@ -35,6 +42,33 @@ func (s *Service) ApplyWithContext(ctx context.Context, req *ApplyRequest) (*iaa
 		return nil, err
 	}
 
+	var held *lock.Held
+	if req.Lock != nil && !req.ID.IsEmpty() {
+		held, err = req.Lock.Acquire(ctx, lock.NewServerTarget(s.caller, req.Zone, req.ID))
+		if err != nil {
+			return nil, err
+		}
+		// 解放に失敗した場合もリースは有効期限を過ぎると失効するためエラーとしない
+		defer held.Release(ctx) //nolint:errcheck
+		// 更新中にロックが外れないよう、更新後のタグにもリースを含める
+		builder.Tags = lock.WithLease(builder.Tags, held.Lease)
+
+		// 変更を伴う処理の前ごとにリースが他の保持者に上書きされていないか確認する
+		// プラン変更後のBeforeBootではeventのIDが変更後のサーバのIDとなる
+		verify := func(ctx context.Context, event *hook.Event) error {
+			if event.ID != req.ID {
+				held.Move(lock.NewServerTarget(s.caller, req.Zone, event.ID))
+			}
+			return held.Verify(ctx)
+		}
+		builder.Hooks = hook.New().
+			On(hook.BeforeShutdown, verify).
+			On(hook.BeforeBoot, verify)
+		if err := held.Verify(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	var result *serverBuilder.BuildResult
 
 	if req.ID.IsEmpty() {
@@ -45,6 +79,10 @@ func (s *Service) ApplyWithContext(ctx context.Context, req *ApplyRequest) (*iaa
 		result = created
 	} else {
 		updated, err := builder.Update(ctx, req.Zone)
+		if held != nil && updated != nil && updated.ServerID != req.ID {
+			// プラン変更でIDが変わった場合はリースを引き継いだ変更後のサーバのロックを解放する
+			held.Move(lock.NewServerTarget(s.caller, req.Zone, updated.ServerID))
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/helper/power"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Boot(req *BootRequest) error {
@@ -26,8 +27,12 @@ func (s *Service) Boot(req *BootRequest) error {
 }
 
 func (s *Service) BootWithContext(ctx context.Context, req *BootRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "server", "boot", req, s.boot)
+}
+
+func (s *Service) boot(ctx context.Context, req *BootRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 	client := iaas.NewServerOp(s.caller)
 
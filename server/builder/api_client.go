@@ -38,6 +38,13 @@ type DiskHandler interface {
 	DisconnectFromServer(ctx context.Context, zone string, id types.ID) error
 }
 
+// DiskReader チェックポイントから再開する際にディスクのコピー完了を待つためのインターフェース
+//
+// APIClient.Diskが実装している場合のみ、再開したディスクのコピー完了を待ってから処理を続けられる
+type DiskReader interface {
+	Read(ctx context.Context, zone string, id types.ID) (*iaas.Disk, error)
+}
+
 // SwitchReader スイッチ参照のためのインターフェース
 type SwitchReader interface {
 	Read(ctx context.Context, zone string, id types.ID) (*iaas.Switch, error)
@@ -73,6 +80,13 @@ type CreateServerHandler interface {
 	ChangePlan(ctx context.Context, zone string, id types.ID, plan *iaas.ServerChangePlanRequest) (*iaas.Server, error)
 }
 
+// DeleteServerHandler ロールバック時にサーバを削除するためのインターフェース
+//
+// APIClient.Serverが実装している場合のみ、ロールバック時に作成したサーバを削除できる
+type DeleteServerHandler interface {
+	Delete(ctx context.Context, zone string, id types.ID) error
+}
+
 // NewBuildersAPIClient APIクライアントの作成
 func NewBuildersAPIClient(caller iaas.APICaller) *APIClient {
 	return &APIClient{
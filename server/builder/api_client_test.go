@@ -98,6 +98,8 @@ type dummyCreateServerHandler struct {
 	cdromErr    error
 	bootErr     error
 	shutdownErr error
+	deleteErr   error
+	deleted     []types.ID
 }
 
 func (d *dummyCreateServerHandler) Create(ctx context.Context, zone string, param *iaas.ServerCreateRequest) (*iaas.Server, error) {
@@ -147,3 +149,11 @@ func (d *dummyCreateServerHandler) ChangePlan(ctx context.Context, zone string,
 	}
 	return d.server, nil
 }
+
+func (d *dummyCreateServerHandler) Delete(ctx context.Context, zone string, id types.ID) error {
+	if d.deleteErr != nil {
+		return d.deleteErr
+	}
+	d.deleted = append(d.deleted, id)
+	return nil
+}
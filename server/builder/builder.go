@@ -18,7 +18,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"reflect"
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/helper/plans"
@@ -26,7 +25,11 @@ import (
 	"github.com/sacloud/iaas-api-go/helper/query"
 	"github.com/sacloud/iaas-api-go/types"
 	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/checkpoint"
 	disk "github.com/sacloud/iaas-service-go/disk/builder"
+	"github.com/sacloud/iaas-service-go/hook"
+	"github.com/sacloud/iaas-service-go/progress"
+	"github.com/sacloud/iaas-service-go/rollback"
 	"github.com/sacloud/packages-go/size"
 )
 
@@ -58,6 +61,28 @@ type Builder struct {
 
 	ServerID      types.ID
 	ForceShutdown bool
+
+	// Observer 進捗イベントの通知先
+	//
+	// 未指定の場合はcontextに設定されたObserverを利用する
+	Observer progress.Observer
+
+	// RollbackOnFailure trueの場合、Buildが失敗した際にBuild中に作成したリソース(サーバ/ディスク/SSHキー/スタートアップスクリプト)を作成とは逆の順番で削除する
+	//
+	// ロールバックを行った場合、Buildは*rollback.Errorを返す
+	RollbackOnFailure bool
+
+	// Checkpoint Buildの進捗の記録先
+	//
+	// 指定した場合、作成したサーバ/ディスクのIDと完了したステップ(NIC設定/CD-ROM挿入/起動)を記録する。
+	// 同じチェックポイントで再度Buildを行うと、完了済みのステップを省略して再開する。
+	Checkpoint *checkpoint.Checkpoint
+
+	// Hooks 処理中の各箇所で呼び出すフック
+	//
+	// hook.AfterCreate/hook.AfterDisk/hook.BeforeBoot(Build/Update)/hook.BeforeShutdown(Update)を呼び出す。
+	// フックがエラーを返した場合はそれ以降の処理を中断する
+	Hooks *hook.Hooks
 }
 
 func BuilderFromResource(ctx context.Context, caller iaas.APICaller, zone string, id types.ID) (*Builder, error) {
@@ -151,6 +176,9 @@ type BuildResult struct {
 	ServerID               types.ID
 	DiskIDs                []types.ID
 	GeneratedSSHPrivateKey string
+
+	// Rollback RollbackOnFailureがtrueでBuildが失敗した場合のロールバック結果
+	Rollback *rollback.Result
 }
 
 var (
@@ -199,15 +227,7 @@ func (b *Builder) Validate(ctx context.Context, zone string) error {
 	}
 
 	// server plan
-	_, err := query.FindServerPlan(ctx, b.Client.ServerPlan, zone, &query.FindServerPlanRequest{
-		CPU:        b.CPU,
-		MemoryGB:   b.MemoryGB,
-		GPU:        b.GPU,
-		CPUModel:   b.CPUModel,
-		Commitment: b.Commitment,
-		Generation: b.Generation,
-	})
-	if err != nil {
+	if _, err := b.FindServerPlan(ctx, zone); err != nil {
 		return err
 	}
 
@@ -227,6 +247,21 @@ func (b *Builder) Validate(ctx context.Context, zone string) error {
 	return nil
 }
 
+// FindServerPlan CPU/MemoryGBなどの指定に合致するサーバプランを返す
+//
+// 未指定の項目にはデフォルト値を用いる
+func (b *Builder) FindServerPlan(ctx context.Context, zone string) (*iaas.ServerPlan, error) {
+	b.setDefaults()
+	return query.FindServerPlan(ctx, b.Client.ServerPlan, zone, &query.FindServerPlanRequest{
+		CPU:        b.CPU,
+		MemoryGB:   b.MemoryGB,
+		GPU:        b.GPU,
+		CPUModel:   b.CPUModel,
+		Commitment: b.Commitment,
+		Generation: b.Generation,
+	})
+}
+
 // Build サーバ構築を行う
 func (b *Builder) Build(ctx context.Context, zone string) (*BuildResult, error) {
 	// validate
@@ -234,8 +269,37 @@ func (b *Builder) Build(ctx context.Context, zone string) (*BuildResult, error)
 		return nil, err
 	}
 
+	ctx = b.withObserver(ctx)
+
+	if err := b.Checkpoint.Load(ctx); err != nil {
+		return nil, err
+	}
+
+	if !b.RollbackOnFailure {
+		return b.build(ctx, zone)
+	}
+
+	var result *BuildResult
+	err := rollback.Run(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = b.build(ctx, zone)
+		return err
+	})
+	var rollbackErr *rollback.Error
+	if errors.As(err, &rollbackErr) && result != nil {
+		result.Rollback = rollbackErr.Result
+	}
+	return result, b.Checkpoint.ResetOnRollback(ctx, err)
+}
+
+func (b *Builder) build(ctx context.Context, zone string) (*BuildResult, error) {
 	// create server
-	server, err := b.createServer(ctx, zone)
+	server, err := b.createOrResumeServer(ctx, zone)
+	if server != nil {
+		rollback.Track(ctx, "server", server.ID, func(ctx context.Context) error {
+			return b.deleteServer(ctx, zone, server.ID)
+		})
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -243,34 +307,91 @@ func (b *Builder) Build(ctx context.Context, zone string) (*BuildResult, error)
 		ServerID: server.ID,
 	}
 
+	err = b.Checkpoint.Run(ctx, "hook/after_create", func() error {
+		return b.runHook(ctx, hook.AfterCreate, zone, server)
+	})
+	if err != nil {
+		return result, err
+	}
+
 	// create&connect disk(s)
-	for _, diskReq := range b.DiskBuilders {
-		builtDisk, err := diskReq.Build(ctx, zone, server.ID)
-		if err != nil {
-			return result, err
+	for i, diskReq := range b.DiskBuilders {
+		key := fmt.Sprintf("disk/%d", i)
+		diskCtx := b.diskScope(ctx, i)
+		builtDisk := &disk.BuildResult{DiskID: b.Checkpoint.ID(key)}
+		if !builtDisk.DiskID.IsEmpty() {
+			progress.Step(diskCtx, "disk", builtDisk.DiskID, "resumed")
+			// コピー完了待ちの途中で中断していた場合に備えて完了を待つ
+			if !diskReq.NoWaitFlag() {
+				if err := b.waitForDiskCopy(diskCtx, zone, builtDisk.DiskID); err != nil {
+					return result, err
+				}
+			}
+		} else {
+			// コピー完了待ちの途中で中断した場合もディスクを作り直さないよう、作成直後にIDを記録する
+			diskCtx = disk.WithCreatedFunc(diskCtx, func(ctx context.Context, id types.ID) error {
+				return b.Checkpoint.SetID(ctx, key, id)
+			})
+			builtDisk, err = diskReq.Build(diskCtx, zone, server.ID)
+			if err != nil {
+				return result, err
+			}
+			if err := b.Checkpoint.SetID(ctx, key, builtDisk.DiskID); err != nil {
+				return result, err
+			}
+			if builtDisk.GeneratedSSHKey != nil {
+				result.GeneratedSSHPrivateKey = builtDisk.GeneratedSSHKey.PrivateKey
+			}
 		}
 		result.DiskIDs = append(result.DiskIDs, builtDisk.DiskID)
-		if builtDisk.GeneratedSSHKey != nil {
-			result.GeneratedSSHPrivateKey = builtDisk.GeneratedSSHKey.PrivateKey
+
+		err := b.Checkpoint.Run(ctx, fmt.Sprintf("hook/after_disk/%d", i), func() error {
+			return b.Hooks.Run(ctx, &hook.Event{
+				Point:    hook.AfterDisk,
+				Zone:     zone,
+				Resource: "disk",
+				ID:       builtDisk.DiskID,
+				Index:    i,
+				Target:   builtDisk,
+			})
+		})
+		if err != nil {
+			return result, err
 		}
 	}
 
 	// connect packet filter
-	if err := b.updateInterfaces(ctx, zone, server); err != nil {
+	err = b.Checkpoint.Run(ctx, "interfaces", func() error {
+		return b.updateInterfaces(ctx, zone, server)
+	})
+	if err != nil {
 		return result, err
 	}
 
 	// insert CD-ROM
 	if !b.CDROMID.IsEmpty() {
-		req := &iaas.InsertCDROMRequest{ID: b.CDROMID}
-		if err := b.Client.Server.InsertCDROM(ctx, zone, server.ID, req); err != nil {
+		err := b.Checkpoint.Run(ctx, "cdrom", func() error {
+			req := &iaas.InsertCDROMRequest{ID: b.CDROMID}
+			return b.Client.Server.InsertCDROM(ctx, zone, server.ID, req)
+		})
+		if err != nil {
 			return result, err
 		}
 	}
 
 	// bool
-	if !b.NoWait && b.BootAfterCreate {
-		if err := power.BootServer(ctx, b.Client.Server, zone, server.ID, b.userData()...); err != nil {
+	if !b.NoWait && b.BootAfterCreate && !b.Checkpoint.Done("boot") {
+		// 起動後に失敗した場合はディスクの削除より先にシャットダウンする
+		rollback.Track(ctx, "server", server.ID, func(ctx context.Context) error {
+			return b.shutdownIfUp(ctx, zone, server.ID)
+		})
+		err := b.Checkpoint.Run(ctx, "boot", func() error {
+			if err := b.runHook(ctx, hook.BeforeBoot, zone, server); err != nil {
+				return err
+			}
+			return b.boot(ctx, zone, server.ID)
+		})
+		if err != nil {
 			return result, err
 		}
 	}
@@ -279,6 +400,26 @@ func (b *Builder) Build(ctx context.Context, zone string) (*BuildResult, error)
 	return result, nil
 }
 
+// createOrResumeServer サーバを作成する、チェックポイントにサーバが記録されている場合はそのサーバを返す
+func (b *Builder) createOrResumeServer(ctx context.Context, zone string) (*iaas.Server, error) {
+	server, resumed, err := checkpoint.CreateOrResume(ctx, b.Checkpoint, "server",
+		func(id types.ID) (*iaas.Server, error) {
+			return b.Client.Server.Read(ctx, zone, id)
+		},
+		func() (*iaas.Server, error) {
+			return b.createServer(ctx, zone)
+		},
+	)
+	if server != nil {
+		if resumed {
+			progress.Step(ctx, "server", server.ID, "resumed")
+		} else {
+			progress.Step(ctx, "server", server.ID, "created")
+		}
+	}
+	return server, err
+}
+
 // IsNeedShutdown Update時にシャットダウンが必要か
 func (b *Builder) IsNeedShutdown(ctx context.Context, zone string) (bool, error) {
 	if b.ServerID.IsEmpty() {
@@ -289,54 +430,7 @@ func (b *Builder) IsNeedShutdown(ctx context.Context, zone string) (bool, error)
 	if err != nil {
 		return false, err
 	}
-
-	if b.UserData != "" {
-		return true, nil
-	}
-
-	current := b.currentState(server)
-	desired := b.desiredState()
-
-	// シャットダウンが不要な項目には固定値を入れる
-	var nics []*nicState
-	nics = append(nics, current.nic)
-	nics = append(nics, current.additionalNICs...)
-	nics = append(nics, desired.nic)
-	nics = append(nics, desired.additionalNICs...)
-	b.fillDummyValueToState(nics...)
-
-	if !reflect.DeepEqual(current, desired) {
-		return true, nil
-	}
-
-	// ここに到達するときはserver.Disksとb.DiskBuildersは同数となっている
-	for i, disk := range server.Disks {
-		level := b.DiskBuilders[i].UpdateLevel(ctx, zone, &iaas.Disk{
-			ID:              disk.ID,
-			Name:            disk.Name,
-			Availability:    disk.Availability,
-			Connection:      disk.Connection,
-			ConnectionOrder: disk.ConnectionOrder,
-			ReinstallCount:  disk.ReinstallCount,
-			SizeMB:          disk.SizeMB,
-			DiskPlanID:      disk.DiskPlanID,
-			Storage:         disk.Storage,
-		})
-
-		if level == service.UpdateLevelNeedShutdown {
-			return true, nil
-		}
-	}
-	return false, nil
-}
-
-func (b *Builder) fillDummyValueToState(state ...*nicState) {
-	for _, s := range state {
-		if s != nil {
-			s.packetFilterID = types.ID(0)
-			s.displayIP = ""
-		}
-	}
+	return b.plan(ctx, zone, server).IsNeedShutdown(), nil
 }
 
 // Update サーバの更新
@@ -349,6 +443,7 @@ func (b *Builder) Update(ctx context.Context, zone string) (*BuildResult, error)
 		return nil, fmt.Errorf("server id required")
 	}
 
+	ctx = b.withObserver(ctx)
 	result := &BuildResult{ServerID: b.ServerID}
 
 	server, err := b.Client.Server.Read(ctx, zone, b.ServerID)
@@ -356,20 +451,26 @@ func (b *Builder) Update(ctx context.Context, zone string) (*BuildResult, error)
 		return result, err
 	}
 
-	isNeedShutdown, err := b.IsNeedShutdown(ctx, zone)
-	if err != nil {
-		return result, err
+	plan := b.plan(ctx, zone, server)
+	if !plan.HasChanges() {
+		return result, nil
 	}
+	isNeedShutdown := plan.IsNeedShutdown()
 
 	// shutdown
 	running := server.InstanceStatus.IsUp()
 	if isNeedShutdown && running {
 		if b.NoWait {
-			return nil, errors.New("NoWait option is not available due to the need to shut down")
+			return nil, service.NewError(service.ErrNeedsShutdown, errors.New("NoWait option is not available due to the need to shut down"))
 		}
+		if err := b.runHook(ctx, hook.BeforeShutdown, zone, server); err != nil {
+			return result, err
+		}
+		progress.Waiting(ctx, "server", server.ID, "shutdown")
 		if err := power.ShutdownServer(ctx, b.Client.Server, zone, server.ID, b.ForceShutdown); err != nil {
 			return result, err
 		}
+		progress.Step(ctx, "server", server.ID, "shutdown completed")
 	}
 
 	// reconcile disks
@@ -397,6 +498,7 @@ func (b *Builder) Update(ctx context.Context, zone string) (*BuildResult, error)
 			return result, err
 		}
 		server = updated
+		result.ServerID = server.ID
 	}
 
 	// update
@@ -428,7 +530,10 @@ func (b *Builder) Update(ctx context.Context, zone string) (*BuildResult, error)
 
 	// boot
 	if isNeedShutdown && running && server.InstanceStatus.IsDown() {
-		if err := power.BootServer(ctx, b.Client.Server, zone, server.ID, b.userData()...); err != nil {
+		if err := b.runHook(ctx, hook.BeforeBoot, zone, server); err != nil {
+			return result, err
+		}
+		if err := b.boot(ctx, zone, server.ID); err != nil {
 			return result, err
 		}
 	}
@@ -437,6 +542,76 @@ func (b *Builder) Update(ctx context.Context, zone string) (*BuildResult, error)
 	return result, nil
 }
 
+func (b *Builder) withObserver(ctx context.Context) context.Context {
+	if b.Observer != nil {
+		return progress.WithObserver(ctx, b.Observer)
+	}
+	return ctx
+}
+
+func (b *Builder) diskScope(ctx context.Context, index int) context.Context {
+	return progress.WithScope(ctx, fmt.Sprintf("disk %d/%d", index+1, len(b.DiskBuilders)))
+}
+
+func (b *Builder) runHook(ctx context.Context, point hook.Point, zone string, server *iaas.Server) error {
+	return b.Hooks.Run(ctx, &hook.Event{
+		Point:    point,
+		Zone:     zone,
+		Resource: "server",
+		ID:       server.ID,
+		Target:   server,
+	})
+}
+
+func (b *Builder) boot(ctx context.Context, zone string, id types.ID) error {
+	progress.Waiting(ctx, "server", id, "boot")
+	if err := power.BootServer(ctx, b.Client.Server, zone, id, b.userData()...); err != nil {
+		return err
+	}
+	progress.Step(ctx, "server", id, "boot completed")
+	return nil
+}
+
+func (b *Builder) shutdownIfUp(ctx context.Context, zone string, id types.ID) error {
+	server, err := b.Client.Server.Read(ctx, zone, id)
+	if err != nil {
+		return err
+	}
+	if server.InstanceStatus.IsUp() {
+		return power.ShutdownServer(ctx, b.Client.Server, zone, id, true)
+	}
+	return nil
+}
+
+// waitForDiskCopy チェックポイントから再開したディスクのコピー完了を待つ
+//
+// APIClient.DiskがDiskReaderを実装していない場合は完了を確認できないためエラーを返す
+func (b *Builder) waitForDiskCopy(ctx context.Context, zone string, id types.ID) error {
+	reader, ok := b.Client.Disk.(DiskReader)
+	if !ok {
+		return fmt.Errorf("disk client %T does not implement DiskReader", b.Client.Disk)
+	}
+	waiter := iaas.WaiterForReady(func() (interface{}, error) {
+		return reader.Read(ctx, zone, id)
+	})
+	_, err := progress.WaitForState(ctx, waiter, "disk", id, "copy")
+	return err
+}
+
+// deleteServer ロールバック用、起動している場合は停止してから削除する
+//
+// APIClient.ServerがDeleteServerHandlerを実装していない場合は削除できないためエラーを返す
+func (b *Builder) deleteServer(ctx context.Context, zone string, id types.ID) error {
+	deleter, ok := b.Client.Server.(DeleteServerHandler)
+	if !ok {
+		return fmt.Errorf("server client %T does not implement DeleteServerHandler", b.Client.Server)
+	}
+	if err := b.shutdownIfUp(ctx, zone, id); err != nil {
+		return err
+	}
+	return deleter.Delete(ctx, zone, id)
+}
+
 func (b *Builder) setDefaults() {
 	if b.CPU == 0 {
 		b.CPU = defaultCPU
@@ -646,7 +821,7 @@ func (b *Builder) reconcileDisks(ctx context.Context, zone string, server *iaas.
 	isDiskUpdated := len(server.Disks) != len(b.DiskBuilders) // isDiskUpdateがtrueの場合、後でディスクの取外&接続を行う
 	for i, diskReq := range b.DiskBuilders {
 		if diskReq.DiskID().IsEmpty() {
-			res, err := diskReq.Build(ctx, zone, server.ID)
+			res, err := diskReq.Build(b.diskScope(ctx, i), zone, server.ID)
 			if err != nil {
 				return err
 			}
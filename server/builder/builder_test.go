@@ -30,6 +30,7 @@ import (
 	"github.com/sacloud/iaas-api-go/types"
 	service "github.com/sacloud/iaas-service-go"
 	disk "github.com/sacloud/iaas-service-go/disk/builder"
+	"github.com/sacloud/iaas-service-go/rollback"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/ssh"
 )
@@ -334,6 +335,54 @@ func TestBuilder_Build(t *testing.T) {
 	}
 }
 
+func TestBuilder_Build_Rollback(t *testing.T) {
+	newBuilder := func(server *dummyCreateServerHandler) *Builder {
+		return &Builder{
+			CDROMID:           1,
+			RollbackOnFailure: true,
+			Client: &APIClient{
+				Switch:       &dummySwitchReader{},
+				PacketFilter: &dummyPackerFilterReader{},
+				ServerPlan: &dummyPlanFinder{
+					plans: []*iaas.ServerPlan{{ID: 1}},
+				},
+				Server: server,
+			},
+		}
+	}
+
+	t.Run("rollback succeeded", func(t *testing.T) {
+		server := &dummyCreateServerHandler{
+			server:   &iaas.Server{ID: 1, InstanceStatus: types.ServerInstanceStatuses.Down},
+			cdromErr: errors.New("dummy"),
+		}
+		res, err := newBuilder(server).Build(context.Background(), "tk1v")
+
+		var rollbackErr *rollback.Error
+		require.ErrorAs(t, err, &rollbackErr)
+		require.EqualError(t, errors.Unwrap(err), "dummy")
+		require.True(t, rollbackErr.Result.Succeeded())
+
+		require.NotNil(t, res.Rollback)
+		require.Len(t, res.Rollback.Steps, 1)
+		require.Equal(t, "server", res.Rollback.Steps[0].Resource)
+		require.Equal(t, []types.ID{1}, server.deleted)
+	})
+
+	t.Run("rollback failed", func(t *testing.T) {
+		server := &dummyCreateServerHandler{
+			server:    &iaas.Server{ID: 1, InstanceStatus: types.ServerInstanceStatuses.Down},
+			cdromErr:  errors.New("dummy"),
+			deleteErr: errors.New("delete failed"),
+		}
+		res, err := newBuilder(server).Build(context.Background(), "tk1v")
+
+		require.EqualError(t, err, "dummy (rollback failed: server[1]: delete failed)")
+		require.False(t, res.Rollback.Succeeded())
+		require.Len(t, res.Rollback.Failed(), 1)
+	})
+}
+
 type dummyDiskBuilder struct {
 	result       *disk.BuildResult
 	updateResult *disk.UpdateResult
@@ -0,0 +1,179 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/lock"
+)
+
+// Plan Build/Update時の変更内容を算出する
+//
+// ServerIDが空の場合は新規作成を表すPlanを返す。APIによるリソースの変更は行わない。
+func (b *Builder) Plan(ctx context.Context, zone string) (*service.Plan, error) {
+	b.setDefaults()
+
+	if b.ServerID.IsEmpty() {
+		nicCount := len(b.AdditionalNICs)
+		if b.NIC != nil {
+			nicCount++
+		}
+		return service.NewCreatePlan(map[string]interface{}{
+			"Name":              b.Name,
+			"Description":       b.Description,
+			"Tags":              b.Tags,
+			"IconID":            b.IconID,
+			"CPU":               b.CPU,
+			"MemoryGB":          b.MemoryGB,
+			"GPU":               b.GPU,
+			"CPUModel":          b.CPUModel,
+			"Commitment":        b.Commitment,
+			"Generation":        b.Generation,
+			"InterfaceDriver":   b.InterfaceDriver,
+			"PrivateHostID":     b.PrivateHostID,
+			"CDROMID":           b.CDROMID,
+			"NetworkInterfaces": nicCount,
+			"Disks":             len(b.DiskBuilders),
+		}), nil
+	}
+
+	server, err := b.Client.Server.Read(ctx, zone, b.ServerID)
+	if err != nil {
+		return nil, err
+	}
+	return b.plan(ctx, zone, server), nil
+}
+
+func (b *Builder) plan(ctx context.Context, zone string, server *iaas.Server) *service.Plan {
+	plan := service.NewPlan()
+
+	plan.Diff("Name", server.Name, b.Name)
+	plan.Diff("Description", server.Description, b.Description)
+	// ロックを表すタグは差分として扱わない
+	plan.Diff("Tags", lock.StripTags(server.Tags), lock.StripTags(b.Tags))
+	plan.Diff("IconID", server.IconID, b.IconID)
+	if !b.CDROMID.IsEmpty() {
+		plan.Diff("CDROMID", server.CDROMID, b.CDROMID)
+	}
+
+	current := b.currentState(server)
+	desired := b.desiredState()
+
+	plan.DiffNeedShutdown("PrivateHostID", current.privateHostID, desired.privateHostID)
+	plan.DiffNeedShutdown("InterfaceDriver", current.interfaceDriver, desired.interfaceDriver)
+	plan.DiffNeedShutdown("CPU", current.cpu, desired.cpu)
+	plan.DiffNeedShutdown("MemoryGB", current.memoryGB, desired.memoryGB)
+	plan.DiffNeedShutdown("GPU", current.gpu, desired.gpu)
+	plan.DiffNeedShutdown("CPUModel", current.cpuModel, desired.cpuModel)
+	plan.DiffNeedShutdown("Commitment", current.commitment, desired.commitment)
+	if b.Generation != types.PlanGenerations.Default {
+		plan.DiffNeedShutdown("Generation", server.ServerPlanGeneration, b.Generation)
+	}
+
+	b.planNICs(plan, current, desired)
+	b.planDisks(ctx, zone, plan, server)
+
+	if b.UserData != "" {
+		plan.Add(&service.FieldChange{
+			Name:        "UserData",
+			After:       service.SecretPlaceholder,
+			UpdateLevel: service.UpdateLevelNeedShutdown,
+		})
+	}
+	return plan
+}
+
+func (b *Builder) planNICs(plan *service.Plan, current, desired *serverState) {
+	var currentNICs, desiredNICs []*nicState
+	if current.nic != nil {
+		currentNICs = append(currentNICs, current.nic)
+	}
+	currentNICs = append(currentNICs, current.additionalNICs...)
+	if desired.nic != nil {
+		desiredNICs = append(desiredNICs, desired.nic)
+	}
+	desiredNICs = append(desiredNICs, desired.additionalNICs...)
+
+	for i := 0; i < len(currentNICs) || i < len(desiredNICs); i++ {
+		name := fmt.Sprintf("NetworkInterfaces[%d]", i)
+		switch {
+		case i >= len(currentNICs):
+			plan.Add(&service.FieldChange{
+				Name:        name,
+				After:       desiredNICs[i].upstreamType,
+				UpdateLevel: service.UpdateLevelNeedShutdown,
+			})
+		case i >= len(desiredNICs):
+			plan.Add(&service.FieldChange{
+				Name:        name,
+				Before:      currentNICs[i].upstreamType,
+				UpdateLevel: service.UpdateLevelNeedShutdown,
+			})
+		default:
+			c, d := currentNICs[i], desiredNICs[i]
+			plan.DiffNeedShutdown(name+".Upstream", c.upstreamType, d.upstreamType)
+			plan.DiffNeedShutdown(name+".SwitchID", c.switchID, d.switchID)
+			plan.Diff(name+".PacketFilterID", c.packetFilterID, d.packetFilterID)
+			plan.Diff(name+".DisplayIPAddress", c.displayIP, d.displayIP)
+		}
+	}
+}
+
+func (b *Builder) planDisks(ctx context.Context, zone string, plan *service.Plan, server *iaas.Server) {
+	for i, diskReq := range b.DiskBuilders {
+		name := fmt.Sprintf("Disks[%d]", i)
+		if i >= len(server.Disks) {
+			plan.Add(&service.FieldChange{
+				Name:        name,
+				After:       diskReq.DiskID(),
+				UpdateLevel: service.UpdateLevelNeedShutdown,
+			})
+			continue
+		}
+
+		disk := server.Disks[i]
+		level := diskReq.UpdateLevel(ctx, zone, &iaas.Disk{
+			ID:              disk.ID,
+			Name:            disk.Name,
+			Availability:    disk.Availability,
+			Connection:      disk.Connection,
+			ConnectionOrder: disk.ConnectionOrder,
+			ReinstallCount:  disk.ReinstallCount,
+			SizeMB:          disk.SizeMB,
+			DiskPlanID:      disk.DiskPlanID,
+			Storage:         disk.Storage,
+		})
+		if level != service.UpdateLevelNone {
+			plan.Add(&service.FieldChange{
+				Name:        name,
+				Before:      disk.ID,
+				After:       diskReq.DiskID(),
+				UpdateLevel: level,
+			})
+		}
+	}
+	for i := len(b.DiskBuilders); i < len(server.Disks); i++ {
+		plan.Add(&service.FieldChange{
+			Name:        fmt.Sprintf("Disks[%d]", i),
+			Before:      server.Disks[i].ID,
+			UpdateLevel: service.UpdateLevelNeedShutdown,
+		})
+	}
+}
@@ -16,11 +16,11 @@ package server
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/helper/plans"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) ChangePlan(req *ChangePlanRequest) (*iaas.Server, error) {
@@ -28,8 +28,12 @@ func (s *Service) ChangePlan(req *ChangePlanRequest) (*iaas.Server, error) {
 }
 
 func (s *Service) ChangePlanWithContext(ctx context.Context, req *ChangePlanRequest) (*iaas.Server, error) {
+	return service.Invoke(ctx, s.interceptors, "server", "change_plan", req, s.changePlan)
+}
+
+func (s *Service) changePlan(ctx context.Context, req *ChangePlanRequest) (*iaas.Server, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewServerOp(s.caller)
@@ -38,7 +42,7 @@ func (s *Service) ChangePlanWithContext(ctx context.Context, req *ChangePlanRequ
 		return nil, err
 	}
 	if !current.InstanceStatus.IsDown() {
-		return nil, fmt.Errorf("server[%s] is still running", req.ID)
+		return nil, service.Errorf(service.ErrNeedsShutdown, "server[%s] is still running", req.ID)
 	}
 
 	changeReq := &iaas.ServerChangePlanRequest{
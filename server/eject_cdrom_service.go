@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) EjectCDROM(req *EjectCDROMRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) EjectCDROM(req *EjectCDROMRequest) error {
 }
 
 func (s *Service) EjectCDROMWithContext(ctx context.Context, req *EjectCDROMRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "server", "eject_cdrom", req, s.ejectCDROM)
+}
+
+func (s *Service) ejectCDROM(ctx context.Context, req *EjectCDROMRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewServerOp(s.caller)
@@ -0,0 +1,93 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	diskService "github.com/sacloud/iaas-service-go/disk"
+)
+
+func (s *Service) Export(req *ExportRequest) (*ApplyRequest, error) {
+	return s.ExportWithContext(context.Background(), req)
+}
+
+func (s *Service) ExportWithContext(ctx context.Context, req *ExportRequest) (*ApplyRequest, error) {
+	return service.Invoke(ctx, s.interceptors, "server", "export", req, s.export)
+}
+
+func (s *Service) export(ctx context.Context, req *ExportRequest) (*ApplyRequest, error) {
+	if err := req.Validate(); err != nil {
+		return nil, service.ValidationError(err)
+	}
+
+	current, err := iaas.NewServerOp(s.caller).Read(ctx, req.Zone, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var nics []*NetworkInterface
+	for _, iface := range current.Interfaces {
+		nic := &NetworkInterface{
+			PacketFilterID: iface.PacketFilterID,
+			UserIPAddress:  iface.UserIPAddress,
+		}
+		switch {
+		case iface.SwitchID.IsEmpty():
+			nic.Upstream = "disconnected"
+		case iface.SwitchScope == types.Scopes.Shared:
+			nic.Upstream = "shared"
+			nic.UserIPAddress = ""
+		default:
+			nic.Upstream = iface.SwitchID.String()
+		}
+		nics = append(nics, nic)
+	}
+
+	// Note: ディスクの修正パラメータは参照できないため出力しない
+	diskSvc := diskService.New(s.caller)
+	var disks []*diskService.ApplyRequest
+	for _, d := range current.Disks {
+		disk, err := diskSvc.ExportWithContext(ctx, &diskService.ExportRequest{Zone: req.Zone, ID: d.ID})
+		if err != nil {
+			return nil, err
+		}
+		disks = append(disks, disk)
+	}
+
+	return &ApplyRequest{
+		Zone:              req.Zone,
+		ID:                current.ID,
+		Name:              current.Name,
+		Description:       current.Description,
+		Tags:              current.Tags,
+		IconID:            current.IconID,
+		CPU:               current.CPU,
+		MemoryGB:          current.GetMemoryGB(),
+		GPU:               current.GPU,
+		CPUModel:          current.ServerPlanCPUModel,
+		Commitment:        current.ServerPlanCommitment,
+		Generation:        current.ServerPlanGeneration,
+		InterfaceDriver:   current.InterfaceDriver,
+		BootAfterCreate:   current.InstanceStatus.IsUp(),
+		CDROMID:           current.CDROMID,
+		PrivateHostID:     current.PrivateHostID,
+		NetworkInterfaces: nics,
+		Disks:             disks,
+	}, nil
+}
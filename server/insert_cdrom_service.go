@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) InsertCDROM(req *InsertCDROMRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) InsertCDROM(req *InsertCDROMRequest) error {
 }
 
 func (s *Service) InsertCDROMWithContext(ctx context.Context, req *InsertCDROMRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "server", "insert_cdrom", req, s.insertCDROM)
+}
+
+func (s *Service) insertCDROM(ctx context.Context, req *InsertCDROMRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewServerOp(s.caller)
@@ -0,0 +1,60 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	diskService "github.com/sacloud/iaas-service-go/disk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerService_Preflight(t *testing.T) {
+	svc := New(testutil.SingletonAPICaller())
+
+	err := svc.PreflightWithContext(context.Background(), &ApplyRequest{
+		Zone:     testutil.TestZone(),
+		Name:     "example",
+		CPU:      1,
+		MemoryGB: 1,
+		NetworkInterfaces: []*NetworkInterface{
+			{Upstream: "shared", PacketFilterID: 1},
+			{Upstream: "2"},
+		},
+		Disks: []*diskService.ApplyRequest{
+			{Name: "example", DistantFrom: []types.ID{3}},
+		},
+	})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, service.ErrValidationFailed))
+
+	var preflightErr *service.PreflightError
+	require.True(t, errors.As(err, &preflightErr))
+
+	var fields []string
+	for _, p := range preflightErr.Problems {
+		fields = append(fields, p.Field)
+	}
+	require.Equal(t, []string{
+		"NetworkInterfaces[0].PacketFilterID",
+		"NetworkInterfaces[1].Upstream",
+		"Disks[0].DistantFrom[0]",
+	}, fields)
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) SendNMI(req *SendNMIRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) SendNMI(req *SendNMIRequest) error {
 }
 
 func (s *Service) SendNMIWithContext(ctx context.Context, req *SendNMIRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "server", "send_nmi", req, s.sendNMI)
+}
+
+func (s *Service) sendNMI(ctx context.Context, req *SendNMIRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewServerOp(s.caller)
@@ -0,0 +1,272 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	service "github.com/sacloud/iaas-service-go"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Registry リソース種別をキーとしてサービスを保持する
+//
+// 各サービスのXxxWithContext(ctx, *XxxRequest)形式のメソッドを操作として登録し、
+// mapで表現されたリクエストから呼び出せるようにする。
+// 操作名はメソッド名をスネークケースにしたもの 例: create, list_parameter
+type Registry struct {
+	services     map[string]*registeredService
+	interceptors []service.Interceptor
+	mu           sync.RWMutex
+}
+
+type registeredService struct {
+	service    reflect.Value
+	operations map[string]reflect.Method
+}
+
+// NewRegistry 空のRegistryを返す
+func NewRegistry() *Registry {
+	return &Registry{services: make(map[string]*registeredService)}
+}
+
+// Use Registry経由での全ての操作の呼び出しに割り込むInterceptorを追加する
+//
+// 各サービスの生成時にservice.WithInterceptorsを指定している場合、そちらはこのInterceptorの内側で実行される
+func (r *Registry) Use(interceptors ...service.Interceptor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interceptors = append(r.interceptors, interceptors...)
+}
+
+// Register サービスをリソース種別と紐付けて登録する
+//
+// 既に同じリソース種別が登録されている場合は上書きする
+func (r *Registry) Register(kind string, svc interface{}) {
+	v := reflect.ValueOf(svc)
+	operations := make(map[string]reflect.Method)
+	for i := 0; i < v.Type().NumMethod(); i++ {
+		m := v.Type().Method(i)
+		if !strings.HasSuffix(m.Name, "WithContext") || !isOperation(m.Type) {
+			continue
+		}
+		operations[toSnakeCase(strings.TrimSuffix(m.Name, "WithContext"))] = m
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.services[kind] = &registeredService{service: v, operations: operations}
+}
+
+// isOperation func(receiver, context.Context, *Request) (result, error)もしくは(error)形式か
+func isOperation(t reflect.Type) bool {
+	if t.NumIn() != 3 || t.In(1) != contextType {
+		return false
+	}
+	if t.In(2).Kind() != reflect.Ptr || t.In(2).Elem().Kind() != reflect.Struct {
+		return false
+	}
+	switch t.NumOut() {
+	case 1, 2:
+		return t.Out(t.NumOut()-1) == errorType
+	}
+	return false
+}
+
+// Kinds 登録済みのリソース種別を返す
+func (r *Registry) Kinds() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var kinds []string
+	for k := range r.services {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// Operations リソース種別ごとに利用可能な操作名を返す
+func (r *Registry) Operations(kind string) ([]string, error) {
+	svc, err := r.lookup(kind)
+	if err != nil {
+		return nil, err
+	}
+	var operations []string
+	for op := range svc.operations {
+		operations = append(operations, op)
+	}
+	sort.Strings(operations)
+	return operations, nil
+}
+
+// Service リソース種別に対応するサービスを返す
+func (r *Registry) Service(kind string) (interface{}, error) {
+	svc, err := r.lookup(kind)
+	if err != nil {
+		return nil, err
+	}
+	return svc.service.Interface(), nil
+}
+
+// NewRequest 操作に対応する空のリクエストを返す
+func (r *Registry) NewRequest(kind, operation string) (interface{}, error) {
+	_, method, err := r.operation(kind, operation)
+	if err != nil {
+		return nil, err
+	}
+	return reflect.New(method.Type.In(2).Elem()).Interface(), nil
+}
+
+// Method 操作に対応するサービスのメソッドを返す
+//
+// メソッドの型はレシーバを第1引数に含む func(receiver, context.Context, *Request) (result, error) もしくは (error) となる
+func (r *Registry) Method(kind, operation string) (reflect.Method, error) {
+	_, method, err := r.operation(kind, operation)
+	return method, err
+}
+
+// Invoke 操作を呼び出す
+//
+// paramsはリクエストのフィールド名(大文字小文字は区別しない)をキーとしたmap。
+// リクエストに存在しないフィールドが含まれる場合はエラーとなる。
+// 戻り値を持たない操作(Deleteなど)の場合はnilを返す。
+func (r *Registry) Invoke(ctx context.Context, kind, operation string, params map[string]interface{}) (interface{}, error) {
+	svc, method, err := r.operation(kind, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	req := reflect.New(method.Type.In(2).Elem())
+	if len(params) > 0 {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, err
+		}
+		if err := service.UnmarshalRequestJSON(data, req.Interface()); err != nil {
+			return nil, fmt.Errorf("invalid parameter for %s.%s: %w", kind, operation, err)
+		}
+	}
+
+	handler := func(ctx context.Context, inv *service.Invocation) (interface{}, error) {
+		if reflect.TypeOf(inv.Request) != method.Type.In(2) {
+			return nil, fmt.Errorf("invalid request type for %s.%s: %T", kind, operation, inv.Request)
+		}
+		results := method.Func.Call([]reflect.Value{svc.service, reflect.ValueOf(ctx), reflect.ValueOf(inv.Request)})
+		if err, _ := results[len(results)-1].Interface().(error); err != nil {
+			return nil, err
+		}
+		if len(results) == 1 {
+			return nil, nil
+		}
+		return results[0].Interface(), nil
+	}
+
+	r.mu.RLock()
+	interceptors := r.interceptors
+	r.mu.RUnlock()
+
+	inv := &service.Invocation{Kind: kind, Operation: operation, Request: req.Interface()}
+	if len(interceptors) == 0 {
+		return handler(ctx, inv)
+	}
+	return service.Chain(interceptors...)(ctx, inv, handler)
+}
+
+// Create リソースを作成する
+func (r *Registry) Create(ctx context.Context, kind string, params map[string]interface{}) (interface{}, error) {
+	return r.Invoke(ctx, kind, "create", params)
+}
+
+// Read リソースを参照する
+func (r *Registry) Read(ctx context.Context, kind string, params map[string]interface{}) (interface{}, error) {
+	return r.Invoke(ctx, kind, "read", params)
+}
+
+// Update リソースを更新する
+func (r *Registry) Update(ctx context.Context, kind string, params map[string]interface{}) (interface{}, error) {
+	return r.Invoke(ctx, kind, "update", params)
+}
+
+// Delete リソースを削除する
+func (r *Registry) Delete(ctx context.Context, kind string, params map[string]interface{}) error {
+	_, err := r.Invoke(ctx, kind, "delete", params)
+	return err
+}
+
+// List リソースの一覧を取得する
+//
+// find操作を持つサービスではfind、持たないサービス(請求など)ではlistを呼び出す
+func (r *Registry) List(ctx context.Context, kind string, params map[string]interface{}) (interface{}, error) {
+	svc, err := r.lookup(kind)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := svc.operations["find"]; ok {
+		return r.Invoke(ctx, kind, "find", params)
+	}
+	return r.Invoke(ctx, kind, "list", params)
+}
+
+func (r *Registry) lookup(kind string) (*registeredService, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	svc, ok := r.services[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown resource kind: %s", kind)
+	}
+	return svc, nil
+}
+
+func (r *Registry) operation(kind, operation string) (*registeredService, reflect.Method, error) {
+	svc, err := r.lookup(kind)
+	if err != nil {
+		return nil, reflect.Method{}, err
+	}
+	method, ok := svc.operations[operation]
+	if !ok {
+		return nil, reflect.Method{}, fmt.Errorf("operation %q is not supported by %s", operation, kind)
+	}
+	return svc, method, nil
+}
+
+// toSnakeCase メソッド名をスネークケースに変換する 例: ListSIMRoute -> list_sim_route
+func toSnakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, c := range runes {
+		if i > 0 && unicode.IsUpper(c) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(c))
+	}
+	return b.String()
+}
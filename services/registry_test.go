@@ -0,0 +1,92 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Operations(t *testing.T) {
+	registry := New(testutil.SingletonAPICaller()).Registry()
+
+	require.Contains(t, registry.Kinds(), "server")
+	require.Contains(t, registry.Kinds(), "switch")
+	require.Len(t, registry.Kinds(), 46)
+
+	ops, err := registry.Operations("mobilegateway")
+	require.NoError(t, err)
+	require.Contains(t, ops, "list_sim_route")
+	require.Contains(t, ops, "set_traffic_config")
+
+	ops, err = registry.Operations("server")
+	require.NoError(t, err)
+	require.Contains(t, ops, "eject_cdrom")
+	require.Contains(t, ops, "send_nmi")
+
+	_, err = registry.Operations("unknown")
+	require.Error(t, err)
+}
+
+func TestRegistry_Invoke(t *testing.T) {
+	ctx := context.Background()
+	registry := New(testutil.SingletonAPICaller()).Registry()
+	zone := testutil.TestZone()
+	name := testutil.ResourceName("services-registry")
+
+	created, err := registry.Create(ctx, "switch", map[string]interface{}{
+		"zone":        zone,
+		"name":        name,
+		"description": "desc",
+	})
+	require.NoError(t, err)
+	sw := created.(*iaas.Switch)
+	require.Equal(t, name, sw.Name)
+
+	read, err := registry.Read(ctx, "switch", map[string]interface{}{"zone": zone, "id": sw.ID.String()})
+	require.NoError(t, err)
+	require.Equal(t, sw.ID, read.(*iaas.Switch).ID)
+
+	updated, err := registry.Update(ctx, "switch", map[string]interface{}{"zone": zone, "id": sw.ID, "description": "upd"})
+	require.NoError(t, err)
+	require.Equal(t, "upd", updated.(*iaas.Switch).Description)
+
+	found, err := registry.List(ctx, "switch", map[string]interface{}{"zone": zone, "names": []string{name}})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+
+	require.NoError(t, registry.Delete(ctx, "switch", map[string]interface{}{"zone": zone, "id": sw.ID}))
+
+	_, err = registry.Create(ctx, "switch", map[string]interface{}{"zone": zone, "unknown": "value"})
+	require.Error(t, err)
+}
+
+func TestToSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"Create":               "create",
+		"ListSIMRoute":         "list_sim_route",
+		"MonitorCPU":           "monitor_cpu",
+		"SendNMI":              "send_nmi",
+		"RenewLetsEncryptCert": "renew_lets_encrypt_cert",
+		"CSV":                  "csv",
+	}
+	for in, expect := range cases {
+		require.Equal(t, expect, toSnakeCase(in))
+	}
+}
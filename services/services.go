@@ -0,0 +1,228 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package services
+
+import (
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/archive"
+	"github.com/sacloud/iaas-service-go/authstatus"
+	"github.com/sacloud/iaas-service-go/autobackup"
+	"github.com/sacloud/iaas-service-go/autoscale"
+	"github.com/sacloud/iaas-service-go/bill"
+	"github.com/sacloud/iaas-service-go/bridge"
+	"github.com/sacloud/iaas-service-go/cdrom"
+	"github.com/sacloud/iaas-service-go/certificateauthority"
+	"github.com/sacloud/iaas-service-go/containerregistry"
+	"github.com/sacloud/iaas-service-go/coupon"
+	"github.com/sacloud/iaas-service-go/database"
+	"github.com/sacloud/iaas-service-go/disk"
+	"github.com/sacloud/iaas-service-go/diskplan"
+	"github.com/sacloud/iaas-service-go/dns"
+	"github.com/sacloud/iaas-service-go/enhanceddb"
+	"github.com/sacloud/iaas-service-go/esme"
+	"github.com/sacloud/iaas-service-go/gslb"
+	"github.com/sacloud/iaas-service-go/icon"
+	"github.com/sacloud/iaas-service-go/iface"
+	"github.com/sacloud/iaas-service-go/internet"
+	"github.com/sacloud/iaas-service-go/internetplan"
+	"github.com/sacloud/iaas-service-go/ipaddress"
+	"github.com/sacloud/iaas-service-go/ipv6addr"
+	"github.com/sacloud/iaas-service-go/ipv6net"
+	"github.com/sacloud/iaas-service-go/license"
+	"github.com/sacloud/iaas-service-go/licenseinfo"
+	"github.com/sacloud/iaas-service-go/loadbalancer"
+	"github.com/sacloud/iaas-service-go/localrouter"
+	"github.com/sacloud/iaas-service-go/mobilegateway"
+	"github.com/sacloud/iaas-service-go/nfs"
+	"github.com/sacloud/iaas-service-go/note"
+	"github.com/sacloud/iaas-service-go/packetfilter"
+	"github.com/sacloud/iaas-service-go/privatehost"
+	"github.com/sacloud/iaas-service-go/privatehostplan"
+	"github.com/sacloud/iaas-service-go/proxylb"
+	"github.com/sacloud/iaas-service-go/region"
+	"github.com/sacloud/iaas-service-go/server"
+	"github.com/sacloud/iaas-service-go/serverplan"
+	"github.com/sacloud/iaas-service-go/serviceclass"
+	"github.com/sacloud/iaas-service-go/sim"
+	"github.com/sacloud/iaas-service-go/simplemonitor"
+	"github.com/sacloud/iaas-service-go/sshkey"
+	"github.com/sacloud/iaas-service-go/subnet"
+	"github.com/sacloud/iaas-service-go/swytch"
+	"github.com/sacloud/iaas-service-go/vpcrouter"
+	"github.com/sacloud/iaas-service-go/zone"
+)
+
+// Services 全てのサービスをまとめて保持する
+//
+// 1つのiaas.APICallerから各パッケージのServiceを生成する
+type Services struct {
+	Archive              *archive.Service
+	AuthStatus           *authstatus.Service
+	AutoBackup           *autobackup.Service
+	AutoScale            *autoscale.Service
+	Bill                 *bill.Service
+	Bridge               *bridge.Service
+	CDROM                *cdrom.Service
+	CertificateAuthority *certificateauthority.Service
+	ContainerRegistry    *containerregistry.Service
+	Coupon               *coupon.Service
+	Database             *database.Service
+	Disk                 *disk.Service
+	DiskPlan             *diskplan.Service
+	DNS                  *dns.Service
+	EnhancedDB           *enhanceddb.Service
+	ESME                 *esme.Service
+	GSLB                 *gslb.Service
+	Icon                 *icon.Service
+	Interface            *iface.Service
+	Internet             *internet.Service
+	InternetPlan         *internetplan.Service
+	IPAddress            *ipaddress.Service
+	IPv6Addr             *ipv6addr.Service
+	IPv6Net              *ipv6net.Service
+	License              *license.Service
+	LicenseInfo          *licenseinfo.Service
+	LoadBalancer         *loadbalancer.Service
+	LocalRouter          *localrouter.Service
+	MobileGateway        *mobilegateway.Service
+	NFS                  *nfs.Service
+	Note                 *note.Service
+	PacketFilter         *packetfilter.Service
+	PrivateHost          *privatehost.Service
+	PrivateHostPlan      *privatehostplan.Service
+	ProxyLB              *proxylb.Service
+	Region               *region.Service
+	Server               *server.Service
+	ServerPlan           *serverplan.Service
+	ServiceClass         *serviceclass.Service
+	SIM                  *sim.Service
+	SimpleMonitor        *simplemonitor.Service
+	SSHKey               *sshkey.Service
+	Subnet               *subnet.Service
+	Switch               *swytch.Service
+	VPCRouter            *vpcrouter.Service
+	Zone                 *zone.Service
+}
+
+// New 全てのサービスを生成して返す
+//
+// optsは全てのサービスに適用される
+func New(caller iaas.APICaller, opts ...service.Option) *Services {
+	return &Services{
+		Archive:              archive.New(caller, opts...),
+		AuthStatus:           authstatus.New(caller, opts...),
+		AutoBackup:           autobackup.New(caller, opts...),
+		AutoScale:            autoscale.New(caller, opts...),
+		Bill:                 bill.New(caller, opts...),
+		Bridge:               bridge.New(caller, opts...),
+		CDROM:                cdrom.New(caller, opts...),
+		CertificateAuthority: certificateauthority.New(caller, opts...),
+		ContainerRegistry:    containerregistry.New(caller, opts...),
+		Coupon:               coupon.New(caller, opts...),
+		Database:             database.New(caller, opts...),
+		Disk:                 disk.New(caller, opts...),
+		DiskPlan:             diskplan.New(caller, opts...),
+		DNS:                  dns.New(caller, opts...),
+		EnhancedDB:           enhanceddb.New(caller, opts...),
+		ESME:                 esme.New(caller, opts...),
+		GSLB:                 gslb.New(caller, opts...),
+		Icon:                 icon.New(caller, opts...),
+		Interface:            iface.New(caller, opts...),
+		Internet:             internet.New(caller, opts...),
+		InternetPlan:         internetplan.New(caller, opts...),
+		IPAddress:            ipaddress.New(caller, opts...),
+		IPv6Addr:             ipv6addr.New(caller, opts...),
+		IPv6Net:              ipv6net.New(caller, opts...),
+		License:              license.New(caller, opts...),
+		LicenseInfo:          licenseinfo.New(caller, opts...),
+		LoadBalancer:         loadbalancer.New(caller, opts...),
+		LocalRouter:          localrouter.New(caller, opts...),
+		MobileGateway:        mobilegateway.New(caller, opts...),
+		NFS:                  nfs.New(caller, opts...),
+		Note:                 note.New(caller, opts...),
+		PacketFilter:         packetfilter.New(caller, opts...),
+		PrivateHost:          privatehost.New(caller, opts...),
+		PrivateHostPlan:      privatehostplan.New(caller, opts...),
+		ProxyLB:              proxylb.New(caller, opts...),
+		Region:               region.New(caller, opts...),
+		Server:               server.New(caller, opts...),
+		ServerPlan:           serverplan.New(caller, opts...),
+		ServiceClass:         serviceclass.New(caller, opts...),
+		SIM:                  sim.New(caller, opts...),
+		SimpleMonitor:        simplemonitor.New(caller, opts...),
+		SSHKey:               sshkey.New(caller, opts...),
+		Subnet:               subnet.New(caller, opts...),
+		Switch:               swytch.New(caller, opts...),
+		VPCRouter:            vpcrouter.New(caller, opts...),
+		Zone:                 zone.New(caller, opts...),
+	}
+}
+
+// Registry 各サービスをリソース種別をキーとして登録したRegistryを返す
+func (s *Services) Registry() *Registry {
+	registry := NewRegistry()
+	for kind, svc := range map[string]interface{}{
+		"archive":              s.Archive,
+		"authstatus":           s.AuthStatus,
+		"autobackup":           s.AutoBackup,
+		"autoscale":            s.AutoScale,
+		"bill":                 s.Bill,
+		"bridge":               s.Bridge,
+		"cdrom":                s.CDROM,
+		"certificateauthority": s.CertificateAuthority,
+		"containerregistry":    s.ContainerRegistry,
+		"coupon":               s.Coupon,
+		"database":             s.Database,
+		"disk":                 s.Disk,
+		"diskplan":             s.DiskPlan,
+		"dns":                  s.DNS,
+		"enhanceddb":           s.EnhancedDB,
+		"esme":                 s.ESME,
+		"gslb":                 s.GSLB,
+		"icon":                 s.Icon,
+		"interface":            s.Interface,
+		"internet":             s.Internet,
+		"internetplan":         s.InternetPlan,
+		"ipaddress":            s.IPAddress,
+		"ipv6addr":             s.IPv6Addr,
+		"ipv6net":              s.IPv6Net,
+		"license":              s.License,
+		"licenseinfo":          s.LicenseInfo,
+		"loadbalancer":         s.LoadBalancer,
+		"localrouter":          s.LocalRouter,
+		"mobilegateway":        s.MobileGateway,
+		"nfs":                  s.NFS,
+		"note":                 s.Note,
+		"packetfilter":         s.PacketFilter,
+		"privatehost":          s.PrivateHost,
+		"privatehostplan":      s.PrivateHostPlan,
+		"proxylb":              s.ProxyLB,
+		"region":               s.Region,
+		"server":               s.Server,
+		"serverplan":           s.ServerPlan,
+		"serviceclass":         s.ServiceClass,
+		"sim":                  s.SIM,
+		"simplemonitor":        s.SimpleMonitor,
+		"sshkey":               s.SSHKey,
+		"subnet":               s.Subnet,
+		"switch":               s.Switch,
+		"vpcrouter":            s.VPCRouter,
+		"zone":                 s.Zone,
+	} {
+		registry.Register(kind, svc)
+	}
+	return registry
+}
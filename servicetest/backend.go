@@ -0,0 +1,230 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicetest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	disk "github.com/sacloud/iaas-service-go/disk/builder"
+	server "github.com/sacloud/iaas-service-go/server/builder"
+)
+
+// Backend fake間で共有されるインメモリな状態
+//
+// 各fakeはBackendを介してリソースを参照/更新するため、例えばServerのfakeでの削除はDiskのfakeからも観測できる。
+// ゾーンは区別せずにIDのみでリソースを管理する。
+type Backend struct {
+	*Faults
+
+	mu            sync.Mutex
+	nextID        int64
+	servers       map[types.ID]*iaas.Server
+	interfaces    map[types.ID]*iaas.Interface
+	disks         map[types.ID]*iaas.Disk
+	switches      map[types.ID]*iaas.Switch
+	packetFilters map[types.ID]*iaas.PacketFilter
+	archives      map[types.ID]*iaas.Archive
+	notes         map[types.ID]*iaas.Note
+	sshKeys       map[types.ID]*iaas.SSHKey
+	vpcRouters    map[types.ID]*iaas.VPCRouter
+}
+
+// NewBackend 空のBackendを返す
+//
+// ディスク作成時のコピー元として利用可能なアーカイブを1件保持した状態となる
+func NewBackend() *Backend {
+	b := &Backend{
+		Faults:        NewFaults(),
+		nextID:        100000000000,
+		servers:       make(map[types.ID]*iaas.Server),
+		interfaces:    make(map[types.ID]*iaas.Interface),
+		disks:         make(map[types.ID]*iaas.Disk),
+		switches:      make(map[types.ID]*iaas.Switch),
+		packetFilters: make(map[types.ID]*iaas.PacketFilter),
+		archives:      make(map[types.ID]*iaas.Archive),
+		notes:         make(map[types.ID]*iaas.Note),
+		sshKeys:       make(map[types.ID]*iaas.SSHKey),
+		vpcRouters:    make(map[types.ID]*iaas.VPCRouter),
+	}
+	b.AddArchive(&iaas.Archive{
+		Name:         "servicetest-archive",
+		Availability: types.Availabilities.Available,
+		SizeMB:       20 * 1024,
+		DiskPlanID:   types.DiskPlans.SSD,
+	})
+	return b
+}
+
+// ServerBuilderClient server/builderが利用するAPIクライアント群を返す
+func (b *Backend) ServerBuilderClient() *server.APIClient {
+	return &server.APIClient{
+		Disk:         &Disk{backend: b},
+		Interface:    &Interface{backend: b},
+		PacketFilter: &PacketFilter{backend: b},
+		Server:       &Server{backend: b},
+		ServerPlan:   &ServerPlan{backend: b},
+		Switch:       &Switch{backend: b},
+	}
+}
+
+// DiskBuilderClient disk/builderが利用するAPIクライアント群を返す
+func (b *Backend) DiskBuilderClient() *disk.APIClient {
+	return &disk.APIClient{
+		Archive:  &Archive{backend: b},
+		Disk:     &Disk{backend: b},
+		DiskPlan: &DiskPlan{backend: b},
+		Note:     &Note{backend: b},
+		SSHKey:   &SSHKey{backend: b},
+	}
+}
+
+// VPCRouterClient vpcrouter/builderが利用するAPIクライアントを返す
+func (b *Backend) VPCRouterClient() iaas.VPCRouterAPI {
+	return &VPCRouter{backend: b}
+}
+
+// AddSwitch スイッチを登録する
+//
+// IDが未指定の場合は採番した上で登録する
+func (b *Backend) AddSwitch(sw *iaas.Switch) *iaas.Switch {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if sw.ID.IsEmpty() {
+		sw.ID = b.newID()
+	}
+	b.switches[sw.ID] = sw
+	return sw
+}
+
+// AddPacketFilter パケットフィルタを登録する
+//
+// IDが未指定の場合は採番した上で登録する
+func (b *Backend) AddPacketFilter(pf *iaas.PacketFilter) *iaas.PacketFilter {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if pf.ID.IsEmpty() {
+		pf.ID = b.newID()
+	}
+	b.packetFilters[pf.ID] = pf
+	return pf
+}
+
+// AddArchive アーカイブを登録する
+//
+// IDが未指定の場合は採番した上で登録する
+func (b *Backend) AddArchive(archive *iaas.Archive) *iaas.Archive {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if archive.ID.IsEmpty() {
+		archive.ID = b.newID()
+	}
+	b.archives[archive.ID] = archive
+	return archive
+}
+
+// Servers 保持しているサーバをID順で返す
+func (b *Backend) Servers() []*iaas.Server {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var servers []*iaas.Server
+	for _, id := range sortedIDs(b.servers) {
+		servers = append(servers, b.serverView(id))
+	}
+	return servers
+}
+
+// Disks 保持しているディスクをID順で返す
+func (b *Backend) Disks() []*iaas.Disk {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var disks []*iaas.Disk
+	for _, id := range sortedIDs(b.disks) {
+		d := *b.disks[id]
+		disks = append(disks, &d)
+	}
+	return disks
+}
+
+// VPCRouters 保持しているVPCルータをID順で返す
+func (b *Backend) VPCRouters() []*iaas.VPCRouter {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var routers []*iaas.VPCRouter
+	for _, id := range sortedIDs(b.vpcRouters) {
+		v := *b.vpcRouters[id]
+		routers = append(routers, &v)
+	}
+	return routers
+}
+
+func (b *Backend) newID() types.ID {
+	b.nextID++
+	return types.ID(b.nextID)
+}
+
+func sortedIDs[T any](m map[types.ID]T) []types.ID {
+	var ids []types.ID
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// notFound 実際のAPIと同様に404を表すiaas.APIErrorを返す
+func notFound(method, resource string, id types.ID) error {
+	return iaas.NewAPIError(method, nil, http.StatusNotFound, &iaas.APIErrorResponse{
+		IsFatal:      true,
+		Status:       "404 Not Found",
+		ErrorCode:    "not_found",
+		ErrorMessage: fmt.Sprintf("%s[%s] not found", resource, id),
+	})
+}
+
+// conflict 実際のAPIと同様に409を表すiaas.APIErrorを返す
+func conflict(method, resource string, id types.ID, reason string) error {
+	return iaas.NewAPIError(method, nil, http.StatusConflict, &iaas.APIErrorResponse{
+		IsFatal:      true,
+		Status:       "409 Conflict",
+		ErrorCode:    "conflict",
+		ErrorMessage: fmt.Sprintf("%s[%s] %s", resource, id, reason),
+	})
+}
+
+// call 障害の注入を行った上でfnを呼び出す
+func call[T any](ctx context.Context, b *Backend, operation string, fn func(fault *Fault) (T, error)) (T, error) {
+	fault, err := b.hit(ctx, operation)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return fn(fault)
+}
+
+// callNoResult 障害の注入を行った上でfnを呼び出す
+func callNoResult(ctx context.Context, b *Backend, operation string, fn func() error) error {
+	_, err := call(ctx, b, operation, func(*Fault) (interface{}, error) {
+		return nil, fn()
+	})
+	return err
+}
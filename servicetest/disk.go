@@ -0,0 +1,367 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicetest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	disk "github.com/sacloud/iaas-service-go/disk/builder"
+	server "github.com/sacloud/iaas-service-go/server/builder"
+)
+
+var (
+	_ disk.CreateDiskHandler = (*Disk)(nil)
+	_ disk.DeleteDiskHandler = (*Disk)(nil)
+	_ server.DiskHandler     = (*Disk)(nil)
+	_ server.DiskReader      = (*Disk)(nil)
+	_ disk.ArchiveFinder     = (*Archive)(nil)
+	_ disk.PlanReader        = (*DiskPlan)(nil)
+	_ disk.NoteHandler       = (*Note)(nil)
+	_ disk.SSHKeyHandler     = (*SSHKey)(nil)
+)
+
+// diskPlanSizesGB DiskPlanのfakeが返すディスクサイズ
+var diskPlanSizesGB = []int{20, 40, 100, 250, 500, 1024, 2048, 4096}
+
+// Disk disk.CreateDiskHandler/server.DiskHandlerのfake実装
+//
+// 操作名は"Disk.<メソッド名>"となる
+type Disk struct {
+	backend *Backend
+}
+
+// Create .
+func (d *Disk) Create(ctx context.Context, zone string, createParam *iaas.DiskCreateRequest, distantFrom []types.ID) (*iaas.Disk, error) {
+	return call(ctx, d.backend, "Disk.Create", func(fault *Fault) (*iaas.Disk, error) {
+		return d.create(createParam, fault)
+	})
+}
+
+// CreateWithConfig .
+func (d *Disk) CreateWithConfig(
+	ctx context.Context,
+	zone string,
+	createParam *iaas.DiskCreateRequest,
+	editParam *iaas.DiskEditRequest,
+	bootAtAvailable bool,
+	distantFrom []types.ID,
+) (*iaas.Disk, error) {
+	return call(ctx, d.backend, "Disk.CreateWithConfig", func(fault *Fault) (*iaas.Disk, error) {
+		created, err := d.create(createParam, fault)
+		if err != nil {
+			return nil, err
+		}
+		if bootAtAvailable && !createParam.ServerID.IsEmpty() {
+			d.backend.servers[createParam.ServerID].InstanceStatus = types.ServerInstanceStatuses.Up
+		}
+		return created, nil
+	})
+}
+
+func (d *Disk) create(param *iaas.DiskCreateRequest, fault *Fault) (*iaas.Disk, error) {
+	b := d.backend
+	if !param.ServerID.IsEmpty() {
+		if _, ok := b.servers[param.ServerID]; !ok {
+			return nil, notFound("POST", "server", param.ServerID)
+		}
+	}
+	created := &iaas.Disk{
+		ID:                  b.newID(),
+		Name:                param.Name,
+		Description:         param.Description,
+		Tags:                param.Tags,
+		IconID:              param.IconID,
+		Availability:        types.Availabilities.Available,
+		Connection:          param.Connection,
+		EncryptionAlgorithm: param.EncryptionAlgorithm,
+		SizeMB:              param.SizeMB,
+		DiskPlanID:          param.DiskPlanID,
+		SourceDiskID:        param.SourceDiskID,
+		SourceArchiveID:     param.SourceArchiveID,
+	}
+	b.disks[created.ID] = created
+	if !param.ServerID.IsEmpty() {
+		d.connect(created, param.ServerID)
+	}
+	v := *created
+	v.Availability = availability(fault, v.Availability)
+	return &v, nil
+}
+
+// Update .
+func (d *Disk) Update(ctx context.Context, zone string, id types.ID, updateParam *iaas.DiskUpdateRequest) (*iaas.Disk, error) {
+	return call(ctx, d.backend, "Disk.Update", func(*Fault) (*iaas.Disk, error) {
+		target, ok := d.backend.disks[id]
+		if !ok {
+			return nil, notFound("PUT", "disk", id)
+		}
+		target.Name = updateParam.Name
+		target.Description = updateParam.Description
+		target.Tags = updateParam.Tags
+		target.IconID = updateParam.IconID
+		if updateParam.Connection != types.EDiskConnection("") {
+			target.Connection = updateParam.Connection
+		}
+		v := *target
+		return &v, nil
+	})
+}
+
+// Config .
+func (d *Disk) Config(ctx context.Context, zone string, id types.ID, editParam *iaas.DiskEditRequest) error {
+	return callNoResult(ctx, d.backend, "Disk.Config", func() error {
+		if _, ok := d.backend.disks[id]; !ok {
+			return notFound("PUT", "disk", id)
+		}
+		return nil
+	})
+}
+
+// Read .
+func (d *Disk) Read(ctx context.Context, zone string, id types.ID) (*iaas.Disk, error) {
+	return call(ctx, d.backend, "Disk.Read", func(fault *Fault) (*iaas.Disk, error) {
+		target, ok := d.backend.disks[id]
+		if !ok {
+			return nil, notFound("GET", "disk", id)
+		}
+		v := *target
+		v.Availability = availability(fault, v.Availability)
+		return &v, nil
+	})
+}
+
+// ConnectToServer .
+func (d *Disk) ConnectToServer(ctx context.Context, zone string, id types.ID, serverID types.ID) error {
+	return callNoResult(ctx, d.backend, "Disk.ConnectToServer", func() error {
+		target, ok := d.backend.disks[id]
+		if !ok {
+			return notFound("PUT", "disk", id)
+		}
+		if _, ok := d.backend.servers[serverID]; !ok {
+			return notFound("PUT", "server", serverID)
+		}
+		if !target.ServerID.IsEmpty() {
+			return conflict("PUT", "disk", id, "is already connected to server")
+		}
+		d.connect(target, serverID)
+		return nil
+	})
+}
+
+func (d *Disk) connect(target *iaas.Disk, serverID types.ID) {
+	order := 0
+	for _, other := range d.backend.disks {
+		if other.ServerID == serverID && other.ConnectionOrder > order {
+			order = other.ConnectionOrder
+		}
+	}
+	target.ServerID = serverID
+	target.ConnectionOrder = order + 1
+}
+
+// DisconnectFromServer .
+func (d *Disk) DisconnectFromServer(ctx context.Context, zone string, id types.ID) error {
+	return callNoResult(ctx, d.backend, "Disk.DisconnectFromServer", func() error {
+		target, ok := d.backend.disks[id]
+		if !ok {
+			return notFound("DELETE", "disk", id)
+		}
+		target.ServerID = types.ID(0)
+		target.ConnectionOrder = 0
+		return nil
+	})
+}
+
+// Delete .
+//
+// 起動中のサーバに接続されたディスクの削除は409エラーとなる
+func (d *Disk) Delete(ctx context.Context, zone string, id types.ID) error {
+	return callNoResult(ctx, d.backend, "Disk.Delete", func() error {
+		target, ok := d.backend.disks[id]
+		if !ok {
+			return notFound("DELETE", "disk", id)
+		}
+		if sv, ok := d.backend.servers[target.ServerID]; ok && sv.InstanceStatus.IsUp() {
+			return conflict("DELETE", "disk", id, "is connected to running server")
+		}
+		delete(d.backend.disks, id)
+		return nil
+	})
+}
+
+// Archive disk.ArchiveFinderのfake実装
+//
+// Findは検索条件によらずBackend.AddArchiveで登録したアーカイブを全て返す。操作名は"Archive.<メソッド名>"となる
+type Archive struct {
+	backend *Backend
+}
+
+// Find .
+func (a *Archive) Find(ctx context.Context, zone string, conditions *iaas.FindCondition) (*iaas.ArchiveFindResult, error) {
+	return call(ctx, a.backend, "Archive.Find", func(*Fault) (*iaas.ArchiveFindResult, error) {
+		result := &iaas.ArchiveFindResult{}
+		for _, id := range sortedIDs(a.backend.archives) {
+			v := *a.backend.archives[id]
+			result.Archives = append(result.Archives, &v)
+		}
+		result.Total = len(result.Archives)
+		result.Count = len(result.Archives)
+		return result, nil
+	})
+}
+
+// Read .
+func (a *Archive) Read(ctx context.Context, zone string, id types.ID) (*iaas.Archive, error) {
+	return call(ctx, a.backend, "Archive.Read", func(fault *Fault) (*iaas.Archive, error) {
+		archive, ok := a.backend.archives[id]
+		if !ok {
+			return nil, notFound("GET", "archive", id)
+		}
+		v := *archive
+		v.Availability = availability(fault, v.Availability)
+		return &v, nil
+	})
+}
+
+// DiskPlan disk.PlanReaderのfake実装
+//
+// 任意のIDに対し一般的なサイズを持つプランを返す。操作名は"DiskPlan.Read"となる
+type DiskPlan struct {
+	backend *Backend
+}
+
+// Read .
+func (p *DiskPlan) Read(ctx context.Context, zone string, id types.ID) (*iaas.DiskPlan, error) {
+	return call(ctx, p.backend, "DiskPlan.Read", func(*Fault) (*iaas.DiskPlan, error) {
+		plan := &iaas.DiskPlan{
+			ID:           id,
+			Name:         fmt.Sprintf("disk-plan/%s", id),
+			Availability: types.Availabilities.Available,
+		}
+		for _, sizeGB := range diskPlanSizesGB {
+			plan.Size = append(plan.Size, &iaas.DiskPlanSizeInfo{
+				Availability:  types.Availabilities.Available,
+				DisplaySize:   sizeGB,
+				DisplaySuffix: "GB",
+				SizeMB:        sizeGB * 1024,
+			})
+		}
+		return plan, nil
+	})
+}
+
+// Note disk.NoteHandlerのfake実装
+//
+// 操作名は"Note.<メソッド名>"となる
+type Note struct {
+	backend *Backend
+}
+
+// Read .
+func (n *Note) Read(ctx context.Context, id types.ID) (*iaas.Note, error) {
+	return call(ctx, n.backend, "Note.Read", func(*Fault) (*iaas.Note, error) {
+		note, ok := n.backend.notes[id]
+		if !ok {
+			return nil, notFound("GET", "note", id)
+		}
+		v := *note
+		return &v, nil
+	})
+}
+
+// Create .
+func (n *Note) Create(ctx context.Context, param *iaas.NoteCreateRequest) (*iaas.Note, error) {
+	return call(ctx, n.backend, "Note.Create", func(*Fault) (*iaas.Note, error) {
+		note := &iaas.Note{
+			ID:           n.backend.newID(),
+			Name:         param.Name,
+			Tags:         param.Tags,
+			IconID:       param.IconID,
+			Class:        param.Class,
+			Content:      param.Content,
+			Availability: types.Availabilities.Available,
+			Scope:        types.Scopes.User,
+		}
+		n.backend.notes[note.ID] = note
+		v := *note
+		return &v, nil
+	})
+}
+
+// Delete .
+func (n *Note) Delete(ctx context.Context, id types.ID) error {
+	return callNoResult(ctx, n.backend, "Note.Delete", func() error {
+		if _, ok := n.backend.notes[id]; !ok {
+			return notFound("DELETE", "note", id)
+		}
+		delete(n.backend.notes, id)
+		return nil
+	})
+}
+
+// SSHKey disk.SSHKeyHandlerのfake実装
+//
+// Generateはダミーの鍵を返す。操作名は"SSHKey.<メソッド名>"となる
+type SSHKey struct {
+	backend *Backend
+}
+
+// Read .
+func (s *SSHKey) Read(ctx context.Context, id types.ID) (*iaas.SSHKey, error) {
+	return call(ctx, s.backend, "SSHKey.Read", func(*Fault) (*iaas.SSHKey, error) {
+		key, ok := s.backend.sshKeys[id]
+		if !ok {
+			return nil, notFound("GET", "sshkey", id)
+		}
+		v := *key
+		return &v, nil
+	})
+}
+
+// Generate .
+func (s *SSHKey) Generate(ctx context.Context, param *iaas.SSHKeyGenerateRequest) (*iaas.SSHKeyGenerated, error) {
+	return call(ctx, s.backend, "SSHKey.Generate", func(*Fault) (*iaas.SSHKeyGenerated, error) {
+		key := &iaas.SSHKey{
+			ID:          s.backend.newID(),
+			Name:        param.Name,
+			Description: param.Description,
+			PublicKey:   "ssh-rsa servicetest",
+			Fingerprint: "servicetest",
+		}
+		s.backend.sshKeys[key.ID] = key
+		return &iaas.SSHKeyGenerated{
+			ID:          key.ID,
+			Name:        key.Name,
+			Description: key.Description,
+			PublicKey:   key.PublicKey,
+			Fingerprint: key.Fingerprint,
+			PrivateKey:  "servicetest private key",
+		}, nil
+	})
+}
+
+// Delete .
+func (s *SSHKey) Delete(ctx context.Context, id types.ID) error {
+	return callNoResult(ctx, s.backend, "SSHKey.Delete", func() error {
+		if _, ok := s.backend.sshKeys[id]; !ok {
+			return notFound("DELETE", "sshkey", id)
+		}
+		delete(s.backend.sshKeys, id)
+		return nil
+	})
+}
@@ -0,0 +1,140 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package servicetest 各builderが利用するAPIクライアントのインメモリなfake実装を提供する
+//
+// fakeはエラーや遅延、Availabilityの上書きといった障害を任意の呼び出し回数で注入できる。
+// setup.RetryableSetupのリトライ/削除処理や、builderを組み合わせた独自の処理のテストに利用する。
+package servicetest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// Fault fakeの呼び出しに注入する障害
+type Fault struct {
+	// Calls 障害を注入する呼び出し回数(1始まり)
+	//
+	// 未指定の場合は全ての呼び出しが対象となる
+	Calls []int
+	// Err 呼び出し結果として返すエラー
+	Err error
+	// Latency 呼び出しの前に待機する時間
+	Latency time.Duration
+	// Availability Read/Createの結果として返すリソースのAvailability
+	//
+	// 返却される値のみを上書きし、fakeが保持する状態は変更しない
+	Availability types.EAvailability
+}
+
+func (f *Fault) match(call int) bool {
+	if len(f.Calls) == 0 {
+		return true
+	}
+	for _, c := range f.Calls {
+		if c == call {
+			return true
+		}
+	}
+	return false
+}
+
+// CallRange fromからtoまで(toを含む)の呼び出し回数を返す
+func CallRange(from, to int) []int {
+	var calls []int
+	for i := from; i <= to; i++ {
+		calls = append(calls, i)
+	}
+	return calls
+}
+
+// Faults 操作ごとの呼び出し回数と注入する障害を管理する
+//
+// 操作は"Server.Create"や"Disk.Read"のように"リソース名.メソッド名"で指定する
+type Faults struct {
+	mu     sync.Mutex
+	calls  map[string]int
+	faults map[string][]*Fault
+}
+
+// NewFaults 障害が未登録のFaultsを返す
+func NewFaults() *Faults {
+	return &Faults{
+		calls:  make(map[string]int),
+		faults: make(map[string][]*Fault),
+	}
+}
+
+// Inject 指定の操作に障害を登録する
+//
+// 1つの呼び出しに複数の障害が該当する場合は先に登録されたものが優先される
+func (f *Faults) Inject(operation string, faults ...*Fault) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults[operation] = append(f.faults[operation], faults...)
+}
+
+// Calls 指定の操作がこれまでに呼び出された回数を返す
+func (f *Faults) Calls(operation string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[operation]
+}
+
+// Reset 呼び出し回数と登録済みの障害をクリアする
+func (f *Faults) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = make(map[string]int)
+	f.faults = make(map[string][]*Fault)
+}
+
+// hit 呼び出し回数を記録し、該当する障害があれば遅延を挟んだ上で返す
+func (f *Faults) hit(ctx context.Context, operation string) (*Fault, error) {
+	f.mu.Lock()
+	f.calls[operation]++
+	call := f.calls[operation]
+	var fault *Fault
+	for _, ft := range f.faults[operation] {
+		if ft.match(call) {
+			fault = ft
+			break
+		}
+	}
+	f.mu.Unlock()
+
+	if fault == nil {
+		return nil, nil
+	}
+	if fault.Latency > 0 {
+		select {
+		case <-ctx.Done():
+			return fault, ctx.Err()
+		case <-time.After(fault.Latency):
+		}
+	}
+	return fault, fault.Err
+}
+
+// availability 障害にAvailabilityが指定されていればそれを、そうでなければcurrentを返す
+func availability(fault *Fault, current types.EAvailability) types.EAvailability {
+	if fault != nil && fault.Availability != types.EAvailability("") {
+		return fault.Availability
+	}
+	return current
+}
@@ -0,0 +1,449 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicetest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/search"
+	"github.com/sacloud/iaas-api-go/types"
+	server "github.com/sacloud/iaas-service-go/server/builder"
+)
+
+var (
+	_ server.CreateServerHandler = (*Server)(nil)
+	_ server.DeleteServerHandler = (*Server)(nil)
+	_ server.InterfaceHandler    = (*Interface)(nil)
+	_ server.SwitchReader        = (*Switch)(nil)
+	_ server.PacketFilterReader  = (*PacketFilter)(nil)
+)
+
+// Server server.CreateServerHandlerのfake実装
+//
+// 操作名は"Server.<メソッド名>"となる
+type Server struct {
+	backend *Backend
+}
+
+// Create .
+func (s *Server) Create(ctx context.Context, zone string, param *iaas.ServerCreateRequest) (*iaas.Server, error) {
+	return call(ctx, s.backend, "Server.Create", func(fault *Fault) (*iaas.Server, error) {
+		b := s.backend
+		sv := &iaas.Server{
+			ID:                   b.newID(),
+			Name:                 param.Name,
+			Description:          param.Description,
+			Tags:                 param.Tags,
+			IconID:               param.IconID,
+			Availability:         types.Availabilities.Available,
+			InterfaceDriver:      param.InterfaceDriver,
+			CPU:                  param.CPU,
+			MemoryMB:             param.MemoryMB,
+			GPU:                  param.GPU,
+			ServerPlanCPUModel:   param.ServerPlanCPUModel,
+			ServerPlanCommitment: param.ServerPlanCommitment,
+			ServerPlanGeneration: param.ServerPlanGeneration,
+			InstanceStatus:       types.ServerInstanceStatuses.Down,
+			PrivateHostID:        param.PrivateHostID,
+		}
+		b.servers[sv.ID] = sv
+		for _, cs := range param.ConnectedSwitches {
+			nic := &iaas.Interface{ID: b.newID(), ServerID: sv.ID}
+			if cs == nil {
+				nic.SwitchScope = types.Scopes.Shared
+			} else {
+				nic.SwitchID = cs.ID
+				nic.SwitchScope = types.Scopes.User
+			}
+			b.interfaces[nic.ID] = nic
+		}
+		view := b.serverView(sv.ID)
+		view.Availability = availability(fault, view.Availability)
+		return view, nil
+	})
+}
+
+// Update .
+func (s *Server) Update(ctx context.Context, zone string, id types.ID, param *iaas.ServerUpdateRequest) (*iaas.Server, error) {
+	return call(ctx, s.backend, "Server.Update", func(*Fault) (*iaas.Server, error) {
+		sv, ok := s.backend.servers[id]
+		if !ok {
+			return nil, notFound("PUT", "server", id)
+		}
+		sv.Name = param.Name
+		sv.Description = param.Description
+		sv.Tags = param.Tags
+		sv.IconID = param.IconID
+		sv.PrivateHostID = param.PrivateHostID
+		sv.InterfaceDriver = param.InterfaceDriver
+		return s.backend.serverView(id), nil
+	})
+}
+
+// Read .
+func (s *Server) Read(ctx context.Context, zone string, id types.ID) (*iaas.Server, error) {
+	return call(ctx, s.backend, "Server.Read", func(fault *Fault) (*iaas.Server, error) {
+		if _, ok := s.backend.servers[id]; !ok {
+			return nil, notFound("GET", "server", id)
+		}
+		view := s.backend.serverView(id)
+		view.Availability = availability(fault, view.Availability)
+		return view, nil
+	})
+}
+
+// InsertCDROM .
+func (s *Server) InsertCDROM(ctx context.Context, zone string, id types.ID, insertParam *iaas.InsertCDROMRequest) error {
+	return callNoResult(ctx, s.backend, "Server.InsertCDROM", func() error {
+		sv, ok := s.backend.servers[id]
+		if !ok {
+			return notFound("PUT", "server", id)
+		}
+		sv.CDROMID = insertParam.ID
+		return nil
+	})
+}
+
+// EjectCDROM .
+func (s *Server) EjectCDROM(ctx context.Context, zone string, id types.ID, ejectParam *iaas.EjectCDROMRequest) error {
+	return callNoResult(ctx, s.backend, "Server.EjectCDROM", func() error {
+		sv, ok := s.backend.servers[id]
+		if !ok {
+			return notFound("DELETE", "server", id)
+		}
+		sv.CDROMID = types.ID(0)
+		return nil
+	})
+}
+
+// Boot .
+func (s *Server) Boot(ctx context.Context, zone string, id types.ID) error {
+	return callNoResult(ctx, s.backend, "Server.Boot", func() error {
+		return s.setInstanceStatus(id, types.ServerInstanceStatuses.Up)
+	})
+}
+
+// BootWithVariables .
+func (s *Server) BootWithVariables(ctx context.Context, zone string, id types.ID, param *iaas.ServerBootVariables) error {
+	return callNoResult(ctx, s.backend, "Server.BootWithVariables", func() error {
+		return s.setInstanceStatus(id, types.ServerInstanceStatuses.Up)
+	})
+}
+
+// Shutdown .
+func (s *Server) Shutdown(ctx context.Context, zone string, id types.ID, shutdownOption *iaas.ShutdownOption) error {
+	return callNoResult(ctx, s.backend, "Server.Shutdown", func() error {
+		return s.setInstanceStatus(id, types.ServerInstanceStatuses.Down)
+	})
+}
+
+func (s *Server) setInstanceStatus(id types.ID, status types.EServerInstanceStatus) error {
+	sv, ok := s.backend.servers[id]
+	if !ok {
+		return notFound("PUT", "server", id)
+	}
+	sv.InstanceStatus = status
+	return nil
+}
+
+// ChangePlan .
+//
+// 実際のAPIと同様にプラン変更後のサーバには新しいIDが割り当てられる
+func (s *Server) ChangePlan(ctx context.Context, zone string, id types.ID, plan *iaas.ServerChangePlanRequest) (*iaas.Server, error) {
+	return call(ctx, s.backend, "Server.ChangePlan", func(*Fault) (*iaas.Server, error) {
+		b := s.backend
+		sv, ok := b.servers[id]
+		if !ok {
+			return nil, notFound("PUT", "server", id)
+		}
+		if sv.InstanceStatus.IsUp() {
+			return nil, conflict("PUT", "server", id, "is running")
+		}
+		newID := b.newID()
+		delete(b.servers, id)
+		sv.ID = newID
+		sv.CPU = plan.CPU
+		sv.MemoryMB = plan.MemoryMB
+		sv.GPU = plan.GPU
+		sv.ServerPlanCPUModel = plan.ServerPlanCPUModel
+		sv.ServerPlanGeneration = plan.ServerPlanGeneration
+		sv.ServerPlanCommitment = plan.ServerPlanCommitment
+		b.servers[newID] = sv
+
+		for _, nic := range b.interfaces {
+			if nic.ServerID == id {
+				nic.ServerID = newID
+			}
+		}
+		for _, d := range b.disks {
+			if d.ServerID == id {
+				d.ServerID = newID
+			}
+		}
+		return b.serverView(newID), nil
+	})
+}
+
+// Delete .
+//
+// 起動中のサーバの削除は409エラーとなる。削除したサーバのNICも削除され、ディスクは切断される。
+func (s *Server) Delete(ctx context.Context, zone string, id types.ID) error {
+	return callNoResult(ctx, s.backend, "Server.Delete", func() error {
+		b := s.backend
+		sv, ok := b.servers[id]
+		if !ok {
+			return notFound("DELETE", "server", id)
+		}
+		if sv.InstanceStatus.IsUp() {
+			return conflict("DELETE", "server", id, "is running")
+		}
+		delete(b.servers, id)
+		for nicID, nic := range b.interfaces {
+			if nic.ServerID == id {
+				delete(b.interfaces, nicID)
+			}
+		}
+		for _, d := range b.disks {
+			if d.ServerID == id {
+				d.ServerID = types.ID(0)
+			}
+		}
+		return nil
+	})
+}
+
+// serverView 接続されたNIC/ディスクを反映したサーバのコピーを返す
+//
+// 呼び出し元でb.muをロックしておく必要がある
+func (b *Backend) serverView(id types.ID) *iaas.Server {
+	sv := *b.servers[id]
+	sv.Interfaces = nil
+	sv.Disks = nil
+
+	for _, nicID := range sortedIDs(b.interfaces) {
+		nic := b.interfaces[nicID]
+		if nic.ServerID != id {
+			continue
+		}
+		sv.Interfaces = append(sv.Interfaces, &iaas.InterfaceView{
+			ID:             nic.ID,
+			UserIPAddress:  nic.UserIPAddress,
+			SwitchID:       nic.SwitchID,
+			SwitchScope:    nic.SwitchScope,
+			PacketFilterID: nic.PacketFilterID,
+		})
+	}
+
+	var disks []*iaas.Disk
+	for _, d := range b.disks {
+		if d.ServerID == id {
+			disks = append(disks, d)
+		}
+	}
+	sort.Slice(disks, func(i, j int) bool { return disks[i].ConnectionOrder < disks[j].ConnectionOrder })
+	for _, d := range disks {
+		sv.Disks = append(sv.Disks, &iaas.ServerConnectedDisk{
+			ID:              d.ID,
+			Name:            d.Name,
+			Availability:    d.Availability,
+			Connection:      d.Connection,
+			ConnectionOrder: d.ConnectionOrder,
+			SizeMB:          d.SizeMB,
+			DiskPlanID:      d.DiskPlanID,
+		})
+	}
+	return &sv
+}
+
+// Interface server.InterfaceHandlerのfake実装
+//
+// 操作名は"Interface.<メソッド名>"となる
+type Interface struct {
+	backend *Backend
+}
+
+// Create .
+func (i *Interface) Create(ctx context.Context, zone string, param *iaas.InterfaceCreateRequest) (*iaas.Interface, error) {
+	return call(ctx, i.backend, "Interface.Create", func(*Fault) (*iaas.Interface, error) {
+		b := i.backend
+		if _, ok := b.servers[param.ServerID]; !ok {
+			return nil, notFound("POST", "server", param.ServerID)
+		}
+		nic := &iaas.Interface{ID: b.newID(), ServerID: param.ServerID}
+		b.interfaces[nic.ID] = nic
+		v := *nic
+		return &v, nil
+	})
+}
+
+// Update .
+func (i *Interface) Update(ctx context.Context, zone string, id types.ID, param *iaas.InterfaceUpdateRequest) (*iaas.Interface, error) {
+	return call(ctx, i.backend, "Interface.Update", func(*Fault) (*iaas.Interface, error) {
+		nic, ok := i.backend.interfaces[id]
+		if !ok {
+			return nil, notFound("PUT", "interface", id)
+		}
+		nic.UserIPAddress = param.UserIPAddress
+		v := *nic
+		return &v, nil
+	})
+}
+
+// Delete .
+func (i *Interface) Delete(ctx context.Context, zone string, id types.ID) error {
+	return callNoResult(ctx, i.backend, "Interface.Delete", func() error {
+		if _, ok := i.backend.interfaces[id]; !ok {
+			return notFound("DELETE", "interface", id)
+		}
+		delete(i.backend.interfaces, id)
+		return nil
+	})
+}
+
+// ConnectToSharedSegment .
+func (i *Interface) ConnectToSharedSegment(ctx context.Context, zone string, id types.ID) error {
+	return callNoResult(ctx, i.backend, "Interface.ConnectToSharedSegment", func() error {
+		return i.update(id, func(nic *iaas.Interface) {
+			nic.SwitchID = types.ID(0)
+			nic.SwitchScope = types.Scopes.Shared
+		})
+	})
+}
+
+// ConnectToSwitch .
+func (i *Interface) ConnectToSwitch(ctx context.Context, zone string, id types.ID, switchID types.ID) error {
+	return callNoResult(ctx, i.backend, "Interface.ConnectToSwitch", func() error {
+		return i.update(id, func(nic *iaas.Interface) {
+			nic.SwitchID = switchID
+			nic.SwitchScope = types.Scopes.User
+		})
+	})
+}
+
+// DisconnectFromSwitch .
+func (i *Interface) DisconnectFromSwitch(ctx context.Context, zone string, id types.ID) error {
+	return callNoResult(ctx, i.backend, "Interface.DisconnectFromSwitch", func() error {
+		return i.update(id, func(nic *iaas.Interface) {
+			nic.SwitchID = types.ID(0)
+			nic.SwitchScope = types.EScope("")
+		})
+	})
+}
+
+// ConnectToPacketFilter .
+func (i *Interface) ConnectToPacketFilter(ctx context.Context, zone string, id types.ID, packetFilterID types.ID) error {
+	return callNoResult(ctx, i.backend, "Interface.ConnectToPacketFilter", func() error {
+		return i.update(id, func(nic *iaas.Interface) {
+			nic.PacketFilterID = packetFilterID
+		})
+	})
+}
+
+// DisconnectFromPacketFilter .
+func (i *Interface) DisconnectFromPacketFilter(ctx context.Context, zone string, id types.ID) error {
+	return callNoResult(ctx, i.backend, "Interface.DisconnectFromPacketFilter", func() error {
+		return i.update(id, func(nic *iaas.Interface) {
+			nic.PacketFilterID = types.ID(0)
+		})
+	})
+}
+
+func (i *Interface) update(id types.ID, fn func(nic *iaas.Interface)) error {
+	nic, ok := i.backend.interfaces[id]
+	if !ok {
+		return notFound("PUT", "interface", id)
+	}
+	fn(nic)
+	return nil
+}
+
+// Switch server.SwitchReaderのfake実装
+//
+// Backend.AddSwitchで登録したスイッチを返す。操作名は"Switch.Read"となる
+type Switch struct {
+	backend *Backend
+}
+
+// Read .
+func (s *Switch) Read(ctx context.Context, zone string, id types.ID) (*iaas.Switch, error) {
+	return call(ctx, s.backend, "Switch.Read", func(*Fault) (*iaas.Switch, error) {
+		sw, ok := s.backend.switches[id]
+		if !ok {
+			return nil, notFound("GET", "switch", id)
+		}
+		v := *sw
+		return &v, nil
+	})
+}
+
+// PacketFilter server.PacketFilterReaderのfake実装
+//
+// Backend.AddPacketFilterで登録したパケットフィルタを返す。操作名は"PacketFilter.Read"となる
+type PacketFilter struct {
+	backend *Backend
+}
+
+// Read .
+func (p *PacketFilter) Read(ctx context.Context, zone string, id types.ID) (*iaas.PacketFilter, error) {
+	return call(ctx, p.backend, "PacketFilter.Read", func(*Fault) (*iaas.PacketFilter, error) {
+		pf, ok := p.backend.packetFilters[id]
+		if !ok {
+			return nil, notFound("GET", "packetfilter", id)
+		}
+		v := *pf
+		return &v, nil
+	})
+}
+
+// ServerPlan query.ServerPlanFinderのfake実装
+//
+// 検索条件のCPU/MemoryMB/GPUを持つプランを常に1件返す。操作名は"ServerPlan.Find"となる
+type ServerPlan struct {
+	backend *Backend
+}
+
+// Find .
+func (p *ServerPlan) Find(ctx context.Context, zone string, conditions *iaas.FindCondition) (*iaas.ServerPlanFindResult, error) {
+	return call(ctx, p.backend, "ServerPlan.Find", func(*Fault) (*iaas.ServerPlanFindResult, error) {
+		plan := &iaas.ServerPlan{
+			ID:           p.backend.newID(),
+			CPU:          1,
+			MemoryMB:     1024,
+			Commitment:   types.Commitments.Standard,
+			Generation:   types.PlanGenerations.G200,
+			Availability: types.Availabilities.Available,
+		}
+		if conditions != nil {
+			if v, ok := conditions.Filter[search.Key("CPU")].(int); ok {
+				plan.CPU = v
+			}
+			if v, ok := conditions.Filter[search.Key("MemoryMB")].(int); ok {
+				plan.MemoryMB = v
+			}
+			if v, ok := conditions.Filter[search.Key("GPU")].(int); ok {
+				plan.GPU = v
+			}
+		}
+		plan.Name = fmt.Sprintf("plan/%dcore-%dMB", plan.CPU, plan.MemoryMB)
+		return &iaas.ServerPlanFindResult{
+			Total:       1,
+			Count:       1,
+			ServerPlans: []*iaas.ServerPlan{plan},
+		}, nil
+	})
+}
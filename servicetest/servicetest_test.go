@@ -0,0 +1,185 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicetest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/accessor"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/rollback"
+	server "github.com/sacloud/iaas-service-go/server/builder"
+	"github.com/sacloud/iaas-service-go/servicetest"
+	"github.com/sacloud/iaas-service-go/setup"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaults(t *testing.T) {
+	ctx := context.Background()
+	backend := servicetest.NewBackend()
+	client := backend.ServerBuilderClient()
+
+	created, err := client.Server.Create(ctx, "is1a", &iaas.ServerCreateRequest{Name: "example"})
+	require.NoError(t, err)
+
+	t.Run("error at chosen call", func(t *testing.T) {
+		backend.Inject("Server.Read", &servicetest.Fault{Calls: []int{2}, Err: errors.New("dummy")})
+		defer backend.Reset()
+
+		_, err := client.Server.Read(ctx, "is1a", created.ID)
+		require.NoError(t, err)
+		_, err = client.Server.Read(ctx, "is1a", created.ID)
+		require.EqualError(t, err, "dummy")
+		_, err = client.Server.Read(ctx, "is1a", created.ID)
+		require.NoError(t, err)
+		require.Equal(t, 3, backend.Calls("Server.Read"))
+	})
+
+	t.Run("availability override", func(t *testing.T) {
+		backend.Inject("Server.Read", &servicetest.Fault{
+			Calls:        servicetest.CallRange(1, 2),
+			Availability: types.Availabilities.Migrating,
+		})
+		defer backend.Reset()
+
+		for _, expected := range []types.EAvailability{
+			types.Availabilities.Migrating,
+			types.Availabilities.Migrating,
+			types.Availabilities.Available,
+		} {
+			read, err := client.Server.Read(ctx, "is1a", created.ID)
+			require.NoError(t, err)
+			require.Equal(t, expected, read.Availability)
+		}
+	})
+
+	t.Run("latency honors context", func(t *testing.T) {
+		backend.Inject("Server.Read", &servicetest.Fault{Latency: time.Minute})
+		defer backend.Reset()
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		_, err := client.Server.Read(ctx, "is1a", created.ID)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := client.Server.Read(ctx, "is1a", types.ID(1))
+		require.True(t, iaas.IsNotFoundError(err))
+		require.ErrorIs(t, service.NewError(nil, err), service.ErrNotFound)
+	})
+}
+
+func TestRetryableSetup_withFakes(t *testing.T) {
+	ctx := context.Background()
+	zone := "is1a"
+
+	newSetup := func(client iaas.VPCRouterAPI, options *setup.Options) *setup.RetryableSetup {
+		return &setup.RetryableSetup{
+			Create: func(ctx context.Context, zone string) (accessor.ID, error) {
+				return client.Create(ctx, zone, &iaas.VPCRouterCreateRequest{Name: "example"})
+			},
+			Read: func(ctx context.Context, zone string, id types.ID) (interface{}, error) {
+				return client.Read(ctx, zone, id)
+			},
+			Delete: func(ctx context.Context, zone string, id types.ID) error {
+				return client.Delete(ctx, zone, id)
+			},
+			IsWaitForCopy: true,
+			Options:       options,
+		}
+	}
+	newOptions := func() *setup.Options {
+		return &setup.Options{
+			RetryCount:                3,
+			DeleteRetryCount:          3,
+			DeleteRetryInterval:       time.Millisecond,
+			PollingInterval:           time.Millisecond,
+			ProvisioningRetryInterval: time.Millisecond,
+		}
+	}
+
+	t.Run("retry after Failed availability", func(t *testing.T) {
+		backend := servicetest.NewBackend()
+		backend.Inject("VPCRouter.Read", &servicetest.Fault{Calls: []int{1}, Availability: types.Availabilities.Failed})
+		// Failed直後の削除は失敗するケース
+		backend.Inject("VPCRouter.Delete", &servicetest.Fault{Calls: []int{1}, Err: errors.New("dummy")})
+
+		created, err := newSetup(backend.VPCRouterClient(), newOptions()).Setup(ctx, zone)
+		require.NoError(t, err)
+
+		require.Equal(t, 2, backend.Calls("VPCRouter.Create"))
+		require.Equal(t, 2, backend.Calls("VPCRouter.Delete"))
+		routers := backend.VPCRouters()
+		require.Len(t, routers, 1)
+		require.Equal(t, routers[0].ID, created.(*iaas.VPCRouter).ID)
+	})
+
+	t.Run("max retry count exceeded", func(t *testing.T) {
+		backend := servicetest.NewBackend()
+		backend.Inject("VPCRouter.Read", &servicetest.Fault{Availability: types.Availabilities.Failed})
+
+		_, err := newSetup(backend.VPCRouterClient(), newOptions()).Setup(ctx, zone)
+		require.ErrorIs(t, err, service.ErrMaxRetryCountExceeded)
+		require.Equal(t, 4, backend.Calls("VPCRouter.Create"))
+		require.Empty(t, backend.VPCRouters())
+	})
+
+	t.Run("rollback stuck in Migrating", func(t *testing.T) {
+		backend := servicetest.NewBackend()
+		backend.Inject("VPCRouter.Read", &servicetest.Fault{Availability: types.Availabilities.Migrating})
+
+		options := newOptions()
+		options.RollbackOnFailure = true
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		_, err := newSetup(backend.VPCRouterClient(), options).Setup(ctx, zone)
+		require.Error(t, err)
+		require.Equal(t, 1, backend.Calls("VPCRouter.Delete"))
+		require.Empty(t, backend.VPCRouters())
+	})
+}
+
+func TestServerBuilder_withFakes(t *testing.T) {
+	ctx := context.Background()
+	zone := "is1a"
+
+	backend := servicetest.NewBackend()
+	sw := backend.AddSwitch(&iaas.Switch{Name: "example"})
+	backend.Inject("Server.InsertCDROM", &servicetest.Fault{Err: errors.New("dummy")})
+
+	builder := &server.Builder{
+		Name:              "example",
+		CPU:               2,
+		MemoryGB:          4,
+		NIC:               &server.ConnectedNICSetting{SwitchID: sw.ID},
+		CDROMID:           types.ID(1),
+		RollbackOnFailure: true,
+		Client:            backend.ServerBuilderClient(),
+	}
+	_, err := builder.Build(ctx, zone)
+	require.Error(t, err)
+
+	var rollbackErr *rollback.Error
+	require.ErrorAs(t, err, &rollbackErr)
+	require.Equal(t, 1, backend.Calls("Server.Delete"))
+	require.Empty(t, backend.Servers())
+}
@@ -0,0 +1,291 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicetest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+var _ iaas.VPCRouterAPI = (*VPCRouter)(nil)
+
+// VPCRouter iaas.VPCRouterAPIのfake実装
+//
+// 操作名は"VPCRouter.<メソッド名>"となる。
+// UpdateSettingsでSettingsHashが指定された場合、実際のAPIと同様に現在の値と異なれば409エラーとなる。
+type VPCRouter struct {
+	backend *Backend
+}
+
+// Find .
+func (v *VPCRouter) Find(ctx context.Context, zone string, conditions *iaas.FindCondition) (*iaas.VPCRouterFindResult, error) {
+	return call(ctx, v.backend, "VPCRouter.Find", func(*Fault) (*iaas.VPCRouterFindResult, error) {
+		result := &iaas.VPCRouterFindResult{}
+		for _, id := range sortedIDs(v.backend.vpcRouters) {
+			result.VPCRouters = append(result.VPCRouters, copyVPCRouter(v.backend.vpcRouters[id]))
+		}
+		result.Total = len(result.VPCRouters)
+		result.Count = len(result.VPCRouters)
+		return result, nil
+	})
+}
+
+// Create .
+func (v *VPCRouter) Create(ctx context.Context, zone string, param *iaas.VPCRouterCreateRequest) (*iaas.VPCRouter, error) {
+	return call(ctx, v.backend, "VPCRouter.Create", func(fault *Fault) (*iaas.VPCRouter, error) {
+		b := v.backend
+		router := &iaas.VPCRouter{
+			ID:             b.newID(),
+			Name:           param.Name,
+			Description:    param.Description,
+			Tags:           param.Tags,
+			IconID:         param.IconID,
+			Class:          "vpcrouter",
+			Availability:   types.Availabilities.Available,
+			PlanID:         param.PlanID,
+			Version:        param.Version,
+			Settings:       param.Settings,
+			InstanceStatus: types.ServerInstanceStatuses.Down,
+		}
+		router.SettingsHash = v.settingsHash(router.ID)
+		nic := &iaas.VPCRouterInterface{ID: b.newID(), Index: 0}
+		if param.Switch != nil {
+			nic.SwitchID = param.Switch.ID
+			nic.SwitchScope = param.Switch.Scope
+		}
+		router.Interfaces = []*iaas.VPCRouterInterface{nic}
+		b.vpcRouters[router.ID] = router
+
+		created := copyVPCRouter(router)
+		created.Availability = availability(fault, created.Availability)
+		return created, nil
+	})
+}
+
+// Read .
+func (v *VPCRouter) Read(ctx context.Context, zone string, id types.ID) (*iaas.VPCRouter, error) {
+	return call(ctx, v.backend, "VPCRouter.Read", func(fault *Fault) (*iaas.VPCRouter, error) {
+		router, ok := v.backend.vpcRouters[id]
+		if !ok {
+			return nil, notFound("GET", "vpcrouter", id)
+		}
+		read := copyVPCRouter(router)
+		read.Availability = availability(fault, read.Availability)
+		return read, nil
+	})
+}
+
+// Update .
+func (v *VPCRouter) Update(ctx context.Context, zone string, id types.ID, param *iaas.VPCRouterUpdateRequest) (*iaas.VPCRouter, error) {
+	return call(ctx, v.backend, "VPCRouter.Update", func(*Fault) (*iaas.VPCRouter, error) {
+		router, err := v.updateSettings(id, param.Settings, param.SettingsHash)
+		if err != nil {
+			return nil, err
+		}
+		router.Name = param.Name
+		router.Description = param.Description
+		router.Tags = param.Tags
+		router.IconID = param.IconID
+		return copyVPCRouter(router), nil
+	})
+}
+
+// UpdateSettings .
+func (v *VPCRouter) UpdateSettings(ctx context.Context, zone string, id types.ID, param *iaas.VPCRouterUpdateSettingsRequest) (*iaas.VPCRouter, error) {
+	return call(ctx, v.backend, "VPCRouter.UpdateSettings", func(*Fault) (*iaas.VPCRouter, error) {
+		router, err := v.updateSettings(id, param.Settings, param.SettingsHash)
+		if err != nil {
+			return nil, err
+		}
+		return copyVPCRouter(router), nil
+	})
+}
+
+func (v *VPCRouter) updateSettings(id types.ID, settings *iaas.VPCRouterSetting, hash string) (*iaas.VPCRouter, error) {
+	router, ok := v.backend.vpcRouters[id]
+	if !ok {
+		return nil, notFound("PUT", "vpcrouter", id)
+	}
+	if hash != "" && hash != router.SettingsHash {
+		return nil, conflict("PUT", "vpcrouter", id, "settings hash is mismatched")
+	}
+	router.Settings = settings
+	router.SettingsHash = v.settingsHash(id)
+	return router, nil
+}
+
+func (v *VPCRouter) settingsHash(id types.ID) string {
+	return fmt.Sprintf("%s-%s", id, v.backend.newID())
+}
+
+// Delete .
+//
+// 起動中のVPCルータの削除は409エラーとなる
+func (v *VPCRouter) Delete(ctx context.Context, zone string, id types.ID) error {
+	return callNoResult(ctx, v.backend, "VPCRouter.Delete", func() error {
+		router, ok := v.backend.vpcRouters[id]
+		if !ok {
+			return notFound("DELETE", "vpcrouter", id)
+		}
+		if router.InstanceStatus.IsUp() {
+			return conflict("DELETE", "vpcrouter", id, "is running")
+		}
+		delete(v.backend.vpcRouters, id)
+		return nil
+	})
+}
+
+// Config .
+func (v *VPCRouter) Config(ctx context.Context, zone string, id types.ID) error {
+	return callNoResult(ctx, v.backend, "VPCRouter.Config", func() error {
+		if _, ok := v.backend.vpcRouters[id]; !ok {
+			return notFound("PUT", "vpcrouter", id)
+		}
+		return nil
+	})
+}
+
+// Boot .
+func (v *VPCRouter) Boot(ctx context.Context, zone string, id types.ID) error {
+	return callNoResult(ctx, v.backend, "VPCRouter.Boot", func() error {
+		return v.setInstanceStatus(id, types.ServerInstanceStatuses.Up)
+	})
+}
+
+// Shutdown .
+func (v *VPCRouter) Shutdown(ctx context.Context, zone string, id types.ID, shutdownOption *iaas.ShutdownOption) error {
+	return callNoResult(ctx, v.backend, "VPCRouter.Shutdown", func() error {
+		return v.setInstanceStatus(id, types.ServerInstanceStatuses.Down)
+	})
+}
+
+// Reset .
+func (v *VPCRouter) Reset(ctx context.Context, zone string, id types.ID) error {
+	return callNoResult(ctx, v.backend, "VPCRouter.Reset", func() error {
+		return v.setInstanceStatus(id, types.ServerInstanceStatuses.Up)
+	})
+}
+
+func (v *VPCRouter) setInstanceStatus(id types.ID, status types.EServerInstanceStatus) error {
+	router, ok := v.backend.vpcRouters[id]
+	if !ok {
+		return notFound("PUT", "vpcrouter", id)
+	}
+	router.InstanceStatus = status
+	return nil
+}
+
+// ConnectToSwitch .
+func (v *VPCRouter) ConnectToSwitch(ctx context.Context, zone string, id types.ID, nicIndex int, switchID types.ID) error {
+	return callNoResult(ctx, v.backend, "VPCRouter.ConnectToSwitch", func() error {
+		router, ok := v.backend.vpcRouters[id]
+		if !ok {
+			return notFound("PUT", "vpcrouter", id)
+		}
+		for _, nic := range router.Interfaces {
+			if nic.Index == nicIndex {
+				return conflict("PUT", "vpcrouter", id, fmt.Sprintf("nic[%d] is already connected", nicIndex))
+			}
+		}
+		router.Interfaces = append(router.Interfaces, &iaas.VPCRouterInterface{
+			ID:          v.backend.newID(),
+			Index:       nicIndex,
+			SwitchID:    switchID,
+			SwitchScope: types.Scopes.User,
+		})
+		return nil
+	})
+}
+
+// DisconnectFromSwitch .
+func (v *VPCRouter) DisconnectFromSwitch(ctx context.Context, zone string, id types.ID, nicIndex int) error {
+	return callNoResult(ctx, v.backend, "VPCRouter.DisconnectFromSwitch", func() error {
+		router, ok := v.backend.vpcRouters[id]
+		if !ok {
+			return notFound("DELETE", "vpcrouter", id)
+		}
+		var interfaces []*iaas.VPCRouterInterface
+		for _, nic := range router.Interfaces {
+			if nic.Index != nicIndex {
+				interfaces = append(interfaces, nic)
+			}
+		}
+		router.Interfaces = interfaces
+		return nil
+	})
+}
+
+// MonitorCPU .
+func (v *VPCRouter) MonitorCPU(ctx context.Context, zone string, id types.ID, condition *iaas.MonitorCondition) (*iaas.CPUTimeActivity, error) {
+	return call(ctx, v.backend, "VPCRouter.MonitorCPU", func(*Fault) (*iaas.CPUTimeActivity, error) {
+		if _, ok := v.backend.vpcRouters[id]; !ok {
+			return nil, notFound("GET", "vpcrouter", id)
+		}
+		return &iaas.CPUTimeActivity{}, nil
+	})
+}
+
+// MonitorInterface .
+func (v *VPCRouter) MonitorInterface(ctx context.Context, zone string, id types.ID, index int, condition *iaas.MonitorCondition) (*iaas.InterfaceActivity, error) {
+	return call(ctx, v.backend, "VPCRouter.MonitorInterface", func(*Fault) (*iaas.InterfaceActivity, error) {
+		if _, ok := v.backend.vpcRouters[id]; !ok {
+			return nil, notFound("GET", "vpcrouter", id)
+		}
+		return &iaas.InterfaceActivity{}, nil
+	})
+}
+
+// Status .
+func (v *VPCRouter) Status(ctx context.Context, zone string, id types.ID) (*iaas.VPCRouterStatus, error) {
+	return call(ctx, v.backend, "VPCRouter.Status", func(*Fault) (*iaas.VPCRouterStatus, error) {
+		if _, ok := v.backend.vpcRouters[id]; !ok {
+			return nil, notFound("GET", "vpcrouter", id)
+		}
+		return &iaas.VPCRouterStatus{}, nil
+	})
+}
+
+// Logs .
+func (v *VPCRouter) Logs(ctx context.Context, zone string, id types.ID) (*iaas.VPCRouterLog, error) {
+	return call(ctx, v.backend, "VPCRouter.Logs", func(*Fault) (*iaas.VPCRouterLog, error) {
+		if _, ok := v.backend.vpcRouters[id]; !ok {
+			return nil, notFound("GET", "vpcrouter", id)
+		}
+		return &iaas.VPCRouterLog{}, nil
+	})
+}
+
+// Ping .
+func (v *VPCRouter) Ping(ctx context.Context, zone string, id types.ID, destination string) (*iaas.VPCRouterPingResults, error) {
+	return call(ctx, v.backend, "VPCRouter.Ping", func(*Fault) (*iaas.VPCRouterPingResults, error) {
+		if _, ok := v.backend.vpcRouters[id]; !ok {
+			return nil, notFound("POST", "vpcrouter", id)
+		}
+		return &iaas.VPCRouterPingResults{}, nil
+	})
+}
+
+func copyVPCRouter(router *iaas.VPCRouter) *iaas.VPCRouter {
+	v := *router
+	v.Interfaces = nil
+	for _, nic := range router.Interfaces {
+		n := *nic
+		v.Interfaces = append(v.Interfaces, &n)
+	}
+	return &v
+}
@@ -0,0 +1,182 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package setup
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+// DefaultBackoffMultiplier ExponentialBackoffでMultiplierが未指定の場合の倍率
+var DefaultBackoffMultiplier = 2.0
+
+// Backoff リトライ時の待機時間を決定するストラテジー
+type Backoff interface {
+	// Next retry回目(1始まり)のリトライの前に待機する時間を返す
+	//
+	// elapsedは最初の試行からの経過時間。falseを返した場合はそれ以上リトライしない
+	Next(retry int, elapsed time.Duration) (time.Duration, bool)
+}
+
+// ConstantBackoff 一定間隔で待機するBackoff
+type ConstantBackoff struct {
+	Interval time.Duration
+	// MaxElapsedTime 最初の試行からこの時間を経過する場合はリトライしない、0の場合は無制限
+	MaxElapsedTime time.Duration
+}
+
+// Next Backoffの実装
+func (b *ConstantBackoff) Next(_ int, elapsed time.Duration) (time.Duration, bool) {
+	if b.MaxElapsedTime > 0 && elapsed+b.Interval > b.MaxElapsedTime {
+		return 0, false
+	}
+	return b.Interval, true
+}
+
+// ExponentialBackoff リトライごとに待機時間を指数的に延ばすBackoff
+type ExponentialBackoff struct {
+	// InitialInterval 初回リトライ前の待機時間
+	InitialInterval time.Duration
+	// Multiplier リトライごとに待機時間に掛ける倍率、0の場合はDefaultBackoffMultiplier
+	Multiplier float64
+	// MaxInterval 待機時間の上限、0の場合は無制限
+	MaxInterval time.Duration
+	// Jitter 待機時間をランダムに増減させる割合(0〜1)
+	//
+	// 例えば0.5の場合、待機時間は算出値の50%〜150%の範囲となる
+	Jitter float64
+	// MaxElapsedTime 最初の試行からこの時間を経過する場合はリトライしない、0の場合は無制限
+	MaxElapsedTime time.Duration
+}
+
+// Next Backoffの実装
+func (b *ExponentialBackoff) Next(retry int, elapsed time.Duration) (time.Duration, bool) {
+	multiplier := b.Multiplier
+	if multiplier <= 0 {
+		multiplier = DefaultBackoffMultiplier
+	}
+
+	interval := float64(b.InitialInterval)
+	for i := 1; i < retry; i++ {
+		interval *= multiplier
+		if b.MaxInterval > 0 && interval > float64(b.MaxInterval) {
+			break
+		}
+	}
+	if b.MaxInterval > 0 && interval > float64(b.MaxInterval) {
+		interval = float64(b.MaxInterval)
+	}
+	if b.Jitter > 0 {
+		delta := interval * b.Jitter
+		interval = interval - delta + rand.Float64()*2*delta //nolint:gosec
+	}
+
+	d := time.Duration(interval)
+	if b.MaxElapsedTime > 0 && elapsed+d > b.MaxElapsedTime {
+		return 0, false
+	}
+	return d, true
+}
+
+// ErrorClassifier エラーがリトライ可能か判定する
+type ErrorClassifier func(err error) bool
+
+// DefaultErrorClassifier デフォルトのErrorClassifier
+//
+// contextのキャンセル/タイムアウト、バリデーションエラー、400/401/403を返すAPIエラーはリトライ不可と判定する。
+// 409(リソースが使用中など)や5xxを含むそれ以外のエラーはリトライ可能と判定する。
+func DefaultErrorClassifier(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, service.ErrValidationFailed) {
+		return false
+	}
+	var apiErr iaas.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ResponseCode() {
+		case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+			return false
+		}
+	}
+	return true
+}
+
+// Sleep ctxがキャンセルされるまでの間、dだけ待機する
+//
+// 待機中にctxがキャンセルされた場合はctx.Err()を返す
+func Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retrier Optionsに従ってリトライを行う
+type retrier struct {
+	backoff     Backoff
+	isRetryable ErrorClassifier
+	maxAttempts int
+	started     time.Time
+}
+
+// newRetrier 最大試行回数とBackoff未指定時の待機間隔を指定してretrierを返す
+func (o *Options) newRetrier(maxAttempts int, interval time.Duration) *retrier {
+	backoff := o.Backoff
+	if backoff == nil {
+		backoff = &ConstantBackoff{Interval: interval}
+	}
+	isRetryable := o.IsRetryable
+	if isRetryable == nil {
+		isRetryable = DefaultErrorClassifier
+	}
+	return &retrier{
+		backoff:     backoff,
+		isRetryable: isRetryable,
+		maxAttempts: maxAttempts,
+		started:     time.Now(),
+	}
+}
+
+// next attempt回目の試行がerrで失敗した後、次の試行までに待機する時間を返す
+//
+// 試行回数の上限に達した場合やリトライ不可なエラーの場合はfalseを返す
+func (r *retrier) next(attempt int, err error) (time.Duration, bool) {
+	if attempt >= r.maxAttempts || (err != nil && !r.isRetryable(err)) {
+		return 0, false
+	}
+	return r.delay(attempt)
+}
+
+// delay retry回目のリトライの前に待機する時間を返す
+func (r *retrier) delay(retry int) (time.Duration, bool) {
+	return r.backoff.Next(retry, time.Since(r.started))
+}
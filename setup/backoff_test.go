@@ -0,0 +1,104 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package setup
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExponentialBackoff_Next(t *testing.T) {
+	t.Run("grows exponentially up to MaxInterval", func(t *testing.T) {
+		backoff := &ExponentialBackoff{
+			InitialInterval: time.Second,
+			MaxInterval:     5 * time.Second,
+		}
+		var got []time.Duration
+		for retry := 1; retry <= 5; retry++ {
+			d, ok := backoff.Next(retry, 0)
+			require.True(t, ok)
+			got = append(got, d)
+		}
+		require.Equal(t, []time.Duration{
+			time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second,
+		}, got)
+	})
+
+	t.Run("jitter", func(t *testing.T) {
+		backoff := &ExponentialBackoff{
+			InitialInterval: time.Second,
+			Jitter:          0.5,
+		}
+		for i := 0; i < 100; i++ {
+			d, ok := backoff.Next(1, 0)
+			require.True(t, ok)
+			require.GreaterOrEqual(t, d, 500*time.Millisecond)
+			require.LessOrEqual(t, d, 1500*time.Millisecond)
+		}
+	})
+
+	t.Run("max elapsed time", func(t *testing.T) {
+		backoff := &ExponentialBackoff{
+			InitialInterval: time.Second,
+			MaxElapsedTime:  10 * time.Second,
+		}
+		_, ok := backoff.Next(1, 8*time.Second)
+		require.True(t, ok)
+		_, ok = backoff.Next(2, 9*time.Second)
+		require.False(t, ok)
+	})
+}
+
+func TestDefaultErrorClassifier(t *testing.T) {
+	apiError := func(code int) error {
+		return iaas.NewAPIError("PUT", nil, code, &iaas.APIErrorResponse{})
+	}
+	cases := []struct {
+		msg       string
+		err       error
+		retryable bool
+	}{
+		{msg: "nil", err: nil, retryable: false},
+		{msg: "plain error", err: errors.New("dummy"), retryable: true},
+		{msg: "context canceled", err: context.Canceled, retryable: false},
+		{msg: "validation error", err: service.ValidationError(errors.New("dummy")), retryable: false},
+		{msg: "bad request", err: apiError(http.StatusBadRequest), retryable: false},
+		{msg: "conflict", err: apiError(http.StatusConflict), retryable: true},
+		{msg: "wrapped conflict", err: service.StepError("provisioning", apiError(http.StatusConflict)), retryable: true},
+		{msg: "service unavailable", err: apiError(http.StatusServiceUnavailable), retryable: true},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.retryable, DefaultErrorClassifier(tc.err), tc.msg)
+	}
+}
+
+func TestSleep(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	started := time.Now()
+	err := Sleep(ctx, time.Minute)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Less(t, time.Since(started), time.Second)
+
+	require.NoError(t, Sleep(context.Background(), time.Millisecond))
+}
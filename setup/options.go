@@ -14,7 +14,11 @@
 
 package setup
 
-import "time"
+import (
+	"time"
+
+	"github.com/sacloud/iaas-service-go/progress"
+)
 
 var (
 	//	DefaultNICUpdateWaitDuration デフォルトのNIC更新待ち
@@ -57,6 +61,22 @@ type Options struct {
 	DeleteRetryInterval time.Duration
 	// sacloud.StateWaiterによるステート待ちの間隔
 	PollingInterval time.Duration
+	// Observer 進捗イベントの通知先
+	//
+	// 未指定の場合はcontextに設定されたObserverを利用する
+	Observer progress.Observer
+	// RollbackOnFailure trueの場合、Setupが失敗した際に作成したリソースを削除する
+	//
+	// ロールバックを行った場合、Setupは*rollback.Errorを返す
+	RollbackOnFailure bool
+	// Backoff プロビジョニング/削除API呼び出しのリトライ時の待機時間を決定するストラテジー
+	//
+	// 未指定の場合はProvisioningRetryInterval/DeleteRetryIntervalの一定間隔で待機する
+	Backoff Backoff
+	// IsRetryable プロビジョニング/削除API呼び出しのエラーがリトライ可能か判定する
+	//
+	// 未指定の場合はDefaultErrorClassifierを利用する
+	IsRetryable ErrorClassifier
 }
 
 func (o *Options) Init() {
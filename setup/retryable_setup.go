@@ -18,14 +18,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/accessor"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/progress"
+	"github.com/sacloud/iaas-service-go/rollback"
 )
 
 // MaxRetryCountExceededError リトライ最大数超過エラー
+//
+// errors.Is(err, iaas.ErrMaxRetryCountExceeded)で判定できる
 type MaxRetryCountExceededError error
 
 // CreateFunc リソース作成関数
@@ -45,6 +49,9 @@ type DeleteFunc func(ctx context.Context, zone string, id types.ID) error
 // ReadFunc リソース起動待ちなどで利用するリソースのRead用Func
 type ReadFunc func(ctx context.Context, zone string, id types.ID) (interface{}, error)
 
+// ShutdownFunc ロールバック時に起動済みのリソースを停止するための関数
+type ShutdownFunc func(ctx context.Context, zone string, id types.ID) error
+
 // RetryableSetup リソース作成時にコピー待ちや起動待ちが必要なリソースのビルダー。
 //
 // リソースのビルドの際、必要に応じてリトライ(リソースの削除&再作成)を行う。
@@ -61,6 +68,8 @@ type RetryableSetup struct {
 	Delete DeleteFunc
 	// Read リソース起動待ち関数
 	Read ReadFunc
+	// Shutdown ロールバック時のリソース停止用関数、未指定の場合は停止せずに削除する
+	Shutdown ShutdownFunc
 
 	// Options .
 	Options *Options
@@ -73,9 +82,26 @@ func (r *RetryableSetup) Setup(ctx context.Context, zone string) (interface{}, e
 	}
 
 	r.init()
+	if r.Options.Observer != nil {
+		ctx = progress.WithObserver(ctx, r.Options.Observer)
+	}
+
+	if !r.Options.RollbackOnFailure {
+		return r.setup(ctx, zone)
+	}
+	var created interface{}
+	err := rollback.Run(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = r.setup(ctx, zone)
+		return err
+	})
+	return created, err
+}
 
+func (r *RetryableSetup) setup(ctx context.Context, zone string) (interface{}, error) {
 	var created interface{}
-	for r.Options.RetryCount+1 > 0 {
+	maxAttempts := r.Options.RetryCount + 1
+	for attempt := 1; r.Options.RetryCount+1 > 0; attempt++ {
 		r.Options.RetryCount--
 
 		// リソース作成
@@ -84,28 +110,43 @@ func (r *RetryableSetup) Setup(ctx context.Context, zone string) (interface{}, e
 			return nil, err
 		}
 		id := target.GetID()
+		resource := progress.ResourceName(target)
+		progress.Step(ctx, resource, id, "created")
+		rollback.Track(ctx, resource, id, func(ctx context.Context) error {
+			return r.cleanup(ctx, zone, id)
+		})
 
 		// コピー待ち
 		if r.IsWaitForCopy {
 			// コピー待ち、Failedになった場合はリソース削除
-			state, err := r.waitForCopyWithCleanup(ctx, zone, id)
+			state, err := r.waitForCopyWithCleanup(ctx, zone, resource, id)
 			if err != nil {
 				return state, err
 			}
 			if state != nil {
 				created = state
+			} else if attempt < maxAttempts {
+				progress.Notify(ctx, &progress.Event{
+					Type:        progress.EventRetry,
+					Resource:    resource,
+					ID:          id,
+					Step:        "copy",
+					Message:     "after Failed availability",
+					Attempt:     attempt,
+					MaxAttempts: maxAttempts - 1,
+				})
 			}
 		} else {
 			created = target
 		}
 
 		// 起動前の設定など
-		if err := r.provisionBeforeUp(ctx, zone, id, created); err != nil {
+		if err := r.provisionBeforeUp(ctx, zone, resource, id, created); err != nil {
 			return created, err
 		}
 
 		// 起動待ち
-		if err := r.waitForUp(ctx, zone, id, created); err != nil {
+		if err := r.waitForUp(ctx, zone, resource, id, created); err != nil {
 			return created, err
 		}
 
@@ -115,7 +156,7 @@ func (r *RetryableSetup) Setup(ctx context.Context, zone string) (interface{}, e
 	}
 
 	if created == nil {
-		return nil, MaxRetryCountExceededError(fmt.Errorf("max retry count exceeded"))
+		return nil, MaxRetryCountExceededError(service.NewError(service.ErrMaxRetryCountExceeded, nil))
 	}
 	return created, nil
 }
@@ -134,7 +175,7 @@ func (r *RetryableSetup) createResource(ctx context.Context, zone string) (acces
 	return r.Create(ctx, zone)
 }
 
-func (r *RetryableSetup) waitForCopyWithCleanup(ctx context.Context, zone string, id types.ID) (interface{}, error) {
+func (r *RetryableSetup) waitForCopyWithCleanup(ctx context.Context, zone, resource string, id types.ID) (interface{}, error) {
 	waiter := &iaas.StatePollingWaiter{
 		ReadFunc: func() (interface{}, error) {
 			return r.Read(ctx, zone, id)
@@ -154,23 +195,7 @@ func (r *RetryableSetup) waitForCopyWithCleanup(ctx context.Context, zone string
 	}
 
 	// wait
-	compChan, progressChan, errChan := waiter.WaitForStateAsync(ctx)
-	var state interface{}
-	var err error
-
-loop:
-	for {
-		select {
-		case v := <-compChan:
-			state = v
-			break loop
-		case v := <-progressChan:
-			state = v
-		case e := <-errChan:
-			err = e
-			break loop
-		}
-	}
+	state, err := progress.WaitForState(ctx, waiter, resource, id, "copy")
 
 	if state != nil {
 		// Availabilityを持ち、Failedになっていた場合はリソースを削除してリトライ
@@ -178,18 +203,15 @@ loop:
 			if f.GetAvailability().IsFailed() {
 				// FailedになったばかりだとDelete APIが失敗する(コピー進行中など)場合があるため、
 				// 任意の回数リトライ&待機を行う
-				for i := 0; i < r.Options.DeleteRetryCount; i++ {
-					time.Sleep(r.Options.DeleteRetryInterval)
-					if err = r.Delete(ctx, zone, id); err == nil {
-						break
-					}
+				if err := r.deleteFailedResource(ctx, zone, resource, id); err != nil {
+					return nil, err
 				}
-
 				return nil, nil
 			}
 		}
 
-		return state, nil
+		// タイムアウトなどで待機が中断された場合は最後に取得した状態とエラーを返す
+		return state, err
 	}
 	if err != nil {
 		return nil, err
@@ -198,21 +220,82 @@ loop:
 	return nil, nil
 }
 
-func (r *RetryableSetup) provisionBeforeUp(ctx context.Context, zone string, id types.ID, created interface{}) error {
-	if r.ProvisionBeforeUp != nil && created != nil {
-		var err error
-		for i := 0; i < r.Options.ProvisioningRetryCount; i++ {
-			if err = r.ProvisionBeforeUp(ctx, zone, id, created); err == nil {
-				break
+// cleanup ロールバック用、起動済みの場合は停止してから削除する
+func (r *RetryableSetup) cleanup(ctx context.Context, zone string, id types.ID) error {
+	if r.Delete == nil {
+		return errors.New("delete func is required for rollback")
+	}
+	if r.Shutdown != nil && r.Read != nil {
+		state, err := r.Read(ctx, zone, id)
+		if err != nil {
+			return err
+		}
+		if s, ok := state.(accessor.InstanceStatus); ok && s.GetInstanceStatus().IsUp() {
+			if err := r.Shutdown(ctx, zone, id); err != nil {
+				return err
 			}
-			time.Sleep(r.Options.ProvisioningRetryInterval)
 		}
-		return err
+	}
+	return r.Delete(ctx, zone, id)
+}
+
+// deleteFailedResource AvailabilityがFailedとなったリソースを削除する
+//
+// 削除に失敗し続けた場合もリソースの再作成を行うためエラーとしない。待機中にctxがキャンセルされた場合のみエラーを返す
+func (r *RetryableSetup) deleteFailedResource(ctx context.Context, zone, resource string, id types.ID) error {
+	retrier := r.Options.newRetrier(r.Options.DeleteRetryCount, r.Options.DeleteRetryInterval)
+	for attempt := 1; attempt <= r.Options.DeleteRetryCount; attempt++ {
+		d, ok := retrier.delay(attempt)
+		if !ok {
+			return nil
+		}
+		if err := Sleep(ctx, d); err != nil {
+			return err
+		}
+		err := r.Delete(ctx, zone, id)
+		if err == nil {
+			rollback.Untrack(ctx, resource, id)
+			return nil
+		}
+		if !retrier.isRetryable(err) {
+			return nil
+		}
 	}
 	return nil
 }
 
-func (r *RetryableSetup) waitForUp(ctx context.Context, zone string, id types.ID, created interface{}) error {
+func (r *RetryableSetup) provisionBeforeUp(ctx context.Context, zone, resource string, id types.ID, created interface{}) error {
+	if r.ProvisionBeforeUp == nil || created == nil {
+		return nil
+	}
+
+	retrier := r.Options.newRetrier(r.Options.ProvisioningRetryCount, r.Options.ProvisioningRetryInterval)
+	for attempt := 1; ; attempt++ {
+		err := r.ProvisionBeforeUp(ctx, zone, id, created)
+		if err == nil {
+			progress.Step(ctx, resource, id, "provisioned")
+			return nil
+		}
+		d, ok := retrier.next(attempt, err)
+		if !ok {
+			return err
+		}
+		progress.Notify(ctx, &progress.Event{
+			Type:        progress.EventRetry,
+			Resource:    resource,
+			ID:          id,
+			Step:        "provisioning",
+			Message:     err.Error(),
+			Attempt:     attempt,
+			MaxAttempts: r.Options.ProvisioningRetryCount - 1,
+		})
+		if err := Sleep(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *RetryableSetup) waitForUp(ctx context.Context, zone, resource string, id types.ID, created interface{}) error {
 	if r.IsWaitForUp && created != nil {
 		waiter := &iaas.StatePollingWaiter{
 			ReadFunc: func() (interface{}, error) {
@@ -238,7 +321,7 @@ func (r *RetryableSetup) waitForUp(ctx context.Context, zone string, id types.ID
 			},
 			Interval: r.Options.PollingInterval,
 		}
-		_, err := waiter.WaitForState(ctx)
+		_, err := progress.WaitForState(ctx, waiter, resource, id, "boot")
 		return err
 	}
 	return nil
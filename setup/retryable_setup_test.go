@@ -16,12 +16,15 @@ package setup
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/sacloud/iaas-api-go/accessor"
 	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/progress"
+	"github.com/sacloud/iaas-service-go/rollback"
 	"github.com/stretchr/testify/require"
 )
 
@@ -95,6 +98,39 @@ func TestRetryableSetup_Setup(t *testing.T) {
 			require.Nil(t, res)
 			require.Error(t, err)
 		})
+
+		t.Run("rollback on failure", func(t *testing.T) {
+			var deleted []types.ID
+			retryable := &RetryableSetup{
+				Create: func(context.Context, string) (id accessor.ID, e error) {
+					return &dummyIDAccessor{id: 1}, nil
+				},
+				ProvisionBeforeUp: func(context.Context, string, types.ID, interface{}) error {
+					return fmt.Errorf("provisioning error")
+				},
+				Delete: func(_ context.Context, _ string, id types.ID) error {
+					deleted = append(deleted, id)
+					return nil
+				},
+				Read: func(ctx context.Context, zone string, id types.ID) (interface{}, error) {
+					return &dummyIDAccessor{id: 1}, nil
+				},
+				Options: &Options{
+					ProvisioningRetryCount:    1,
+					ProvisioningRetryInterval: time.Millisecond,
+					DeleteRetryInterval:       time.Millisecond,
+					PollingInterval:           time.Millisecond,
+					RollbackOnFailure:         true,
+				},
+			}
+			_, err := retryable.Setup(ctx, zone)
+
+			require.Error(t, err)
+			var rbErr *rollback.Error
+			require.True(t, errors.As(err, &rbErr))
+			require.NoError(t, rbErr.Result.Err())
+			require.Equal(t, []types.ID{1}, deleted)
+		})
 	})
 	//
 	t.Run("Retry", func(t *testing.T) {
@@ -125,6 +161,127 @@ func TestRetryableSetup_Setup(t *testing.T) {
 			require.NoError(t, err)
 		})
 
+		t.Run("notify retry events", func(t *testing.T) {
+			var events []*progress.Event
+			retryable := &RetryableSetup{
+				Create: func(context.Context, string) (id accessor.ID, e error) {
+					return &dummyIDAccessor{id: 1}, nil
+				},
+				IsWaitForCopy: true,
+				Delete: func(context.Context, string, types.ID) error {
+					return nil
+				},
+				Read: withErrorReadFunc(func(ctx context.Context, zone string, id types.ID) (interface{}, error) {
+					return &dummyIDAccessor{id: 1}, nil
+				}, 3),
+				Options: &Options{
+					RetryCount:                3,
+					ProvisioningRetryInterval: time.Millisecond,
+					DeleteRetryInterval:       time.Millisecond,
+					PollingInterval:           time.Millisecond,
+					Observer: progress.ObserverFunc(func(e *progress.Event) {
+						events = append(events, e)
+					}),
+				},
+			}
+
+			_, err := retryable.Setup(ctx, zone)
+			require.NoError(t, err)
+
+			var retries []*progress.Event
+			for _, e := range events {
+				if e.Type == progress.EventRetry {
+					retries = append(retries, e)
+				}
+			}
+			require.Len(t, retries, 2)
+			require.Equal(t, 1, retries[0].Attempt)
+			require.Equal(t, 3, retries[0].MaxAttempts)
+			require.Equal(t, "dummyidaccessor[1] retry 2/3 after Failed availability", retries[1].String())
+		})
+
+		t.Run("provisioning stops on fatal error", func(t *testing.T) {
+			calls := 0
+			retryable := &RetryableSetup{
+				Create: func(context.Context, string) (id accessor.ID, e error) {
+					return &dummyIDAccessor{id: 1}, nil
+				},
+				ProvisionBeforeUp: func(context.Context, string, types.ID, interface{}) error {
+					calls++
+					if calls < 3 {
+						return errors.New("retryable")
+					}
+					return errors.New("fatal")
+				},
+				Options: &Options{
+					ProvisioningRetryCount: 10,
+					Backoff:                &ExponentialBackoff{InitialInterval: time.Millisecond},
+					IsRetryable: func(err error) bool {
+						return err.Error() == "retryable"
+					},
+				},
+			}
+
+			_, err := retryable.Setup(ctx, zone)
+			require.EqualError(t, err, "fatal")
+			require.Equal(t, 3, calls)
+		})
+
+		t.Run("provisioning honors context", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+
+			retryable := &RetryableSetup{
+				Create: func(context.Context, string) (id accessor.ID, e error) {
+					return &dummyIDAccessor{id: 1}, nil
+				},
+				ProvisionBeforeUp: func(context.Context, string, types.ID, interface{}) error {
+					return errors.New("dummy")
+				},
+				Options: &Options{
+					ProvisioningRetryInterval: time.Minute,
+				},
+			}
+
+			_, err := retryable.Setup(ctx, zone)
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+		})
+
+		t.Run("copy wait timeout is returned", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+			defer cancel()
+
+			provisioned := false
+			retryable := &RetryableSetup{
+				Create: func(context.Context, string) (id accessor.ID, e error) {
+					return &dummyIDAccessor{id: 1}, nil
+				},
+				IsWaitForCopy: true,
+				Delete: func(context.Context, string, types.ID) error {
+					return nil
+				},
+				// コピーが完了せずMigratingのまま
+				Read: func(ctx context.Context, zone string, id types.ID) (interface{}, error) {
+					return &dummyAvailabilityAccessor{available: types.Availabilities.Migrating}, nil
+				},
+				ProvisionBeforeUp: func(context.Context, string, types.ID, interface{}) error {
+					provisioned = true
+					return nil
+				},
+				Options: &Options{
+					ProvisioningRetryInterval: time.Millisecond,
+					DeleteRetryInterval:       time.Millisecond,
+					PollingInterval:           time.Millisecond,
+				},
+			}
+
+			// 待機の中断を成功として扱わず、最後に取得した状態とエラーを返す
+			res, err := retryable.Setup(ctx, zone)
+			require.Error(t, err)
+			require.NotNil(t, res)
+			require.False(t, provisioned)
+		})
+
 		t.Run("max retry count exceeded", func(t *testing.T) {
 			retryable := &RetryableSetup{
 				Create: func(context.Context, string) (id accessor.ID, e error) {
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Activate(req *ActivateRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) Activate(req *ActivateRequest) error {
 }
 
 func (s *Service) ActivateWithContext(ctx context.Context, req *ActivateRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "sim", "activate", req, s.activate)
+}
+
+func (s *Service) activate(ctx context.Context, req *ActivateRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewSIMOp(s.caller)
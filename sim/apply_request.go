@@ -15,8 +15,13 @@
 package sim
 
 import (
+	"context"
+
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/secret"
+	"github.com/sacloud/iaas-service-go/sim/builder"
 	"github.com/sacloud/packages-go/validate"
 )
 
@@ -33,6 +38,47 @@ type ApplyRequest struct {
 	Carriers    []*iaas.SIMNetworkOperatorConfig
 }
 
+// String 秘匿情報を伏せた文字列表現を返す
+func (req *ApplyRequest) String() string {
+	return secret.Redacted(req)
+}
+
 func (req *ApplyRequest) Validate() error {
-	return validate.New().Struct(req)
+	if err := validate.New().Struct(req); err != nil {
+		return err
+	}
+	return service.ValidateNoSecretPlaceholder(req)
+}
+
+func (req *ApplyRequest) Builder(caller iaas.APICaller) *builder.Builder {
+	return &builder.Builder{
+		Name:        req.Name,
+		Description: req.Description,
+		Tags:        req.Tags,
+		IconID:      req.IconID,
+		ICCID:       req.ICCID,
+		PassCode:    req.PassCode,
+		Activate:    req.Activate,
+		IMEI:        req.IMEI,
+		Carrier:     req.Carriers,
+		Client:      builder.NewAPIClient(caller),
+	}
+}
+
+// Preflight 参照先のリソースを確認し、検出した全ての問題を返す
+//
+// リソースの作成や更新は行わない
+func (req *ApplyRequest) Preflight(ctx context.Context, caller iaas.APICaller) error {
+	p := service.NewPreflight()
+
+	p.Ref("ID", "sim", "", req.ID, func() error {
+		_, err := iaas.NewSIMOp(caller).Read(ctx, req.ID)
+		return err
+	})
+	p.Ref("IconID", "icon", "", req.IconID, func() error {
+		_, err := iaas.NewIconOp(caller).Read(ctx, req.IconID)
+		return err
+	})
+
+	return p.Err()
 }
@@ -18,7 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
-	builder2 "github.com/sacloud/iaas-service-go/sim/builder"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Apply(req *ApplyRequest) (*iaas.SIM, error) {
@@ -26,22 +26,15 @@ func (s *Service) Apply(req *ApplyRequest) (*iaas.SIM, error) {
 }
 
 func (s *Service) ApplyWithContext(ctx context.Context, req *ApplyRequest) (*iaas.SIM, error) {
+	return service.Invoke(ctx, s.interceptors, "sim", "apply", req, s.apply)
+}
+
+func (s *Service) apply(ctx context.Context, req *ApplyRequest) (*iaas.SIM, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
-	builder := &builder2.Builder{
-		Name:        req.Name,
-		Description: req.Description,
-		Tags:        req.Tags,
-		IconID:      req.IconID,
-		ICCID:       req.ICCID,
-		PassCode:    req.PassCode,
-		Activate:    req.Activate,
-		IMEI:        req.IMEI,
-		Carrier:     req.Carriers,
-		Client:      builder2.NewAPIClient(s.caller),
-	}
+	builder := req.Builder(s.caller)
 	if err := builder.Validate(ctx); err != nil {
 		return nil, err
 	}
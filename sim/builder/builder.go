@@ -103,6 +103,14 @@ func (b *Builder) Update(ctx context.Context, id types.ID) (*iaas.SIM, error) {
 		return nil, err
 	}
 
+	plan, err := b.plan(ctx, sim)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.HasChanges() {
+		return sim, nil
+	}
+
 	_, err = b.Client.SIM.Update(ctx, id, &iaas.SIMUpdateRequest{
 		Name:        b.Name,
 		Description: b.Description,
@@ -0,0 +1,87 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/helper/query"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+)
+
+// Plan Build/Update時の変更内容を算出する
+//
+// idが空の場合は新規作成を表すPlanを返す。APIによるリソースの変更は行わない。
+func (b *Builder) Plan(ctx context.Context, id types.ID) (*service.Plan, error) {
+	if id.IsEmpty() {
+		return service.NewCreatePlan(map[string]interface{}{
+			"Name":        b.Name,
+			"Description": b.Description,
+			"Tags":        b.Tags,
+			"IconID":      b.IconID,
+			"ICCID":       b.ICCID,
+			"Activate":    b.Activate,
+			"IMEI":        b.IMEI,
+			"Carrier":     b.Carrier,
+		}), nil
+	}
+
+	sim, err := query.FindSIMByID(ctx, b.Client.SIM, id)
+	if err != nil {
+		return nil, err
+	}
+	return b.plan(ctx, sim)
+}
+
+func (b *Builder) plan(ctx context.Context, sim *iaas.SIM) (*service.Plan, error) {
+	plan := service.NewPlan()
+
+	plan.Diff("Name", sim.Name, b.Name)
+	plan.Diff("Description", sim.Description, b.Description)
+	plan.Diff("Tags", sim.Tags, b.Tags)
+	plan.Diff("IconID", sim.IconID, b.IconID)
+	plan.DiffReplace("ICCID", sim.ICCID, b.ICCID)
+
+	info := sim.Info
+	if info == nil {
+		info = &iaas.SIMInfo{}
+	}
+	plan.Diff("Activate", info.Activated, b.Activate)
+	currentIMEI := ""
+	if info.IMEILock {
+		currentIMEI = info.IMEI
+	}
+	plan.Diff("IMEI", currentIMEI, b.IMEI)
+
+	carrier, err := b.Client.SIM.GetNetworkOperator(ctx, sim.ID)
+	if err != nil {
+		return nil, err
+	}
+	plan.Diff("Carrier", enabledCarriers(carrier), enabledCarriers(b.Carrier))
+
+	return plan, nil
+}
+
+func enabledCarriers(configs []*iaas.SIMNetworkOperatorConfig) []string {
+	var results []string
+	for _, c := range configs {
+		if c.Allow {
+			results = append(results, c.Name)
+		}
+	}
+	return results
+}
@@ -17,6 +17,7 @@ package sim
 import (
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/secret"
 	"github.com/sacloud/packages-go/validate"
 )
 
@@ -33,6 +34,11 @@ type CreateRequest struct {
 	Carriers []*iaas.SIMNetworkOperatorConfig
 }
 
+// String 秘匿情報を伏せた文字列表現を返す
+func (req *CreateRequest) String() string {
+	return secret.Redacted(req)
+}
+
 func (req *CreateRequest) Validate() error {
 	return validate.New().Struct(req)
 }
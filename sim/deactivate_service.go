@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Deactivate(req *DeactivateRequest) error {
@@ -25,8 +26,12 @@ func (s *Service) Deactivate(req *DeactivateRequest) error {
 }
 
 func (s *Service) DeactivateWithContext(ctx context.Context, req *DeactivateRequest) error {
+	return service.InvokeNoResult(ctx, s.interceptors, "sim", "deactivate", req, s.deactivate)
+}
+
+func (s *Service) deactivate(ctx context.Context, req *DeactivateRequest) error {
 	if err := req.Validate(); err != nil {
-		return err
+		return service.ValidationError(err)
 	}
 
 	client := iaas.NewSIMOp(s.caller)
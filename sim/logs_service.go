@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Logs(req *LogsRequest) ([]*iaas.SIMLog, error) {
@@ -25,8 +26,12 @@ func (s *Service) Logs(req *LogsRequest) ([]*iaas.SIMLog, error) {
 }
 
 func (s *Service) LogsWithContext(ctx context.Context, req *LogsRequest) ([]*iaas.SIMLog, error) {
+	return service.Invoke(ctx, s.interceptors, "sim", "logs", req, s.logs)
+}
+
+func (s *Service) logs(ctx context.Context, req *LogsRequest) ([]*iaas.SIMLog, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewSIMOp(s.caller)
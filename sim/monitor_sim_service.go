@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 )
 
@@ -26,8 +27,12 @@ func (s *Service) MonitorSIM(req *MonitorSIMRequest) ([]*iaas.MonitorLinkValue,
 }
 
 func (s *Service) MonitorSIMWithContext(ctx context.Context, req *MonitorSIMRequest) ([]*iaas.MonitorLinkValue, error) {
+	return service.Invoke(ctx, s.interceptors, "sim", "monitor_sim", req, s.monitorSIM)
+}
+
+func (s *Service) monitorSIM(ctx context.Context, req *MonitorSIMRequest) ([]*iaas.MonitorLinkValue, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewSIMOp(s.caller)
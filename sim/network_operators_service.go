@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) NetworkOperators(req *NetworkOperatorsRequest) ([]*iaas.SIMNetworkOperatorConfig, error) {
@@ -25,8 +26,12 @@ func (s *Service) NetworkOperators(req *NetworkOperatorsRequest) ([]*iaas.SIMNet
 }
 
 func (s *Service) NetworkOperatorsWithContext(ctx context.Context, req *NetworkOperatorsRequest) ([]*iaas.SIMNetworkOperatorConfig, error) {
+	return service.Invoke(ctx, s.interceptors, "sim", "network_operators", req, s.networkOperators)
+}
+
+func (s *Service) networkOperators(ctx context.Context, req *NetworkOperatorsRequest) ([]*iaas.SIMNetworkOperatorConfig, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewSIMOp(s.caller)
@@ -19,6 +19,7 @@ import (
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/helper/query"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Read(req *ReadRequest) (*iaas.SIM, error) {
@@ -26,8 +27,12 @@ func (s *Service) Read(req *ReadRequest) (*iaas.SIM, error) {
 }
 
 func (s *Service) ReadWithContext(ctx context.Context, req *ReadRequest) (*iaas.SIM, error) {
+	return service.Invoke(ctx, s.interceptors, "sim", "read", req, s.read)
+}
+
+func (s *Service) read(ctx context.Context, req *ReadRequest) (*iaas.SIM, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 	client := iaas.NewSIMOp(s.caller)
 	return query.FindSIMByID(ctx, client, req.ID)
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 	"github.com/sacloud/iaas-service-go/serviceutil"
 )
 
@@ -26,8 +27,12 @@ func (s *Service) MonitorResponseTime(req *MonitorResponseTimeRequest) ([]*iaas.
 }
 
 func (s *Service) MonitorResponseTimeWithContext(ctx context.Context, req *MonitorResponseTimeRequest) ([]*iaas.MonitorResponseTimeSecValue, error) {
+	return service.Invoke(ctx, s.interceptors, "simplemonitor", "monitor_response_time", req, s.monitorResponseTime)
+}
+
+func (s *Service) monitorResponseTime(ctx context.Context, req *MonitorResponseTimeRequest) ([]*iaas.MonitorResponseTimeSecValue, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewSimpleMonitorOp(s.caller)
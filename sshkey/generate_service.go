@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) Generate(req *GenerateRequest) (*iaas.SSHKeyGenerated, error) {
@@ -25,8 +26,12 @@ func (s *Service) Generate(req *GenerateRequest) (*iaas.SSHKeyGenerated, error)
 }
 
 func (s *Service) GenerateWithContext(ctx context.Context, req *GenerateRequest) (*iaas.SSHKeyGenerated, error) {
+	return service.Invoke(ctx, s.interceptors, "sshkey", "generate", req, s.generate)
+}
+
+func (s *Service) generate(ctx context.Context, req *GenerateRequest) (*iaas.SSHKeyGenerated, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	client := iaas.NewSSHKeyOp(s.caller)
@@ -0,0 +1,92 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// dependencies リソースが依存するリソースのキーを返す
+func (r *Resource) dependencies() []string {
+	seen := make(map[string]bool)
+	var results []string
+	for _, key := range append(append([]string{}, r.DependsOn...), findReferences(r.Spec)...) {
+		if key == r.Key() || seen[key] {
+			continue
+		}
+		seen[key] = true
+		results = append(results, key)
+	}
+	return results
+}
+
+// levels 依存関係を元にリソースを段階ごとに分けて返す
+//
+// 同じ段階のリソース同士は依存関係を持たないため並列にApplyできる
+func (m *Manifest) levels() ([][]*Resource, error) {
+	resources := make(map[string]*Resource)
+	for _, r := range m.Resources {
+		resources[r.Key()] = r
+	}
+
+	remaining := make(map[string][]string)
+	for _, r := range m.Resources {
+		deps := r.dependencies()
+		for _, dep := range deps {
+			if _, ok := resources[dep]; !ok {
+				return nil, fmt.Errorf("%s: referenced resource not found: %s", r.Key(), dep)
+			}
+		}
+		remaining[r.Key()] = deps
+	}
+
+	var levels [][]*Resource
+	done := make(map[string]bool)
+	for len(remaining) > 0 {
+		var level []*Resource
+		for _, r := range m.Resources {
+			deps, ok := remaining[r.Key()]
+			if !ok {
+				continue
+			}
+			ready := true
+			for _, dep := range deps {
+				if !done[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				level = append(level, r)
+			}
+		}
+		if len(level) == 0 {
+			var keys []string
+			for k := range remaining {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			return nil, fmt.Errorf("dependency cycle detected: %s", strings.Join(keys, ", "))
+		}
+		for _, r := range level {
+			done[r.Key()] = true
+			delete(remaining, r.Key())
+		}
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
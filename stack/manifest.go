@@ -0,0 +1,107 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest 複数リソースをまとめて定義するマニフェスト
+//
+// YAMLまたはJSONで記述する。specの値には${type.name.attr}形式で他のリソースへの参照を記載できる。
+//
+//	zone: is1a
+//	resources:
+//	  - type: switch
+//	    name: web
+//	    spec:
+//	      name: web-switch
+//	  - type: server
+//	    name: web
+//	    spec:
+//	      name: web-server
+//	      networkInterfaces:
+//	        - upstream: ${switch.web.id}
+type Manifest struct {
+	// Zone ゾーン指定が必要なリソースでspecにzoneが含まれない場合に利用されるゾーン
+	Zone      string      `yaml:"zone" json:"zone"`
+	Resources []*Resource `yaml:"resources" json:"resources"`
+}
+
+// Resource マニフェスト中の1リソースの定義
+type Resource struct {
+	// Type リソース種別 例: switch, server
+	Type string `yaml:"type" json:"type"`
+	// Name マニフェスト内でリソースを識別するための論理名
+	Name string `yaml:"name" json:"name"`
+	// DependsOn 参照以外で明示的に依存するリソース 例: switch.web
+	DependsOn []string `yaml:"dependsOn" json:"dependsOn"`
+	// Spec 各パッケージのApplyRequest(またはCreate/UpdateRequest)に対応する値
+	Spec map[string]interface{} `yaml:"spec" json:"spec"`
+}
+
+// Key マニフェスト内でリソースを一意に識別するキー 例: switch.web
+func (r *Resource) Key() string {
+	return r.Type + "." + r.Name
+}
+
+// LoadManifest ファイルからマニフェストを読み込む
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseManifest(data)
+}
+
+// ParseManifest YAMLまたはJSONからマニフェストを読み込む
+func ParseManifest(data []byte) (*Manifest, error) {
+	manifest := &Manifest{}
+	if err := yaml.Unmarshal(data, manifest); err != nil {
+		return nil, err
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Validate マニフェストの検証
+func (m *Manifest) Validate() error {
+	keys := make(map[string]bool)
+	for i, r := range m.Resources {
+		if r == nil {
+			return fmt.Errorf("resources[%d] is nil", i)
+		}
+		if r.Type == "" || r.Name == "" {
+			return fmt.Errorf("resources[%d]: type and name are required", i)
+		}
+		if strings.Contains(r.Name, ".") {
+			return fmt.Errorf("resources[%d]: name must not contain '.': %s", i, r.Name)
+		}
+		if _, ok := resourceTypes[r.Type]; !ok {
+			return fmt.Errorf("resources[%d]: unsupported type: %s", i, r.Type)
+		}
+		if keys[r.Key()] {
+			return fmt.Errorf("resources[%d]: duplicated resource: %s", i, r.Key())
+		}
+		keys[r.Key()] = true
+	}
+	return nil
+}
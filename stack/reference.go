@@ -0,0 +1,160 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var referencePattern = regexp.MustCompile(`\$\{([a-zA-Z0-9_-]+)\.([a-zA-Z0-9_-]+)\.([a-zA-Z0-9_.-]+)\}`)
+
+// reference ${type.name.attr}形式の参照
+type reference struct {
+	raw  string
+	key  string
+	attr string
+}
+
+// findReferences specに含まれる参照先リソースのキーを返す
+func findReferences(v interface{}) []string {
+	var keys []string
+	walkStrings(v, func(s string) {
+		for _, ref := range parseReferences(s) {
+			keys = append(keys, ref.key)
+		}
+	})
+	return keys
+}
+
+func parseReferences(s string) []*reference {
+	var refs []*reference
+	for _, m := range referencePattern.FindAllStringSubmatch(s, -1) {
+		refs = append(refs, &reference{raw: m[0], key: m[1] + "." + m[2], attr: m[3]})
+	}
+	return refs
+}
+
+func walkStrings(v interface{}, fn func(s string)) {
+	switch v := v.(type) {
+	case string:
+		fn(v)
+	case map[string]interface{}:
+		for _, value := range v {
+			walkStrings(value, fn)
+		}
+	case []interface{}:
+		for _, value := range v {
+			walkStrings(value, fn)
+		}
+	}
+}
+
+// resolveReferences specに含まれる参照を解決した値を返す
+//
+// 文字列全体が1つの参照の場合は参照先の値をそのまま、それ以外の場合は文字列として埋め込む
+func resolveReferences(v interface{}, lookup func(ref *reference) (interface{}, error)) (interface{}, error) {
+	switch v := v.(type) {
+	case string:
+		refs := parseReferences(v)
+		if len(refs) == 0 {
+			return v, nil
+		}
+		if len(refs) == 1 && refs[0].raw == v {
+			return lookup(refs[0])
+		}
+		resolved := v
+		for _, ref := range refs {
+			value, err := lookup(ref)
+			if err != nil {
+				return nil, err
+			}
+			resolved = strings.ReplaceAll(resolved, ref.raw, fmt.Sprint(value))
+		}
+		return resolved, nil
+	case map[string]interface{}:
+		results := make(map[string]interface{}, len(v))
+		for k, value := range v {
+			resolved, err := resolveReferences(value, lookup)
+			if err != nil {
+				return nil, err
+			}
+			results[k] = resolved
+		}
+		return results, nil
+	case []interface{}:
+		results := make([]interface{}, len(v))
+		for i, value := range v {
+			resolved, err := resolveReferences(value, lookup)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = resolved
+		}
+		return results, nil
+	}
+	return v, nil
+}
+
+// attribute Apply結果からattrで示される値を取り出す
+//
+// attrはドット区切りでフィールド名(大文字小文字は区別しない)またはスライスのインデックスを指定する。
+// 例: id, Interfaces.0.IPAddress
+func attribute(result interface{}, attr string) (interface{}, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var current interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&current); err != nil {
+		return nil, err
+	}
+
+	for _, p := range strings.Split(attr, ".") {
+		switch c := current.(type) {
+		case map[string]interface{}:
+			found := false
+			for k, value := range c {
+				if strings.EqualFold(k, p) {
+					current = value
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("attribute %q not found", attr)
+			}
+		case []interface{}:
+			index, err := strconv.Atoi(p)
+			if err != nil || index < 0 || index >= len(c) {
+				return nil, fmt.Errorf("attribute %q: invalid index %q", attr, p)
+			}
+			current = c[index]
+		default:
+			return nil, fmt.Errorf("attribute %q not found", attr)
+		}
+	}
+	if n, ok := current.(json.Number); ok && strings.EqualFold(attr, "id") {
+		// IDはtypes.IDと文字列のどちらのフィールドにも指定できるよう文字列として扱う
+		return n.String(), nil
+	}
+	return current, nil
+}
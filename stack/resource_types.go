@@ -0,0 +1,259 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/containerregistry"
+	"github.com/sacloud/iaas-service-go/database"
+	"github.com/sacloud/iaas-service-go/disk"
+	"github.com/sacloud/iaas-service-go/enhanceddb"
+	"github.com/sacloud/iaas-service-go/loadbalancer"
+	"github.com/sacloud/iaas-service-go/nfs"
+	"github.com/sacloud/iaas-service-go/packetfilter"
+	"github.com/sacloud/iaas-service-go/server"
+	"github.com/sacloud/iaas-service-go/swytch"
+	"github.com/sacloud/iaas-service-go/vpcrouter"
+)
+
+// applyFunc specを元にリソースの作成または更新を行い、IDとApply結果を返す
+//
+// idが空の場合は新規作成、それ以外の場合は既存リソースの更新を表す
+type applyFunc func(ctx context.Context, caller iaas.APICaller, id types.ID, spec []byte) (types.ID, interface{}, error)
+
+type resourceType struct {
+	// zoned trueの場合はspecにゾーンが指定されていなければマニフェストのゾーンを補完する
+	zoned bool
+	apply applyFunc
+}
+
+var resourceTypes = map[string]*resourceType{
+	"switch":             {zoned: true, apply: applySwitch},
+	"packetfilter":       {zoned: true, apply: applyPacketFilter},
+	"server":             {zoned: true, apply: applyServer},
+	"disk":               {zoned: true, apply: applyDisk},
+	"database":           {zoned: true, apply: applyDatabase},
+	"vpcrouter":          {zoned: true, apply: applyVPCRouter},
+	"vpcrouter-standard": {zoned: true, apply: applyVPCRouterStandard},
+	"loadbalancer":       {zoned: true, apply: applyLoadBalancer},
+	"nfs":                {zoned: true, apply: applyNFS},
+	"enhanceddb":         {apply: applyEnhancedDB},
+	"containerregistry":  {apply: applyContainerRegistry},
+}
+
+// decodeSpec specをリクエストへ変換する
+//
+// フィールド名の大文字小文字は区別しない。リクエストに存在しないフィールドが含まれる場合はエラーとする。
+func decodeSpec(spec []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(spec))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+func applySwitch(ctx context.Context, caller iaas.APICaller, id types.ID, spec []byte) (types.ID, interface{}, error) {
+	svc := swytch.New(caller)
+	if id.IsEmpty() {
+		req := &swytch.CreateRequest{}
+		if err := decodeSpec(spec, req); err != nil {
+			return 0, nil, err
+		}
+		created, err := svc.CreateWithContext(ctx, req)
+		if err != nil {
+			return 0, nil, err
+		}
+		return created.ID, created, nil
+	}
+
+	req := &swytch.UpdateRequest{}
+	if err := decodeSpec(spec, req); err != nil {
+		return 0, nil, err
+	}
+	req.ID = id
+	updated, err := svc.UpdateWithContext(ctx, req)
+	if err != nil {
+		return 0, nil, err
+	}
+	return updated.ID, updated, nil
+}
+
+func applyPacketFilter(ctx context.Context, caller iaas.APICaller, id types.ID, spec []byte) (types.ID, interface{}, error) {
+	svc := packetfilter.New(caller)
+	if id.IsEmpty() {
+		req := &packetfilter.CreateRequest{}
+		if err := decodeSpec(spec, req); err != nil {
+			return 0, nil, err
+		}
+		created, err := svc.CreateWithContext(ctx, req)
+		if err != nil {
+			return 0, nil, err
+		}
+		return created.ID, created, nil
+	}
+
+	req := &packetfilter.UpdateRequest{}
+	if err := decodeSpec(spec, req); err != nil {
+		return 0, nil, err
+	}
+	req.ID = id
+	updated, err := svc.UpdateWithContext(ctx, req)
+	if err != nil {
+		return 0, nil, err
+	}
+	return updated.ID, updated, nil
+}
+
+func applyServer(ctx context.Context, caller iaas.APICaller, id types.ID, spec []byte) (types.ID, interface{}, error) {
+	req := &server.ApplyRequest{}
+	if err := decodeSpec(spec, req); err != nil {
+		return 0, nil, err
+	}
+	req.ID = id
+
+	for _, d := range req.Disks {
+		if d.Zone == "" {
+			d.Zone = req.Zone
+		}
+	}
+	if !id.IsEmpty() {
+		// ディスクはサーバと共に作成されるためマニフェストにはIDを記載できない、このため接続済みのディスクのIDを順に割り当てる
+		current, err := iaas.NewServerOp(caller).Read(ctx, req.Zone, id)
+		if err != nil {
+			return 0, nil, err
+		}
+		for i, d := range req.Disks {
+			if d.ID.IsEmpty() && i < len(current.Disks) {
+				d.ID = current.Disks[i].ID
+			}
+		}
+	}
+
+	applied, err := server.New(caller).ApplyWithContext(ctx, req)
+	if err != nil {
+		return 0, nil, err
+	}
+	return applied.ID, applied, nil
+}
+
+func applyDisk(ctx context.Context, caller iaas.APICaller, id types.ID, spec []byte) (types.ID, interface{}, error) {
+	req := &disk.ApplyRequest{}
+	if err := decodeSpec(spec, req); err != nil {
+		return 0, nil, err
+	}
+	req.ID = id
+	applied, err := disk.New(caller).ApplyWithContext(ctx, req)
+	if err != nil {
+		return 0, nil, err
+	}
+	return applied.ID, applied, nil
+}
+
+func applyDatabase(ctx context.Context, caller iaas.APICaller, id types.ID, spec []byte) (types.ID, interface{}, error) {
+	req := &database.ApplyRequest{}
+	if err := decodeSpec(spec, req); err != nil {
+		return 0, nil, err
+	}
+	req.ID = id
+	applied, err := database.New(caller).ApplyWithContext(ctx, req)
+	if err != nil {
+		return 0, nil, err
+	}
+	return applied.ID, applied, nil
+}
+
+func applyVPCRouter(ctx context.Context, caller iaas.APICaller, id types.ID, spec []byte) (types.ID, interface{}, error) {
+	// ApplyRequestはNIC設定がインターフェース型のため、プレミアム/ハイスペック向けのCreateRequestを経由する
+	createReq := &vpcrouter.CreateRequest{}
+	if err := decodeSpec(spec, createReq); err != nil {
+		return 0, nil, err
+	}
+	req := createReq.ApplyRequest()
+	req.ID = id
+	applied, err := vpcrouter.New(caller).ApplyWithContext(ctx, req)
+	if err != nil {
+		return 0, nil, err
+	}
+	return applied.ID, applied, nil
+}
+
+func applyVPCRouterStandard(ctx context.Context, caller iaas.APICaller, id types.ID, spec []byte) (types.ID, interface{}, error) {
+	createReq := &vpcrouter.CreateStandardRequest{}
+	if err := decodeSpec(spec, createReq); err != nil {
+		return 0, nil, err
+	}
+	req := createReq.ApplyRequest()
+	req.ID = id
+	applied, err := vpcrouter.New(caller).ApplyWithContext(ctx, req)
+	if err != nil {
+		return 0, nil, err
+	}
+	return applied.ID, applied, nil
+}
+
+func applyLoadBalancer(ctx context.Context, caller iaas.APICaller, id types.ID, spec []byte) (types.ID, interface{}, error) {
+	req := &loadbalancer.ApplyRequest{}
+	if err := decodeSpec(spec, req); err != nil {
+		return 0, nil, err
+	}
+	req.ID = id
+	applied, err := loadbalancer.New(caller).ApplyWithContext(ctx, req)
+	if err != nil {
+		return 0, nil, err
+	}
+	return applied.ID, applied, nil
+}
+
+func applyNFS(ctx context.Context, caller iaas.APICaller, id types.ID, spec []byte) (types.ID, interface{}, error) {
+	req := &nfs.ApplyRequest{}
+	if err := decodeSpec(spec, req); err != nil {
+		return 0, nil, err
+	}
+	req.ID = id
+	applied, err := nfs.New(caller).ApplyWithContext(ctx, req)
+	if err != nil {
+		return 0, nil, err
+	}
+	return applied.ID, applied, nil
+}
+
+func applyEnhancedDB(ctx context.Context, caller iaas.APICaller, id types.ID, spec []byte) (types.ID, interface{}, error) {
+	req := &enhanceddb.ApplyRequest{}
+	if err := decodeSpec(spec, req); err != nil {
+		return 0, nil, err
+	}
+	req.ID = id
+	applied, err := enhanceddb.New(caller).ApplyWithContext(ctx, req)
+	if err != nil {
+		return 0, nil, err
+	}
+	return applied.ID, applied, nil
+}
+
+func applyContainerRegistry(ctx context.Context, caller iaas.APICaller, id types.ID, spec []byte) (types.ID, interface{}, error) {
+	req := &containerregistry.ApplyRequest{}
+	if err := decodeSpec(spec, req); err != nil {
+		return 0, nil, err
+	}
+	req.ID = id
+	applied, err := containerregistry.New(caller).ApplyWithContext(ctx, req)
+	if err != nil {
+		return 0, nil, err
+	}
+	return applied.ID, applied, nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+// Stack マニフェストに記載された複数のリソースを依存関係の順にまとめて作成/更新する
+//
+// Stateに記録済みのリソースは各パッケージのApplyRequestを用いてその場で更新される。
+// マニフェストから削除されたリソースの削除は行わない。
+type Stack struct {
+	Manifest *Manifest
+	State    *State
+
+	// StatePath 空でない場合、各リソースのApplyが完了するたびにStateを書き込む
+	StatePath string
+	// Parallelism 同時にApplyするリソースの最大数、0以下の場合は制限しない
+	Parallelism int
+
+	Caller iaas.APICaller
+}
+
+// New Stackを作成する
+//
+// stateがnilの場合は空のStateを利用する
+func New(caller iaas.APICaller, manifest *Manifest, state *State) *Stack {
+	if state == nil {
+		state = NewState()
+	}
+	return &Stack{
+		Manifest: manifest,
+		State:    state,
+		Caller:   caller,
+	}
+}
+
+// Apply マニフェストに記載されたリソースを依存関係の順に作成/更新する
+//
+// 依存関係のないリソース同士は並列にApplyされる。いずれかのリソースでエラーとなった場合は
+// 同じ段階の他のリソースの完了を待ってから以降の段階を実行せずにエラーを返す。
+func (s *Stack) Apply(ctx context.Context) error {
+	if err := s.Manifest.Validate(); err != nil {
+		return err
+	}
+	levels, err := s.Manifest.levels()
+	if err != nil {
+		return err
+	}
+
+	results := &applyResults{values: make(map[string]interface{})}
+	for _, level := range levels {
+		if err := s.applyLevel(ctx, level, results); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Stack) applyLevel(ctx context.Context, level []*Resource, results *applyResults) error {
+	parallelism := s.Parallelism
+	if parallelism <= 0 {
+		parallelism = len(level)
+	}
+	sem := make(chan struct{}, parallelism)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	for _, r := range level {
+		wg.Add(1)
+		go func(r *Resource) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := s.applyResource(ctx, r, results); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", r.Key(), err))
+				mu.Unlock()
+			}
+		}(r)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (s *Stack) applyResource(ctx context.Context, r *Resource, results *applyResults) error {
+	rt := resourceTypes[r.Type]
+
+	spec := make(map[string]interface{}, len(r.Spec)+1)
+	for k, v := range r.Spec {
+		spec[k] = v
+	}
+	zone := specValue(spec, "zone")
+	if rt.zoned && zone == "" && s.Manifest.Zone != "" {
+		zone = s.Manifest.Zone
+		spec["zone"] = zone
+	}
+
+	resolved, err := resolveReferences(spec, results.lookup)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(resolved)
+	if err != nil {
+		return err
+	}
+
+	current := s.State.Get(r.Key())
+	if current != nil && current.Zone != "" && zone != "" && current.Zone != zone {
+		return fmt.Errorf("zone cannot be changed: %s => %s", current.Zone, zone)
+	}
+	id := current.id()
+
+	appliedID, result, err := rt.apply(ctx, s.Caller, id, data)
+	if err != nil {
+		return err
+	}
+
+	s.State.Set(r.Key(), &ResourceState{Type: r.Type, Name: r.Name, Zone: zone, ID: appliedID})
+	if s.StatePath != "" {
+		if err := s.State.Save(s.StatePath); err != nil {
+			return err
+		}
+	}
+	results.set(r.Key(), result)
+	return nil
+}
+
+func specValue(spec map[string]interface{}, key string) string {
+	for k, v := range spec {
+		if strings.EqualFold(k, key) {
+			if s, ok := v.(string); ok {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+type applyResults struct {
+	values map[string]interface{}
+	mu     sync.Mutex
+}
+
+func (r *applyResults) set(key string, value interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values[key] = value
+}
+
+func (r *applyResults) lookup(ref *reference) (interface{}, error) {
+	r.mu.Lock()
+	value, ok := r.values[ref.key]
+	r.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("referenced resource is not applied yet: %s", ref.key)
+	}
+	return attribute(value, ref.attr)
+}
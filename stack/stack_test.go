@@ -0,0 +1,138 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifest_levels(t *testing.T) {
+	manifest, err := ParseManifest([]byte(`
+resources:
+  - type: server
+    name: web
+    spec:
+      name: web
+      networkInterfaces:
+        - upstream: ${switch.web.id}
+          packetFilterID: ${packetfilter.web.id}
+  - type: switch
+    name: web
+    spec:
+      name: web
+  - type: packetfilter
+    name: web
+    spec:
+      name: web
+`))
+	require.NoError(t, err)
+
+	levels, err := manifest.levels()
+	require.NoError(t, err)
+	require.Len(t, levels, 2)
+	require.Len(t, levels[0], 2)
+	require.Equal(t, "server.web", levels[1][0].Key())
+}
+
+func TestManifest_levelsWithCycle(t *testing.T) {
+	manifest, err := ParseManifest([]byte(`
+resources:
+  - type: switch
+    name: a
+    dependsOn: [switch.b]
+    spec:
+      name: a
+  - type: switch
+    name: b
+    spec:
+      name: ${switch.a.name}
+`))
+	require.NoError(t, err)
+
+	_, err = manifest.levels()
+	require.Error(t, err)
+}
+
+func TestStack_Apply(t *testing.T) {
+	ctx := context.Background()
+	caller := testutil.SingletonAPICaller()
+	name := testutil.ResourceName("stack")
+	statePath := filepath.Join(t.TempDir(), "state.json")
+
+	manifestText := fmt.Sprintf(`
+zone: %s
+resources:
+  - type: switch
+    name: web
+    spec:
+      name: %s
+  - type: server
+    name: web
+    spec:
+      name: %s
+      description: ${switch.web.name}
+      cpu: 1
+      memoryGB: 1
+      networkInterfaces:
+        - upstream: ${switch.web.id}
+`, testutil.TestZone(), name, name)
+
+	manifest, err := ParseManifest([]byte(manifestText))
+	require.NoError(t, err)
+
+	stack := New(caller, manifest, nil)
+	stack.StatePath = statePath
+	require.NoError(t, stack.Apply(ctx))
+
+	state, err := LoadState(statePath)
+	require.NoError(t, err)
+	require.Len(t, state.Resources, 2)
+	switchID := state.Resources["switch.web"].ID
+	serverID := state.Resources["server.web"].ID
+	require.False(t, switchID.IsEmpty())
+	require.False(t, serverID.IsEmpty())
+
+	defer func() {
+		iaas.NewServerOp(caller).Delete(ctx, testutil.TestZone(), serverID) //nolint
+		iaas.NewSwitchOp(caller).Delete(ctx, testutil.TestZone(), switchID) //nolint
+	}()
+
+	server, err := iaas.NewServerOp(caller).Read(ctx, testutil.TestZone(), serverID)
+	require.NoError(t, err)
+	require.Equal(t, name, server.Description)
+	require.Equal(t, switchID, server.Interfaces[0].SwitchID)
+
+	// 2回目はStateに記録されたリソースを更新する
+	manifest.Resources[0].Spec["description"] = "updated"
+	stack = New(caller, manifest, state)
+	stack.StatePath = statePath
+	require.NoError(t, stack.Apply(ctx))
+
+	state, err = LoadState(statePath)
+	require.NoError(t, err)
+	require.Equal(t, switchID, state.Resources["switch.web"].ID)
+	require.Equal(t, serverID, state.Resources["server.web"].ID)
+
+	sw, err := iaas.NewSwitchOp(caller).Read(ctx, testutil.TestZone(), switchID)
+	require.NoError(t, err)
+	require.Equal(t, "updated", sw.Description)
+}
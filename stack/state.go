@@ -0,0 +1,103 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sync"
+
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// State マニフェスト中の論理名と作成済みリソースのIDの対応を保持する
+type State struct {
+	Resources map[string]*ResourceState `json:"resources"`
+
+	mu sync.Mutex
+}
+
+// ResourceState 作成済みリソースの情報
+type ResourceState struct {
+	Type string   `json:"type"`
+	Name string   `json:"name"`
+	Zone string   `json:"zone,omitempty"`
+	ID   types.ID `json:"id"`
+}
+
+// NewState 空のStateを返す
+func NewState() *State {
+	return &State{Resources: make(map[string]*ResourceState)}
+}
+
+// LoadState ファイルからStateを読み込む
+//
+// ファイルが存在しない場合は空のStateを返す
+func LoadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return NewState(), nil
+		}
+		return nil, err
+	}
+	state := NewState()
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Resources == nil {
+		state.Resources = make(map[string]*ResourceState)
+	}
+	return state, nil
+}
+
+// Save Stateをファイルへ書き込む
+func (s *State) Save(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Get キーに対応するリソースの情報を返す。存在しない場合はnilを返す
+func (s *State) Get(key string) *ResourceState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Resources[key]
+}
+
+// Set キーに対応するリソースの情報を記録する
+func (s *State) Set(key string, rs *ResourceState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Resources[key] = rs
+}
+
+func (rs *ResourceState) id() types.ID {
+	if rs == nil {
+		return types.ID(0)
+	}
+	return rs.ID
+}
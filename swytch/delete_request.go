@@ -23,7 +23,8 @@ type DeleteRequest struct {
 	Zone string   `service:"-" validate:"required"`
 	ID   types.ID `service:"-" validate:"required"`
 
-	FailIfNotFound bool `service:"-"`
+	FailIfNotFound   bool `service:"-"`
+	FailIfReferenced bool `service:"-"` // trueの場合、他リソースから参照されている間は削除せずに参照元の一覧を含むエラーを返す
 
 	WaitForRelease        bool `service:"-"` // trueの場合、他リソースから参照されている間は削除を待ち合わせし続ける
 	WaitForReleaseTimeout int  // WaitForReleaseがtrueの場合の待ち時間タイムアウト(デフォルト:1時間)
@@ -0,0 +1,44 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swytch
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwitchService_Errors(t *testing.T) {
+	svc := New(testutil.SingletonAPICaller())
+	zone := testutil.TestZone()
+
+	_, err := svc.Read(&ReadRequest{Zone: zone})
+	require.True(t, errors.Is(err, service.ErrValidationFailed))
+
+	_, err = svc.Update(&UpdateRequest{Zone: zone, ID: types.ID(1)})
+	require.True(t, errors.Is(err, service.ErrNotFound))
+
+	var e *service.Error
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, "switch", e.Kind)
+	require.Equal(t, "update", e.Operation)
+	require.Equal(t, "read", e.Step)
+	require.Equal(t, types.ID(1), e.ID)
+	require.NotNil(t, e.APIError())
+}
@@ -0,0 +1,63 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swytch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwitchService_FindAll(t *testing.T) {
+	svc := New(testutil.SingletonAPICaller())
+	zone := testutil.TestZone()
+
+	for i := 0; i < 5; i++ {
+		_, err := svc.Create(&CreateRequest{
+			Zone: zone,
+			Name: testutil.ResourceName(fmt.Sprintf("service-switch-find-all-%d", i)),
+		})
+		require.NoError(t, err)
+	}
+
+	expected, err := svc.Find(&FindRequest{Zone: zone})
+	require.NoError(t, err)
+	require.True(t, len(expected) >= 5)
+
+	found, err := svc.FindAll(&FindRequest{Zone: zone})
+	require.NoError(t, err)
+	require.ElementsMatch(t, switchIDs(expected), switchIDs(found))
+
+	var names []string
+	svc.FindIterWithContext(context.Background(), &FindRequest{Zone: zone, Count: 2})(func(sw *iaas.Switch, err error) bool {
+		require.NoError(t, err)
+		names = append(names, sw.Name)
+		return len(names) < 3
+	})
+	require.Len(t, names, 3)
+}
+
+func switchIDs(switches []*iaas.Switch) []types.ID {
+	var ids []types.ID
+	for _, sw := range switches {
+		ids = append(ids, sw.ID)
+	}
+	return ids
+}
@@ -0,0 +1,44 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swytch
+
+import (
+	"testing"
+
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwitchService_FindInZones(t *testing.T) {
+	svc := New(testutil.SingletonAPICaller())
+	zones := []string{"is1a", "tk1a"}
+	name := testutil.ResourceName("service-switch-find-in-zones")
+
+	for _, zone := range zones {
+		_, err := svc.Create(&CreateRequest{Zone: zone, Name: name})
+		require.NoError(t, err)
+	}
+
+	found, err := svc.FindInZones(&FindInZonesRequest{Zones: zones, Names: []string{name}})
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	for i, zone := range zones {
+		require.Equal(t, zone, found[i].Zone)
+		require.Equal(t, name, found[i].Value.Name)
+	}
+
+	err = (&FindInZonesRequest{Zones: []string{"is1a", "is1a"}}).Validate()
+	require.Error(t, err)
+}
@@ -0,0 +1,495 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package teardown タグで選択したリソース一式を依存関係を考慮した順序で削除する
+package teardown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/archive"
+	"github.com/sacloud/iaas-service-go/bridge"
+	"github.com/sacloud/iaas-service-go/bulk"
+	"github.com/sacloud/iaas-service-go/database"
+	"github.com/sacloud/iaas-service-go/disk"
+	"github.com/sacloud/iaas-service-go/internet"
+	"github.com/sacloud/iaas-service-go/loadbalancer"
+	"github.com/sacloud/iaas-service-go/mobilegateway"
+	"github.com/sacloud/iaas-service-go/nfs"
+	"github.com/sacloud/iaas-service-go/packetfilter"
+	"github.com/sacloud/iaas-service-go/server"
+	"github.com/sacloud/iaas-service-go/swytch"
+	"github.com/sacloud/iaas-service-go/vpcrouter"
+)
+
+// Kind 削除対象のリソース種別
+type Kind string
+
+// 削除対象となるリソース種別
+//
+// パケットフィルタとブリッジ以外はTeardown.Tagsを全て持つものが対象となる
+const (
+	KindServer        = Kind("server")
+	KindLoadBalancer  = Kind("loadbalancer")
+	KindDatabase      = Kind("database")
+	KindNFS           = Kind("nfs")
+	KindMobileGateway = Kind("mobilegateway")
+	KindVPCRouter     = Kind("vpcrouter")
+	KindDisk          = Kind("disk")
+	KindArchive       = Kind("archive")
+	KindPacketFilter  = Kind("packetfilter")
+	KindSwitch        = Kind("switch")
+	KindBridge        = Kind("bridge")
+	KindInternet      = Kind("internet")
+)
+
+// Phases 削除の順序
+//
+// 同じフェーズのリソースは並行して削除し、フェーズ内で失敗したリソースがある場合は以降のフェーズは実行しない
+var Phases = [][]Kind{
+	{KindServer, KindLoadBalancer, KindDatabase, KindNFS, KindMobileGateway, KindVPCRouter},
+	{KindDisk, KindArchive},
+	{KindPacketFilter, KindSwitch},
+	{KindBridge},
+	{KindInternet},
+}
+
+func phaseOf(kind Kind) int {
+	for i, kinds := range Phases {
+		for _, k := range kinds {
+			if k == kind {
+				return i
+			}
+		}
+	}
+	return len(Phases)
+}
+
+// Resource 削除対象のリソース
+type Resource struct {
+	Kind Kind
+	Zone string
+	ID   types.ID
+	Name string
+	Tags types.Tags
+	// Phase Phasesにおける添字
+	Phase int
+	// Reason 削除対象となった理由、Skippedの場合は削除しない理由
+	Reason string
+	// Owner 設定されている場合はOwnerの削除時に一緒に削除される
+	Owner *Resource
+	// Skipped trueの場合はタグを持たないリソースから利用されているため削除しない
+	Skipped bool
+}
+
+// String fmt.Stringerの実装
+func (r *Resource) String() string {
+	return fmt.Sprintf("%s[%s](%s) in %s", r.Kind, r.ID, r.Name, r.Zone)
+}
+
+// Teardown Tagsを全て持つリソースを検索し、依存関係を考慮した順序で削除する
+//
+// パケットフィルタとブリッジはタグを持たないため、削除対象のリソースからのみ参照されているものを対象とする。
+// 削除対象のサーバに接続されたディスクはサーバと一緒に削除される。
+// タグを持たないサーバやアプライアンスから利用されているスイッチ/ディスクは削除せず、Skippedとして返す。
+type Teardown struct {
+	Caller iaas.APICaller
+	// Zones 対象ゾーンのリスト、デフォルトはiaas.SakuraCloudZones
+	Zones []string
+	// Tags 削除対象を選択するタグ、全てのタグを持つリソースが対象となる
+	Tags types.Tags
+
+	// DryRun trueの場合は削除対象の一覧を返すのみで削除は行わない
+	DryRun bool
+	// Parallelism フェーズ内での同時実行数、省略時はbulk.DefaultParallelism
+	Parallelism int
+	// PerZoneParallelism ゾーンごとの同時実行数、0の場合はゾーンごとの制限を行わない
+	PerZoneParallelism int
+}
+
+// Run 削除対象を検索して削除する
+//
+// 戻り値は削除順に並んだ削除対象のリスト。DryRunがtrueの場合は削除を行わずにリストのみを返す。
+// Skippedのリソースはリストに含まれるが削除しない。
+func (t *Teardown) Run(ctx context.Context) ([]*Resource, error) {
+	resources, err := t.Find(ctx)
+	if err != nil || t.DryRun {
+		return resources, err
+	}
+
+	for phase := range Phases {
+		var targets []*Resource
+		for _, r := range resources {
+			if r.Phase == phase && r.Owner == nil && !r.Skipped {
+				targets = append(targets, r)
+			}
+		}
+		if len(targets) == 0 {
+			continue
+		}
+		_, err := bulk.Run(ctx, targets, bulk.NoResult(t.delete), &bulk.Options{
+			Parallelism:        t.Parallelism,
+			PerZoneParallelism: t.PerZoneParallelism,
+		})
+		if err != nil {
+			return resources, err
+		}
+	}
+	return resources, nil
+}
+
+func (t *Teardown) zones() []string {
+	if len(t.Zones) == 0 {
+		return iaas.SakuraCloudZones
+	}
+	return t.Zones
+}
+
+// Find 削除対象を検索し、削除順に並べて返す
+func (t *Teardown) Find(ctx context.Context) ([]*Resource, error) {
+	if len(t.Tags) == 0 {
+		return nil, service.ValidationError(errors.New("tags is required"))
+	}
+
+	found, err := service.FindInZones(ctx, t.zones(), t.findInZone)
+	if err != nil {
+		return nil, err
+	}
+	var resources []*Resource
+	for _, z := range found {
+		resources = append(resources, z.Value)
+	}
+
+	bridges, err := t.findBridges(ctx, resources)
+	if err != nil {
+		return nil, err
+	}
+	resources = append(resources, bridges...)
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		return resources[i].Phase < resources[j].Phase
+	})
+	return resources, nil
+}
+
+func (t *Teardown) findInZone(ctx context.Context, zone string) ([]*Resource, error) {
+	var resources []*Resource
+	add := func(kind Kind, id types.ID, name string, tags types.Tags, reason string) *Resource {
+		r := &Resource{Kind: kind, Zone: zone, ID: id, Name: name, Tags: tags, Phase: phaseOf(kind), Reason: reason}
+		resources = append(resources, r)
+		return r
+	}
+	const tagged = "tagged"
+
+	// タグを持たないリソースから利用されているスイッチ/ディスク、値は利用しているリソース
+	usedSwitches := make(map[types.ID]string)
+	usedDisks := make(map[types.ID]string)
+	useSwitch := func(id types.ID, user string) {
+		if !id.IsEmpty() {
+			usedSwitches[id] = user
+		}
+	}
+	addUnlessUsed := func(kind Kind, id types.ID, name string, tags types.Tags, used map[types.ID]string) {
+		r := add(kind, id, name, tags, tagged)
+		if user, ok := used[id]; ok {
+			r.Skipped = true
+			r.Reason = "in use by untagged " + user
+		}
+	}
+
+	// パケットフィルタ/スイッチ/ディスクの判定のため、タグを持たないサーバも含めて検索する
+	servers, err := server.New(t.Caller).FindAllWithContext(ctx, &server.FindRequest{Zone: zone})
+	if err != nil {
+		return nil, err
+	}
+	ownedDisks := make(map[types.ID]bool)
+	filters := make(map[types.ID]bool)
+	for _, s := range servers {
+		if !t.match(s.Tags) {
+			user := fmt.Sprintf("server[%s]", s.ID)
+			for _, nic := range s.Interfaces {
+				filters[nic.PacketFilterID] = false
+				useSwitch(nic.SwitchID, user)
+			}
+			for _, d := range s.Disks {
+				usedDisks[d.ID] = user
+			}
+			continue
+		}
+		owner := add(KindServer, s.ID, s.Name, s.Tags, tagged)
+		for _, d := range s.Disks {
+			ownedDisks[d.ID] = true
+			r := add(KindDisk, d.ID, d.Name, nil, fmt.Sprintf("connected to server[%s]", s.ID))
+			r.Owner = owner
+		}
+		for _, nic := range s.Interfaces {
+			if _, ok := filters[nic.PacketFilterID]; !ok && !nic.PacketFilterID.IsEmpty() {
+				filters[nic.PacketFilterID] = true
+			}
+		}
+	}
+
+	// スイッチの判定のため、アプライアンスもタグを持たないものを含めて検索する
+	lbs, err := loadbalancer.New(t.Caller).FindAllWithContext(ctx, &loadbalancer.FindRequest{Zone: zone})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range lbs {
+		if !t.match(v.Tags) {
+			useSwitch(v.SwitchID, fmt.Sprintf("loadbalancer[%s]", v.ID))
+			continue
+		}
+		add(KindLoadBalancer, v.ID, v.Name, v.Tags, tagged)
+	}
+
+	dbs, err := database.New(t.Caller).FindAllWithContext(ctx, &database.FindRequest{Zone: zone})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range dbs {
+		if !t.match(v.Tags) {
+			useSwitch(v.SwitchID, fmt.Sprintf("database[%s]", v.ID))
+			continue
+		}
+		add(KindDatabase, v.ID, v.Name, v.Tags, tagged)
+	}
+
+	nfsList, err := nfs.New(t.Caller).FindAllWithContext(ctx, &nfs.FindRequest{Zone: zone})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range nfsList {
+		if !t.match(v.Tags) {
+			useSwitch(v.SwitchID, fmt.Sprintf("nfs[%s]", v.ID))
+			continue
+		}
+		add(KindNFS, v.ID, v.Name, v.Tags, tagged)
+	}
+
+	mgws, err := mobilegateway.New(t.Caller).FindAllWithContext(ctx, &mobilegateway.FindRequest{Zone: zone})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range mgws {
+		if !t.match(v.Tags) {
+			for _, nic := range v.Interfaces {
+				useSwitch(nic.SwitchID, fmt.Sprintf("mobilegateway[%s]", v.ID))
+			}
+			continue
+		}
+		add(KindMobileGateway, v.ID, v.Name, v.Tags, tagged)
+	}
+
+	routers, err := vpcrouter.New(t.Caller).FindAllWithContext(ctx, &vpcrouter.FindRequest{Zone: zone})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range routers {
+		if !t.match(v.Tags) {
+			for _, nic := range v.Interfaces {
+				useSwitch(nic.SwitchID, fmt.Sprintf("vpcrouter[%s]", v.ID))
+			}
+			continue
+		}
+		add(KindVPCRouter, v.ID, v.Name, v.Tags, tagged)
+	}
+
+	disks, err := disk.New(t.Caller).FindAllWithContext(ctx, &disk.FindRequest{Zone: zone, Tags: t.Tags})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range disks {
+		if !ownedDisks[v.ID] {
+			addUnlessUsed(KindDisk, v.ID, v.Name, v.Tags, usedDisks)
+		}
+	}
+
+	archives, err := archive.New(t.Caller).FindAllWithContext(ctx, &archive.FindRequest{Zone: zone, Tags: t.Tags, Scope: types.Scopes.User})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range archives {
+		add(KindArchive, v.ID, v.Name, v.Tags, tagged)
+	}
+
+	var filterIDs []types.ID
+	for id, used := range filters {
+		if used {
+			filterIDs = append(filterIDs, id)
+		}
+	}
+	sort.Slice(filterIDs, func(i, j int) bool { return filterIDs[i] < filterIDs[j] })
+	for _, id := range filterIDs {
+		pf, err := packetfilter.New(t.Caller).ReadWithContext(ctx, &packetfilter.ReadRequest{Zone: zone, ID: id})
+		if err != nil {
+			return nil, err
+		}
+		add(KindPacketFilter, pf.ID, pf.Name, nil, "used only by servers to be deleted")
+	}
+
+	switches, err := swytch.New(t.Caller).FindAllWithContext(ctx, &swytch.FindRequest{Zone: zone, Tags: t.Tags})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range switches {
+		// ルータのスイッチはルータと一緒に削除される
+		if isRouterSwitch(v) {
+			continue
+		}
+		addUnlessUsed(KindSwitch, v.ID, v.Name, v.Tags, usedSwitches)
+	}
+
+	internets, err := internet.New(t.Caller).FindAllWithContext(ctx, &internet.FindRequest{Zone: zone, Tags: t.Tags})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range internets {
+		add(KindInternet, v.ID, v.Name, v.Tags, tagged)
+	}
+	return resources, nil
+}
+
+// findBridges 削除対象のスイッチのみが接続されているブリッジを返す
+func (t *Teardown) findBridges(ctx context.Context, resources []*Resource) ([]*Resource, error) {
+	switches := make(map[types.ID]bool)
+	for _, r := range resources {
+		if r.Kind == KindSwitch && !r.Skipped {
+			switches[r.ID] = true
+		}
+	}
+
+	seen := make(map[types.ID]bool)
+	var bridges []*Resource
+	for _, r := range resources {
+		if r.Kind != KindSwitch || r.Skipped {
+			continue
+		}
+		sw, err := iaas.NewSwitchOp(t.Caller).Read(ctx, r.Zone, r.ID)
+		if err != nil {
+			return nil, err
+		}
+		if sw.BridgeID.IsEmpty() || seen[sw.BridgeID] {
+			continue
+		}
+		seen[sw.BridgeID] = true
+
+		b, err := bridge.New(t.Caller).ReadWithContext(ctx, &bridge.ReadRequest{Zone: r.Zone, ID: sw.BridgeID})
+		if err != nil {
+			return nil, err
+		}
+		used := false
+		for _, info := range b.BridgeInfo {
+			if !switches[info.ID] {
+				used = true
+				break
+			}
+		}
+		if used {
+			continue
+		}
+		bridges = append(bridges, &Resource{
+			Kind:   KindBridge,
+			Zone:   r.Zone,
+			ID:     b.ID,
+			Name:   b.Name,
+			Phase:  phaseOf(KindBridge),
+			Reason: "connected only to switches to be deleted",
+		})
+	}
+	return bridges, nil
+}
+
+func (t *Teardown) match(tags types.Tags) bool {
+	for _, tag := range t.Tags {
+		found := false
+		for _, v := range tags {
+			if v == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func isRouterSwitch(sw *iaas.Switch) bool {
+	for _, subnet := range sw.Subnets {
+		if subnet.Internet != nil && !subnet.Internet.ID.IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Teardown) delete(ctx context.Context, r *Resource) error {
+	if err := t.deleteResource(ctx, r); err != nil {
+		return fmt.Errorf("%s: %w", r, err)
+	}
+	return nil
+}
+
+// deleteResource rを削除する
+//
+// 前のフェーズで削除したリソースからの参照はAPI上ですぐに解除されない場合があるため、
+// ディスク/パケットフィルタ/スイッチ/ブリッジは参照が解除されるのを待ってから削除する
+func (t *Teardown) deleteResource(ctx context.Context, r *Resource) error {
+	switch r.Kind {
+	case KindServer:
+		return server.New(t.Caller).DeleteWithContext(ctx, &server.DeleteRequest{Zone: r.Zone, ID: r.ID, WithDisks: true, Force: true})
+	case KindLoadBalancer:
+		return loadbalancer.New(t.Caller).DeleteWithContext(ctx, &loadbalancer.DeleteRequest{Zone: r.Zone, ID: r.ID, Force: true})
+	case KindDatabase:
+		return database.New(t.Caller).DeleteWithContext(ctx, &database.DeleteRequest{Zone: r.Zone, ID: r.ID, Force: true})
+	case KindNFS:
+		return nfs.New(t.Caller).DeleteWithContext(ctx, &nfs.DeleteRequest{Zone: r.Zone, ID: r.ID, Force: true})
+	case KindMobileGateway:
+		return mobilegateway.New(t.Caller).DeleteWithContext(ctx, &mobilegateway.DeleteRequest{Zone: r.Zone, ID: r.ID, Force: true})
+	case KindVPCRouter:
+		return vpcrouter.New(t.Caller).DeleteWithContext(ctx, &vpcrouter.DeleteRequest{Zone: r.Zone, ID: r.ID, Force: true})
+	case KindDisk:
+		return disk.New(t.Caller).DeleteWithContext(ctx, &disk.DeleteRequest{Zone: r.Zone, ID: r.ID, WaitForRelease: true})
+	case KindArchive:
+		return archive.New(t.Caller).DeleteWithContext(ctx, &archive.DeleteRequest{Zone: r.Zone, ID: r.ID})
+	case KindPacketFilter:
+		return packetfilter.New(t.Caller).DeleteWithContext(ctx, &packetfilter.DeleteRequest{Zone: r.Zone, ID: r.ID, WaitForRelease: true})
+	case KindSwitch:
+		sw, err := iaas.NewSwitchOp(t.Caller).Read(ctx, r.Zone, r.ID)
+		if err != nil {
+			if iaas.IsNotFoundError(err) {
+				return nil
+			}
+			return err
+		}
+		if !sw.BridgeID.IsEmpty() {
+			if err := iaas.NewSwitchOp(t.Caller).DisconnectFromBridge(ctx, r.Zone, r.ID); err != nil {
+				return err
+			}
+		}
+		return swytch.New(t.Caller).DeleteWithContext(ctx, &swytch.DeleteRequest{Zone: r.Zone, ID: r.ID, WaitForRelease: true})
+	case KindBridge:
+		return bridge.New(t.Caller).DeleteWithContext(ctx, &bridge.DeleteRequest{Zone: r.Zone, ID: r.ID, Zones: t.zones(), WaitForRelease: true})
+	case KindInternet:
+		return internet.New(t.Caller).DeleteWithContext(ctx, &internet.DeleteRequest{Zone: r.Zone, ID: r.ID, Force: true})
+	}
+	return fmt.Errorf("unsupported kind: %s", r.Kind)
+}
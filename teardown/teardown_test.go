@@ -0,0 +1,190 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package teardown_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/iaas-service-go/teardown"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeardown(t *testing.T) {
+	ctx := context.Background()
+	caller := testutil.SingletonAPICaller()
+	zone := testutil.TestZone()
+	tags := types.Tags{"env=pr-1234"}
+
+	createSwitch := func() *iaas.Switch {
+		sw, err := iaas.NewSwitchOp(caller).Create(ctx, zone, &iaas.SwitchCreateRequest{
+			Name: testutil.ResourceName("teardown"),
+			Tags: tags,
+		})
+		require.NoError(t, err)
+		return sw
+	}
+	sw := createSwitch()
+	bridgedSW := createSwitch()
+	lbSW := createSwitch()
+	br, err := iaas.NewBridgeOp(caller).Create(ctx, zone, &iaas.BridgeCreateRequest{
+		Name: testutil.ResourceName("teardown"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, iaas.NewSwitchOp(caller).ConnectToBridge(ctx, zone, bridgedSW.ID, br.ID))
+
+	pf, err := iaas.NewPacketFilterOp(caller).Create(ctx, zone, &iaas.PacketFilterCreateRequest{
+		Name: testutil.ResourceName("teardown"),
+	})
+	require.NoError(t, err)
+	sharedPF, err := iaas.NewPacketFilterOp(caller).Create(ctx, zone, &iaas.PacketFilterCreateRequest{
+		Name: testutil.ResourceName("teardown-shared"),
+	})
+	require.NoError(t, err)
+
+	createServer := func(tags types.Tags, filters ...types.ID) *iaas.Server {
+		s, err := iaas.NewServerOp(caller).Create(ctx, zone, &iaas.ServerCreateRequest{
+			Name:              testutil.ResourceName("teardown"),
+			CPU:               1,
+			MemoryMB:          1024,
+			ConnectedSwitches: []*iaas.ConnectedSwitch{{Scope: types.Scopes.Shared}, {ID: sw.ID}},
+			Tags:              tags,
+		})
+		require.NoError(t, err)
+		for i, id := range filters {
+			require.NoError(t, iaas.NewInterfaceOp(caller).ConnectToPacketFilter(ctx, zone, s.Interfaces[i].ID, id))
+		}
+		return s
+	}
+	tagged := createServer(tags, pf.ID, sharedPF.ID)
+	untagged := createServer(nil, sharedPF.ID)
+	createDisk := func(tags types.Tags, serverID types.ID) *iaas.Disk {
+		d, err := iaas.NewDiskOp(caller).Create(ctx, zone, &iaas.DiskCreateRequest{
+			Name:       testutil.ResourceName("teardown"),
+			DiskPlanID: types.DiskPlans.SSD,
+			SizeMB:     20 * 1024,
+			ServerID:   serverID,
+			Tags:       tags,
+		}, nil)
+		require.NoError(t, err)
+		return d
+	}
+	attached := createDisk(nil, tagged.ID)
+	// タグを持つがタグを持たないサーバに接続されているディスク
+	usedDisk := createDisk(tags, untagged.ID)
+
+	// タグを持つスイッチに接続されたタグを持たないアプライアンス
+	lb, err := iaas.NewLoadBalancerOp(caller).Create(ctx, zone, &iaas.LoadBalancerCreateRequest{
+		SwitchID:       lbSW.ID,
+		PlanID:         types.LoadBalancerPlans.Standard,
+		VRID:           1,
+		IPAddresses:    []string{"192.168.0.11"},
+		NetworkMaskLen: 24,
+		Name:           testutil.ResourceName("teardown"),
+	})
+	require.NoError(t, err)
+
+	td := &teardown.Teardown{
+		Caller: caller,
+		Zones:  []string{zone},
+		Tags:   tags,
+		DryRun: true,
+	}
+	resources, err := td.Run(ctx)
+	require.NoError(t, err)
+
+	found := make(map[types.ID]*teardown.Resource)
+	for i, r := range resources {
+		found[r.ID] = r
+		if i > 0 {
+			require.LessOrEqual(t, resources[i-1].Phase, r.Phase)
+		}
+	}
+	require.Contains(t, found, tagged.ID)
+	require.NotContains(t, found, untagged.ID)
+	require.NotContains(t, found, lb.ID)
+	require.Contains(t, found, pf.ID)
+	require.NotContains(t, found, sharedPF.ID, "packet filter used by an untagged server must be kept")
+	require.Contains(t, found, br.ID)
+	require.Contains(t, found, attached.ID)
+	require.Equal(t, tagged.ID, found[attached.ID].Owner.ID)
+	require.Less(t, found[tagged.ID].Phase, found[bridgedSW.ID].Phase)
+	require.Less(t, found[bridgedSW.ID].Phase, found[br.ID].Phase)
+	require.False(t, found[bridgedSW.ID].Skipped)
+
+	// タグを持たないリソースから利用されているスイッチ/ディスクは削除しない
+	for _, id := range []types.ID{sw.ID, lbSW.ID, usedDisk.ID} {
+		require.Contains(t, found, id)
+		require.True(t, found[id].Skipped, id)
+	}
+	require.Equal(t, "in use by untagged server["+untagged.ID.String()+"]", found[sw.ID].Reason)
+	require.Equal(t, "in use by untagged server["+untagged.ID.String()+"]", found[usedDisk.ID].Reason)
+	require.Equal(t, "in use by untagged loadbalancer["+lb.ID.String()+"]", found[lbSW.ID].Reason)
+
+	// DryRunでは削除しない
+	_, err = iaas.NewServerOp(caller).Read(ctx, zone, tagged.ID)
+	require.NoError(t, err)
+
+	// 利用中のスイッチ/ディスクの解放を待たずに完了する
+	td.DryRun = false
+	runCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	_, err = td.Run(runCtx)
+	require.NoError(t, err)
+	_, err = iaas.NewServerOp(caller).Read(ctx, zone, tagged.ID)
+	require.True(t, iaas.IsNotFoundError(err))
+	_, err = iaas.NewDiskOp(caller).Read(ctx, zone, attached.ID)
+	require.True(t, iaas.IsNotFoundError(err))
+	_, err = iaas.NewPacketFilterOp(caller).Read(ctx, zone, pf.ID)
+	require.True(t, iaas.IsNotFoundError(err))
+	_, err = iaas.NewSwitchOp(caller).Read(ctx, zone, bridgedSW.ID)
+	require.True(t, iaas.IsNotFoundError(err))
+	_, err = iaas.NewBridgeOp(caller).Read(ctx, zone, br.ID)
+	require.True(t, iaas.IsNotFoundError(err))
+
+	// タグを持たないリソースとそれらから参照されているパケットフィルタは残る
+	_, err = iaas.NewServerOp(caller).Read(ctx, zone, untagged.ID)
+	require.NoError(t, err)
+	_, err = iaas.NewPacketFilterOp(caller).Read(ctx, zone, sharedPF.ID)
+	require.NoError(t, err)
+	_, err = iaas.NewSwitchOp(caller).Read(ctx, zone, sw.ID)
+	require.NoError(t, err)
+	_, err = iaas.NewSwitchOp(caller).Read(ctx, zone, lbSW.ID)
+	require.NoError(t, err)
+	_, err = iaas.NewDiskOp(caller).Read(ctx, zone, usedDisk.ID)
+	require.NoError(t, err)
+}
+
+func TestTeardown_defaultZones(t *testing.T) {
+	ctx := context.Background()
+	caller := testutil.SingletonAPICaller()
+	tags := types.Tags{"env=pr-default-zones"}
+
+	sw, err := iaas.NewSwitchOp(caller).Create(ctx, testutil.TestZone(), &iaas.SwitchCreateRequest{
+		Name: testutil.ResourceName("teardown"),
+		Tags: tags,
+	})
+	require.NoError(t, err)
+
+	// Zonesを省略した場合はiaas.SakuraCloudZonesが対象となる
+	resources, err := (&teardown.Teardown{Caller: caller, Tags: tags, DryRun: true}).Find(ctx)
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	require.Equal(t, sw.ID, resources[0].ID)
+}
@@ -25,3 +25,16 @@ const (
 	// UpdateLevelNeedShutdown シャットダウンが必要な変更
 	UpdateLevelNeedShutdown
 )
+
+// String fmt.Stringerの実装
+func (l UpdateLevel) String() string {
+	switch l {
+	case UpdateLevelNone:
+		return "none"
+	case UpdateLevelSimple:
+		return "simple"
+	case UpdateLevelNeedShutdown:
+		return "need-shutdown"
+	}
+	return "unknown"
+}
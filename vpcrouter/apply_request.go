@@ -15,8 +15,15 @@
 package vpcrouter
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/lock"
+	"github.com/sacloud/iaas-service-go/secret"
 	"github.com/sacloud/iaas-service-go/setup"
 	"github.com/sacloud/iaas-service-go/vpcrouter/builder"
 	"github.com/sacloud/packages-go/validate"
@@ -39,10 +46,59 @@ type ApplyRequest struct {
 	RouterSetting         *RouterSetting
 	NoWait                bool
 	BootAfterCreate       bool
+
+	// Lock 指定した場合、既存VPCルータの更新前にタグを用いたロックを取得し、完了後に解放する
+	Lock *lock.Locker `service:"-" json:"-"`
+}
+
+// String 秘匿情報を伏せた文字列表現を返す
+func (req *ApplyRequest) String() string {
+	return secret.Redacted(req)
 }
 
 func (req *ApplyRequest) Validate() error {
-	return validate.New().Struct(req)
+	if err := validate.New().Struct(req); err != nil {
+		return err
+	}
+	return service.ValidateNoSecretPlaceholder(req)
+}
+
+// Preflight 参照先のリソースを確認し、検出した全ての問題を返す
+//
+// リソースの作成や更新は行わない
+func (req *ApplyRequest) Preflight(ctx context.Context, caller iaas.APICaller) error {
+	p := service.NewPreflight()
+	switchOp := iaas.NewSwitchOp(caller)
+
+	p.Ref("ID", "vpc router", req.Zone, req.ID, func() error {
+		_, err := iaas.NewVPCRouterOp(caller).Read(ctx, req.Zone, req.ID)
+		return err
+	})
+	p.Ref("IconID", "icon", "", req.IconID, func() error {
+		_, err := iaas.NewIconOp(caller).Read(ctx, req.IconID)
+		return err
+	})
+	if nic, ok := req.NICSetting.(*builder.PremiumNICSetting); ok {
+		p.Ref("NICSetting.SwitchID", "switch", req.Zone, nic.SwitchID, func() error {
+			_, err := switchOp.Read(ctx, req.Zone, nic.SwitchID)
+			return err
+		})
+	}
+	for i, nic := range req.AdditionalNICSettings {
+		var switchID types.ID
+		switch nic := nic.(type) {
+		case *builder.AdditionalStandardNICSetting:
+			switchID = nic.SwitchID
+		case *builder.AdditionalPremiumNICSetting:
+			switchID = nic.SwitchID
+		}
+		p.Ref(fmt.Sprintf("AdditionalNICSettings[%d].SwitchID", i), "switch", req.Zone, switchID, func() error {
+			_, err := switchOp.Read(ctx, req.Zone, switchID)
+			return err
+		})
+	}
+
+	return p.Err()
 }
 
 // RouterSetting VPCルータの設定
@@ -65,6 +121,11 @@ type RouterSetting struct {
 	ScheduledMaintenance      *iaas.VPCRouterScheduledMaintenance
 }
 
+// String 秘匿情報を伏せた文字列表現を返す
+func (s *RouterSetting) String() string {
+	return secret.Redacted(s)
+}
+
 func (req *ApplyRequest) Builder(caller iaas.APICaller) *builder.Builder {
 	return &builder.Builder{
 		ID:   req.ID,
@@ -111,3 +172,44 @@ func (req *ApplyRequest) routerSetting() *builder.RouterSetting {
 		ScheduledMaintenance:      req.RouterSetting.ScheduledMaintenance,
 	}
 }
+
+// UnmarshalJSON NICSetting/AdditionalNICSettingsをPlanIDに応じた型として読み込む
+func (req *ApplyRequest) UnmarshalJSON(data []byte) error {
+	type alias ApplyRequest
+	tmp := &struct {
+		*alias
+		NICSetting            json.RawMessage
+		AdditionalNICSettings []json.RawMessage
+	}{alias: (*alias)(req)}
+	if err := json.Unmarshal(data, tmp); err != nil {
+		return err
+	}
+
+	isStandard := req.PlanID == types.VPCRouterPlans.Standard
+	if len(tmp.NICSetting) > 0 && string(tmp.NICSetting) != "null" {
+		if isStandard {
+			req.NICSetting = &builder.StandardNICSetting{}
+		} else {
+			nic := &builder.PremiumNICSetting{}
+			if err := json.Unmarshal(tmp.NICSetting, nic); err != nil {
+				return err
+			}
+			req.NICSetting = nic
+		}
+	}
+
+	req.AdditionalNICSettings = nil
+	for _, raw := range tmp.AdditionalNICSettings {
+		var nic builder.AdditionalNICSettingHolder
+		if isStandard {
+			nic = &builder.AdditionalStandardNICSetting{}
+		} else {
+			nic = &builder.AdditionalPremiumNICSetting{}
+		}
+		if err := json.Unmarshal(raw, nic); err != nil {
+			return err
+		}
+		req.AdditionalNICSettings = append(req.AdditionalNICSettings, nic)
+	}
+	return nil
+}
@@ -18,6 +18,9 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/hook"
+	"github.com/sacloud/iaas-service-go/lock"
 )
 
 func (s *Service) Apply(req *ApplyRequest) (*iaas.VPCRouter, error) {
@@ -25,9 +28,35 @@ func (s *Service) Apply(req *ApplyRequest) (*iaas.VPCRouter, error) {
 }
 
 func (s *Service) ApplyWithContext(ctx context.Context, req *ApplyRequest) (*iaas.VPCRouter, error) {
+	return service.Invoke(ctx, s.interceptors, "vpcrouter", "apply", req, s.apply)
+}
+
+func (s *Service) apply(ctx context.Context, req *ApplyRequest) (*iaas.VPCRouter, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
-	return req.Builder(s.caller).Build(ctx)
+	builder := req.Builder(s.caller)
+	if req.Lock != nil && !req.ID.IsEmpty() {
+		held, err := req.Lock.Acquire(ctx, lock.NewVPCRouterTarget(s.caller, req.Zone, req.ID))
+		if err != nil {
+			return nil, err
+		}
+		// 解放に失敗した場合もリースは有効期限を過ぎると失効するためエラーとしない
+		defer held.Release(ctx) //nolint:errcheck
+		// 更新中にロックが外れないよう、更新後のタグにもリースを含める
+		builder.Tags = lock.WithLease(builder.Tags, held.Lease)
+
+		// 変更を伴う処理の前ごとにリースが他の保持者に上書きされていないか確認する
+		verify := func(ctx context.Context, _ *hook.Event) error {
+			return held.Verify(ctx)
+		}
+		builder.Hooks = hook.New().
+			On(hook.BeforeUpdateSettings, verify).
+			On(hook.BeforeBoot, verify)
+		if err := held.Verify(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return builder.Build(ctx)
 }
@@ -18,12 +18,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/accessor"
 	"github.com/sacloud/iaas-api-go/helper/power"
 	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/checkpoint"
+	"github.com/sacloud/iaas-service-go/hook"
 	setup2 "github.com/sacloud/iaas-service-go/setup"
 )
 
@@ -45,6 +47,18 @@ type Builder struct {
 	SetupOptions *setup2.Options
 	Client       iaas.VPCRouterAPI
 	NoWait       bool
+
+	// Checkpoint 作成時の進捗の記録先
+	//
+	// 指定した場合、作成したVPCルータのIDと完了したステップ(スイッチ接続/設定投入/起動)を記録する。
+	// 同じチェックポイントで再度Buildを行うと、完了済みのステップを省略して再開する。
+	Checkpoint *checkpoint.Checkpoint
+
+	// Hooks 処理中の各箇所で呼び出すフック
+	//
+	// hook.BeforeUpdateSettings/hook.AfterConfig/hook.BeforeBoot(Build/Update)を呼び出す。
+	// フックがエラーを返した場合はそれ以降の処理を中断する
+	Hooks *hook.Hooks
 }
 
 // RouterSetting VPCルータの設定
@@ -191,24 +205,21 @@ func (b *Builder) create(ctx context.Context, zone string) (*iaas.VPCRouter, err
 		return nil, err
 	}
 
+	if err := b.Checkpoint.Load(ctx); err != nil {
+		return nil, err
+	}
+
 	builder := &setup2.RetryableSetup{
 		Create: func(ctx context.Context, zone string) (accessor.ID, error) {
-			return b.Client.Create(ctx, zone, &iaas.VPCRouterCreateRequest{
-				Name:        b.Name,
-				Description: b.Description,
-				Tags:        b.Tags,
-				IconID:      b.IconID,
-				PlanID:      b.PlanID,
-				Switch:      b.NICSetting.getConnectedSwitch(),
-				IPAddresses: b.NICSetting.getIPAddresses(),
-				Version:     b.Version,
-				Settings: &iaas.VPCRouterSetting{
-					VRID:                      b.RouterSetting.VRID,
-					InternetConnectionEnabled: b.RouterSetting.InternetConnectionEnabled,
-					Interfaces:                b.getInitInterfaceSettings(),
-					SyslogHost:                b.RouterSetting.SyslogHost,
+			vpcRouter, _, err := checkpoint.CreateOrResume(ctx, b.Checkpoint, "vpcrouter",
+				func(id types.ID) (*iaas.VPCRouter, error) {
+					return b.Client.Read(ctx, zone, id)
 				},
-			})
+				func() (*iaas.VPCRouter, error) {
+					return b.createVPCRouter(ctx, zone)
+				},
+			)
+			return vpcRouter, err
 		},
 		ProvisionBeforeUp: func(ctx context.Context, zone string, id types.ID, target interface{}) error {
 			if b.NoWait {
@@ -219,54 +230,62 @@ func (b *Builder) create(ctx context.Context, zone string) (*iaas.VPCRouter, err
 			// スイッチの接続
 			for _, additionalNIC := range b.AdditionalNICSettings {
 				switchID, index := additionalNIC.getSwitchInfo()
-				if err := b.Client.ConnectToSwitch(ctx, zone, id, index, switchID); err != nil {
+				err := b.Checkpoint.Run(ctx, fmt.Sprintf("switch/%d", index), func() error {
+					return b.Client.ConnectToSwitch(ctx, zone, id, index, switchID)
+				})
+				if err != nil {
 					return err
 				}
 			}
 
-			// [HACK] スイッチ接続直後だとエラーになることがあるため数秒待つ
-			time.Sleep(b.SetupOptions.NICUpdateWaitDuration)
-
-			// 残りの設定の投入
-			_, err := b.Client.UpdateSettings(ctx, zone, id, &iaas.VPCRouterUpdateSettingsRequest{
-				Settings: &iaas.VPCRouterSetting{
-					VRID:                      b.RouterSetting.VRID,
-					InternetConnectionEnabled: b.RouterSetting.InternetConnectionEnabled,
-					Interfaces:                b.getInterfaceSettings(),
-					StaticNAT:                 b.RouterSetting.StaticNAT,
-					PortForwarding:            b.RouterSetting.PortForwarding,
-					Firewall:                  b.RouterSetting.Firewall,
-					DHCPServer:                b.RouterSetting.DHCPServer,
-					DHCPStaticMapping:         b.RouterSetting.DHCPStaticMapping,
-					DNSForwarding:             b.RouterSetting.DNSForwarding,
-					PPTPServer:                b.RouterSetting.PPTPServer,
-					PPTPServerEnabled:         b.RouterSetting.PPTPServer != nil,
-					L2TPIPsecServer:           b.RouterSetting.L2TPIPsecServer,
-					L2TPIPsecServerEnabled:    b.RouterSetting.L2TPIPsecServer != nil,
-					WireGuard:                 b.RouterSetting.WireGuard,
-					WireGuardEnabled:          b.RouterSetting.WireGuard != nil,
-					RemoteAccessUsers:         b.RouterSetting.RemoteAccessUsers,
-					SiteToSiteIPsecVPN:        b.RouterSetting.SiteToSiteIPsecVPN,
-					StaticRoute:               b.RouterSetting.StaticRoute,
-					SyslogHost:                b.RouterSetting.SyslogHost,
-					ScheduledMaintenance:      b.RouterSetting.ScheduledMaintenance,
-				},
-				SettingsHash: vpcRouter.SettingsHash,
+			err := b.Checkpoint.Run(ctx, "settings", func() error {
+				// [HACK] スイッチ接続直後だとエラーになることがあるため数秒待つ
+				if err := setup2.Sleep(ctx, b.SetupOptions.NICUpdateWaitDuration); err != nil {
+					return err
+				}
+
+				if err := b.runHook(ctx, hook.BeforeUpdateSettings, zone, id, vpcRouter); err != nil {
+					return err
+				}
+
+				// 残りの設定の投入
+				_, err := b.Client.UpdateSettings(ctx, zone, id, &iaas.VPCRouterUpdateSettingsRequest{
+					Settings:     b.desiredSettings(),
+					SettingsHash: vpcRouter.SettingsHash,
+				})
+				if err != nil {
+					return err
+				}
+				return b.Client.Config(ctx, zone, id)
 			})
 			if err != nil {
 				return err
 			}
-			if err := b.Client.Config(ctx, zone, id); err != nil {
+			err = b.Checkpoint.Run(ctx, "hook/after_config", func() error {
+				return b.runHook(ctx, hook.AfterConfig, zone, id, vpcRouter)
+			})
+			if err != nil {
 				return err
 			}
 
 			if b.SetupOptions.BootAfterBuild {
-				return power.BootVPCRouter(ctx, b.Client, zone, id)
+				return b.Checkpoint.Run(ctx, "boot", func() error {
+					if err := b.runHook(ctx, hook.BeforeBoot, zone, id, vpcRouter); err != nil {
+						return err
+					}
+					return power.BootVPCRouter(ctx, b.Client, zone, id)
+				})
 			}
 			return nil
 		},
 		Delete: func(ctx context.Context, zone string, id types.ID) error {
-			return b.Client.Delete(ctx, zone, id)
+			if err := b.Client.Delete(ctx, zone, id); err != nil {
+				return err
+			}
+			return b.Checkpoint.Reset(ctx)
+		},
+		Shutdown: func(ctx context.Context, zone string, id types.ID) error {
+			return power.ShutdownVPCRouter(ctx, b.Client, zone, id, true)
 		},
 		Read: func(ctx context.Context, zone string, id types.ID) (interface{}, error) {
 			return b.Client.Read(ctx, zone, id)
@@ -282,7 +301,7 @@ func (b *Builder) create(ctx context.Context, zone string) (*iaas.VPCRouter, err
 		vpcRouter = result.(*iaas.VPCRouter)
 	}
 	if err != nil {
-		return vpcRouter, err
+		return vpcRouter, b.Checkpoint.ResetOnRollback(ctx, err)
 	}
 
 	// refresh
@@ -293,6 +312,25 @@ func (b *Builder) create(ctx context.Context, zone string) (*iaas.VPCRouter, err
 	return refreshed, nil
 }
 
+func (b *Builder) createVPCRouter(ctx context.Context, zone string) (*iaas.VPCRouter, error) {
+	return b.Client.Create(ctx, zone, &iaas.VPCRouterCreateRequest{
+		Name:        b.Name,
+		Description: b.Description,
+		Tags:        b.Tags,
+		IconID:      b.IconID,
+		PlanID:      b.PlanID,
+		Switch:      b.NICSetting.getConnectedSwitch(),
+		IPAddresses: b.NICSetting.getIPAddresses(),
+		Version:     b.Version,
+		Settings: &iaas.VPCRouterSetting{
+			VRID:                      b.RouterSetting.VRID,
+			InternetConnectionEnabled: b.RouterSetting.InternetConnectionEnabled,
+			Interfaces:                b.getInitInterfaceSettings(),
+			SyslogHost:                b.RouterSetting.SyslogHost,
+		},
+	})
+}
+
 func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.VPCRouter, error) {
 	b.init()
 
@@ -310,11 +348,14 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.V
 	if err != nil {
 		return nil, err
 	}
+	if !b.plan(vpcRouter).HasChanges() {
+		return vpcRouter, nil
+	}
 
 	isNeedRestart := false
 	if vpcRouter.InstanceStatus.IsUp() && isNeedShutdown {
 		if b.NoWait {
-			return nil, errors.New("NoWait option is not available due to the need to shut down")
+			return nil, service.NewError(service.ErrNeedsShutdown, errors.New("NoWait option is not available due to the need to shut down"))
 		}
 
 		isNeedRestart = true
@@ -355,35 +396,19 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.V
 		}
 	}
 	// [HACK] スイッチ接続直後だとエラーになることがあるため数秒待つ
-	time.Sleep(b.SetupOptions.NICUpdateWaitDuration)
+	if err := setup2.Sleep(ctx, b.SetupOptions.NICUpdateWaitDuration); err != nil {
+		return nil, err
+	}
 
+	if err := b.runHook(ctx, hook.BeforeUpdateSettings, zone, id, vpcRouter); err != nil {
+		return nil, err
+	}
 	_, err = b.Client.Update(ctx, zone, id, &iaas.VPCRouterUpdateRequest{
-		Name:        b.Name,
-		Description: b.Description,
-		Tags:        b.Tags,
-		IconID:      b.IconID,
-		Settings: &iaas.VPCRouterSetting{
-			VRID:                      b.RouterSetting.VRID,
-			InternetConnectionEnabled: b.RouterSetting.InternetConnectionEnabled,
-			Interfaces:                b.getInterfaceSettings(),
-			StaticNAT:                 b.RouterSetting.StaticNAT,
-			PortForwarding:            b.RouterSetting.PortForwarding,
-			Firewall:                  b.RouterSetting.Firewall,
-			DHCPServer:                b.RouterSetting.DHCPServer,
-			DHCPStaticMapping:         b.RouterSetting.DHCPStaticMapping,
-			DNSForwarding:             b.RouterSetting.DNSForwarding,
-			PPTPServer:                b.RouterSetting.PPTPServer,
-			PPTPServerEnabled:         b.RouterSetting.PPTPServer != nil,
-			L2TPIPsecServer:           b.RouterSetting.L2TPIPsecServer,
-			L2TPIPsecServerEnabled:    b.RouterSetting.L2TPIPsecServer != nil,
-			WireGuard:                 b.RouterSetting.WireGuard,
-			WireGuardEnabled:          b.RouterSetting.WireGuard != nil,
-			RemoteAccessUsers:         b.RouterSetting.RemoteAccessUsers,
-			SiteToSiteIPsecVPN:        b.RouterSetting.SiteToSiteIPsecVPN,
-			StaticRoute:               b.RouterSetting.StaticRoute,
-			SyslogHost:                b.RouterSetting.SyslogHost,
-			ScheduledMaintenance:      b.RouterSetting.ScheduledMaintenance,
-		},
+		Name:         b.Name,
+		Description:  b.Description,
+		Tags:         b.Tags,
+		IconID:       b.IconID,
+		Settings:     b.desiredSettings(),
 		SettingsHash: vpcRouter.SettingsHash,
 	})
 	if err != nil {
@@ -393,8 +418,14 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.V
 	if err := b.Client.Config(ctx, zone, id); err != nil {
 		return nil, err
 	}
+	if err := b.runHook(ctx, hook.AfterConfig, zone, id, vpcRouter); err != nil {
+		return nil, err
+	}
 
 	if isNeedRestart {
+		if err := b.runHook(ctx, hook.BeforeBoot, zone, id, vpcRouter); err != nil {
+			return nil, err
+		}
 		if err := power.BootVPCRouter(ctx, b.Client, zone, id); err != nil {
 			return nil, err
 		}
@@ -407,6 +438,41 @@ func (b *Builder) update(ctx context.Context, zone string, id types.ID) (*iaas.V
 	return vpcRouter, err
 }
 
+func (b *Builder) runHook(ctx context.Context, point hook.Point, zone string, id types.ID, vpcRouter *iaas.VPCRouter) error {
+	return b.Hooks.Run(ctx, &hook.Event{
+		Point:    point,
+		Zone:     zone,
+		Resource: "vpcrouter",
+		ID:       id,
+		Target:   vpcRouter,
+	})
+}
+
+func (b *Builder) desiredSettings() *iaas.VPCRouterSetting {
+	return &iaas.VPCRouterSetting{
+		VRID:                      b.RouterSetting.VRID,
+		InternetConnectionEnabled: b.RouterSetting.InternetConnectionEnabled,
+		Interfaces:                b.getInterfaceSettings(),
+		StaticNAT:                 b.RouterSetting.StaticNAT,
+		PortForwarding:            b.RouterSetting.PortForwarding,
+		Firewall:                  b.RouterSetting.Firewall,
+		DHCPServer:                b.RouterSetting.DHCPServer,
+		DHCPStaticMapping:         b.RouterSetting.DHCPStaticMapping,
+		DNSForwarding:             b.RouterSetting.DNSForwarding,
+		PPTPServer:                b.RouterSetting.PPTPServer,
+		PPTPServerEnabled:         b.RouterSetting.PPTPServer != nil,
+		L2TPIPsecServer:           b.RouterSetting.L2TPIPsecServer,
+		L2TPIPsecServerEnabled:    b.RouterSetting.L2TPIPsecServer != nil,
+		WireGuard:                 b.RouterSetting.WireGuard,
+		WireGuardEnabled:          b.RouterSetting.WireGuard != nil,
+		RemoteAccessUsers:         b.RouterSetting.RemoteAccessUsers,
+		SiteToSiteIPsecVPN:        b.RouterSetting.SiteToSiteIPsecVPN,
+		StaticRoute:               b.RouterSetting.StaticRoute,
+		SyslogHost:                b.RouterSetting.SyslogHost,
+		ScheduledMaintenance:      b.RouterSetting.ScheduledMaintenance,
+	}
+}
+
 func (b *Builder) collectUpdateInfo(vpcRouter *iaas.VPCRouter) (isNeedShutdown bool, err error) {
 	// プランの変更はエラーとする
 	if vpcRouter.PlanID != b.PlanID {
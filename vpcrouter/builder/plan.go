@@ -0,0 +1,96 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/lock"
+)
+
+// Plan Build時の変更内容を算出する
+//
+// IDが空の場合は新規作成を表すPlanを返す。APIによるリソースの変更は行わない。
+func (b *Builder) Plan(ctx context.Context) (*service.Plan, error) {
+	b.init()
+
+	if b.ID.IsEmpty() {
+		return service.NewCreatePlan(map[string]interface{}{
+			"Name":                  b.Name,
+			"Description":           b.Description,
+			"Tags":                  b.Tags,
+			"IconID":                b.IconID,
+			"PlanID":                b.PlanID,
+			"Version":               b.Version,
+			"AdditionalNICSettings": len(b.AdditionalNICSettings),
+			"RouterSetting":         b.desiredSettings(),
+		}), nil
+	}
+
+	vpcRouter, err := b.Client.Read(ctx, b.Zone, b.ID)
+	if err != nil {
+		return nil, err
+	}
+	return b.plan(vpcRouter), nil
+}
+
+func (b *Builder) plan(vpcRouter *iaas.VPCRouter) *service.Plan {
+	plan := service.NewPlan()
+
+	plan.Diff("Name", vpcRouter.Name, b.Name)
+	plan.Diff("Description", vpcRouter.Description, b.Description)
+	// ロックを表すタグは差分として扱わない
+	plan.Diff("Tags", lock.StripTags(vpcRouter.Tags), lock.StripTags(b.Tags))
+	plan.Diff("IconID", vpcRouter.IconID, b.IconID)
+
+	plan.DiffReplace("PlanID", vpcRouter.PlanID, b.PlanID)
+	if b.Version != 0 {
+		plan.DiffReplace("Version", vpcRouter.Version, b.Version)
+	}
+
+	// スイッチの変更/削除/増設は再起動が必要
+	for _, iface := range vpcRouter.Interfaces {
+		if iface.Index == 0 {
+			continue
+		}
+		plan.DiffNeedShutdown(
+			fmt.Sprintf("AdditionalNICSettings[%d].SwitchID", iface.Index),
+			iface.SwitchID,
+			b.findAdditionalSwitchSettingByIndex(iface.Index),
+		)
+	}
+	for _, nic := range b.AdditionalNICSettings {
+		switchID, index := nic.getSwitchInfo()
+		if b.findInterfaceByIndex(vpcRouter, index) == nil {
+			plan.DiffNeedShutdown(fmt.Sprintf("AdditionalNICSettings[%d].SwitchID", index), nil, switchID)
+		}
+	}
+
+	current := vpcRouter.Settings
+	if current == nil {
+		current = &iaas.VPCRouterSetting{}
+	}
+	desired := b.desiredSettings()
+	cv, dv := reflect.ValueOf(current).Elem(), reflect.ValueOf(desired).Elem()
+	for i := 0; i < cv.NumField(); i++ {
+		name := cv.Type().Field(i).Name
+		plan.Diff("RouterSetting."+name, cv.Field(i).Interface(), dv.Field(i).Interface())
+	}
+	return plan
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) CreateStandard(req *CreateStandardRequest) (*iaas.VPCRouter, error) {
@@ -25,8 +26,12 @@ func (s *Service) CreateStandard(req *CreateStandardRequest) (*iaas.VPCRouter, e
 }
 
 func (s *Service) CreateStandardWithContext(ctx context.Context, req *CreateStandardRequest) (*iaas.VPCRouter, error) {
+	return service.Invoke(ctx, s.interceptors, "vpcrouter", "create_standard", req, s.createStandard)
+}
+
+func (s *Service) createStandard(ctx context.Context, req *CreateStandardRequest) (*iaas.VPCRouter, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	return s.ApplyWithContext(ctx, req.ApplyRequest())
@@ -0,0 +1,143 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"context"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/vpcrouter/builder"
+)
+
+func (s *Service) Export(req *ExportRequest) (*ApplyRequest, error) {
+	return s.ExportWithContext(context.Background(), req)
+}
+
+func (s *Service) ExportWithContext(ctx context.Context, req *ExportRequest) (*ApplyRequest, error) {
+	return service.Invoke(ctx, s.interceptors, "vpcrouter", "export", req, s.export)
+}
+
+func (s *Service) export(ctx context.Context, req *ExportRequest) (*ApplyRequest, error) {
+	if err := req.Validate(); err != nil {
+		return nil, service.ValidationError(err)
+	}
+
+	current, err := iaas.NewVPCRouterOp(s.caller).Read(ctx, req.Zone, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := &ApplyRequest{
+		Zone:            req.Zone,
+		ID:              current.ID,
+		Name:            current.Name,
+		Description:     current.Description,
+		Tags:            current.Tags,
+		IconID:          current.IconID,
+		PlanID:          current.PlanID,
+		Version:         current.Version,
+		BootAfterCreate: current.InstanceStatus.IsUp(),
+	}
+
+	settings := current.Settings
+	if settings == nil {
+		settings = &iaas.VPCRouterSetting{}
+	}
+	isStandard := current.PlanID == types.VPCRouterPlans.Standard
+	if isStandard {
+		exported.NICSetting = &builder.StandardNICSetting{}
+	}
+	for _, nic := range settings.Interfaces {
+		switchID := exportedSwitchID(current, nic.Index)
+		switch {
+		case nic.Index == 0 && !isStandard:
+			exported.NICSetting = &builder.PremiumNICSetting{
+				SwitchID:         switchID,
+				IPAddresses:      nic.IPAddress,
+				VirtualIPAddress: nic.VirtualIPAddress,
+				IPAliases:        nic.IPAliases,
+			}
+		case nic.Index == 0:
+			// スタンダードプランのeth0は共有セグメントに接続されるため設定不要
+		case isStandard:
+			ipAddress := ""
+			if len(nic.IPAddress) > 0 {
+				ipAddress = nic.IPAddress[0]
+			}
+			exported.AdditionalNICSettings = append(exported.AdditionalNICSettings, &builder.AdditionalStandardNICSetting{
+				SwitchID:       switchID,
+				IPAddress:      ipAddress,
+				NetworkMaskLen: nic.NetworkMaskLen,
+				Index:          nic.Index,
+			})
+		default:
+			exported.AdditionalNICSettings = append(exported.AdditionalNICSettings, &builder.AdditionalPremiumNICSetting{
+				SwitchID:         switchID,
+				IPAddresses:      nic.IPAddress,
+				VirtualIPAddress: nic.VirtualIPAddress,
+				NetworkMaskLen:   nic.NetworkMaskLen,
+				Index:            nic.Index,
+			})
+		}
+	}
+
+	exported.RouterSetting = &RouterSetting{
+		VRID:                      settings.VRID,
+		InternetConnectionEnabled: settings.InternetConnectionEnabled,
+		StaticNAT:                 settings.StaticNAT,
+		PortForwarding:            settings.PortForwarding,
+		Firewall:                  settings.Firewall,
+		DHCPServer:                settings.DHCPServer,
+		DHCPStaticMapping:         settings.DHCPStaticMapping,
+		DNSForwarding:             settings.DNSForwarding,
+		PPTPServer:                settings.PPTPServer,
+		L2TPIPsecServer:           settings.L2TPIPsecServer,
+		WireGuard:                 settings.WireGuard,
+		RemoteAccessUsers:         settings.RemoteAccessUsers,
+		SiteToSiteIPsecVPN:        settings.SiteToSiteIPsecVPN,
+		StaticRoute:               settings.StaticRoute,
+		SyslogHost:                settings.SyslogHost,
+		ScheduledMaintenance:      settings.ScheduledMaintenance,
+	}
+	maskRouterSecrets(exported.RouterSetting)
+	return exported, nil
+}
+
+func exportedSwitchID(vpcRouter *iaas.VPCRouter, index int) types.ID {
+	for _, iface := range vpcRouter.Interfaces {
+		if iface.Index == index {
+			return iface.SwitchID
+		}
+	}
+	return types.ID(0)
+}
+
+func maskRouterSecrets(rs *RouterSetting) {
+	if rs.L2TPIPsecServer != nil && rs.L2TPIPsecServer.PreSharedSecret != "" {
+		rs.L2TPIPsecServer.PreSharedSecret = service.SecretPlaceholder
+	}
+	for _, user := range rs.RemoteAccessUsers {
+		user.Password = service.SecretPlaceholder
+	}
+	if rs.SiteToSiteIPsecVPN != nil {
+		for _, c := range rs.SiteToSiteIPsecVPN.Config {
+			if c.PreSharedSecret != "" {
+				c.PreSharedSecret = service.SecretPlaceholder
+			}
+		}
+	}
+}
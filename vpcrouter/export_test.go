@@ -0,0 +1,88 @@
+// Copyright 2022-2025 The sacloud/iaas-service-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vpcrouter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/testutil"
+	"github.com/sacloud/iaas-api-go/types"
+	service "github.com/sacloud/iaas-service-go"
+	"github.com/sacloud/iaas-service-go/setup"
+	vpcRouterBuilder "github.com/sacloud/iaas-service-go/vpcrouter/builder"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVPCRouterService_Export(t *testing.T) {
+	ctx := context.Background()
+	caller := testutil.SingletonAPICaller()
+	zone := testutil.TestZone()
+	name := testutil.ResourceName("vpc-router-service-export")
+
+	createReq := &CreateStandardRequest{
+		Zone:        zone,
+		Name:        name,
+		Description: "desc",
+		Tags:        types.Tags{"tag1", "tag2"},
+		RouterSetting: &RouterSetting{
+			InternetConnectionEnabled: true,
+			RemoteAccessUsers: []*iaas.VPCRouterRemoteAccessUser{
+				{
+					UserName: "username",
+					Password: "password",
+				},
+			},
+		},
+	}
+	builder := createReq.ApplyRequest().Builder(caller)
+	if !testutil.IsAccTest() {
+		builder.SetupOptions = &setup.Options{
+			NICUpdateWaitDuration:     time.Millisecond,
+			ProvisioningRetryInterval: time.Millisecond,
+			DeleteRetryInterval:       time.Millisecond,
+			PollingInterval:           time.Millisecond,
+		}
+	}
+	vpcRouter, err := builder.Build(ctx)
+	require.NoError(t, err)
+	defer iaas.NewVPCRouterOp(caller).Delete(ctx, zone, vpcRouter.ID) //nolint
+
+	exported, err := New(caller).ExportWithContext(ctx, &ExportRequest{Zone: zone, ID: vpcRouter.ID})
+	require.NoError(t, err)
+	require.Equal(t, vpcRouter.ID, exported.ID)
+	require.Equal(t, name, exported.Name)
+	require.Equal(t, types.VPCRouterPlans.Standard, exported.PlanID)
+	require.Equal(t, &vpcRouterBuilder.StandardNICSetting{}, exported.NICSetting)
+	require.Equal(t, service.SecretPlaceholder, exported.RouterSetting.RemoteAccessUsers[0].Password)
+
+	// YAMLを経由してもNIC設定の型が復元されること
+	data, err := service.MarshalRequestYAML(exported)
+	require.NoError(t, err)
+
+	restored := &ApplyRequest{}
+	require.NoError(t, service.UnmarshalRequestYAML(data, restored))
+	require.Equal(t, exported, restored)
+
+	// シークレットを置き換えないままではApplyできない
+	err = restored.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "RouterSetting.RemoteAccessUsers[0].Password")
+
+	restored.RouterSetting.RemoteAccessUsers[0].Password = "password"
+	require.NoError(t, restored.Validate())
+}
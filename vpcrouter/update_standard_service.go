@@ -16,9 +16,9 @@ package vpcrouter
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/sacloud/iaas-api-go"
+	service "github.com/sacloud/iaas-service-go"
 )
 
 func (s *Service) UpdateStandard(req *UpdateStandardRequest) (*iaas.VPCRouter, error) {
@@ -26,13 +26,17 @@ func (s *Service) UpdateStandard(req *UpdateStandardRequest) (*iaas.VPCRouter, e
 }
 
 func (s *Service) UpdateStandardWithContext(ctx context.Context, req *UpdateStandardRequest) (*iaas.VPCRouter, error) {
+	return service.Invoke(ctx, s.interceptors, "vpcrouter", "update_standard", req, s.updateStandard)
+}
+
+func (s *Service) updateStandard(ctx context.Context, req *UpdateStandardRequest) (*iaas.VPCRouter, error) {
 	if err := req.Validate(); err != nil {
-		return nil, err
+		return nil, service.ValidationError(err)
 	}
 
 	applyRequest, err := req.ApplyRequest(ctx, s.caller)
 	if err != nil {
-		return nil, fmt.Errorf("processing request parameter failed: %s", err)
+		return nil, service.StepError("process request parameter", err)
 	}
 
 	return s.ApplyWithContext(ctx, applyRequest)